@@ -41,6 +41,9 @@ func main() {
 }
 
 func core(logE *logrus.Entry, rt *runtime.Runtime) error {
+	if err := rt.Validate(); err != nil {
+		return err //nolint:wrapcheck
+	}
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 	return cli.Run(ctx, &util.Param{ //nolint:wrapcheck
@@ -3,4 +3,5 @@ package asset
 const (
 	osDarwin  = "darwin"
 	osWindows = "windows"
+	libcMusl  = "musl"
 )
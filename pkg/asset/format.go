@@ -1,46 +1,49 @@
 package asset
 
 import (
+	"slices"
 	"strings"
 )
 
 const formatRaw string = "raw"
 
+// formats is the list of archive formats recognized from the extension of asset names.
+var formats = []string{ //nolint:gochecknoglobals
+	"tar.br",
+	"tar.bz2",
+	"tar.gz",
+	"tar.lz4",
+	"tar.sz",
+	"tar.xz",
+	"tbr",
+	"tbz",
+	"tbz2",
+	"tgz",
+	"tlz4",
+	"tsz",
+	"txz",
+
+	"tar.zst",
+
+	"zip",
+	"gz",
+	"bz2",
+	"lz4",
+	"sz",
+	"xz",
+	"zst",
+
+	"dmg",
+	"pkg",
+
+	"rar",
+	"tar",
+}
+
 // RemoveExtFromAsset removes file extensions from asset names and returns the format.
 // It recognizes various archive formats including tar variants, compression formats,
 // and platform-specific formats. Returns the asset name without extension and the format.
 func RemoveExtFromAsset(assetName string) (string, string) {
-	formats := []string{
-		"tar.br",
-		"tar.bz2",
-		"tar.gz",
-		"tar.lz4",
-		"tar.sz",
-		"tar.xz",
-		"tbr",
-		"tbz",
-		"tbz2",
-		"tgz",
-		"tlz4",
-		"tsz",
-		"txz",
-
-		"tar.zst",
-
-		"zip",
-		"gz",
-		"bz2",
-		"lz4",
-		"sz",
-		"xz",
-		"zst",
-
-		"dmg",
-		"pkg",
-
-		"rar",
-		"tar",
-	}
 	for _, format := range formats {
 		if s, ok := strings.CutSuffix(assetName, "."+format); ok {
 			return s, format
@@ -55,3 +58,12 @@ func getFormat(assetName string) string {
 	_, format := RemoveExtFromAsset(assetName)
 	return format
 }
+
+// IsKnownFormat returns true if the format is supported by aqua.
+// An empty format is treated as known because aqua infers the format from the asset name.
+func IsKnownFormat(format string) bool {
+	if format == "" || format == formatRaw {
+		return true
+	}
+	return slices.Contains(formats, format)
+}
@@ -64,3 +64,27 @@ func TestRemoveExtFromAsset(t *testing.T) {
 		})
 	}
 }
+
+func TestIsKnownFormat(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		format string
+		exp    bool
+	}{
+		{format: "", exp: true},
+		{format: "raw", exp: true},
+		{format: "tar.gz", exp: true},
+		{format: "zip", exp: true},
+		{format: "dmg", exp: true},
+		{format: "targz", exp: false},
+		{format: ".zip", exp: false},
+	}
+	for _, d := range data {
+		t.Run(d.format, func(t *testing.T) {
+			t.Parallel()
+			if f := asset.IsKnownFormat(d.format); f != d.exp {
+				t.Fatalf("wanted %v, got %v", d.exp, f)
+			}
+		})
+	}
+}
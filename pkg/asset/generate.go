@@ -155,6 +155,86 @@ func ParseAssetInfos(pkgInfo *registry.PackageInfo, assetInfos []*AssetInfo) { /
 	pkgInfo.Overrides = normalizeOverridesByAsset(pkgInfo.Asset, pkgInfo.Overrides)
 
 	pkgInfo.Replacements, pkgInfo.Overrides = normalizeOverridesByReplacements(pkgInfo)
+
+	// Overrides for musl must precede other overrides because the first matching override is applied.
+	if overrides := getMuslOverrides(pkgInfo, assetInfos); overrides != nil {
+		pkgInfo.Overrides = append(overrides, pkgInfo.Overrides...)
+	}
+}
+
+// getMuslOverrides generates overrides for musl based Linux such as Alpine Linux.
+// If the asset selected for Linux isn't for musl but there is an asset for musl,
+// an override with `libc: musl` is generated.
+func getMuslOverrides(pkgInfo *registry.PackageInfo, assetInfos []*AssetInfo) []*registry.Override {
+	var muslAssetInfos []*AssetInfo
+	for _, assetInfo := range assetInfos {
+		if assetInfo.Libc == libcMusl {
+			muslAssetInfos = append(muslAssetInfos, assetInfo)
+		}
+	}
+	if muslAssetInfos == nil {
+		return nil
+	}
+	var overrides []*registry.Override
+	for _, goarch := range []string{"amd64", "arm64"} {
+		assetInfo := GetOSArch("linux", goarch, assetInfos)
+		if assetInfo == nil || assetInfo.Libc == libcMusl {
+			continue
+		}
+		muslAssetInfo := GetOSArch("linux", goarch, muslAssetInfos)
+		if muslAssetInfo == nil {
+			continue
+		}
+		ov := &registry.Override{
+			GOOS:         "linux",
+			GOArch:       goarch,
+			Libc:         libcMusl,
+			Asset:        muslAssetInfo.Template,
+			Replacements: getMuslReplacements(pkgInfo.Replacements, muslAssetInfo.Replacements, goarch),
+		}
+		if muslAssetInfo.Format != pkgInfo.Format {
+			ov.Format = muslAssetInfo.Format
+		}
+		overrides = append(overrides, ov)
+	}
+	if len(overrides) == 2 { //nolint:mnd
+		// amd64, arm64
+		ov1 := overrides[0]
+		ov2 := overrides[1]
+		if ov1.Asset == ov2.Asset && ov1.Format == ov2.Format {
+			if replacements, ok := mergeReplacements("linux", ov1.Replacements, ov2.Replacements); ok {
+				ov1.GOArch = ""
+				ov1.Replacements = replacements
+				return []*registry.Override{ov1}
+			}
+		}
+	}
+	return overrides
+}
+
+// getMuslReplacements returns replacements of an override for musl.
+// Replacements of the override are merged with the package's replacements,
+// so the package's replacements which the asset for musl doesn't use are reset.
+func getMuslReplacements(pkgReplacements, assetReplacements map[string]string, goarch string) map[string]string {
+	replacements := map[string]string{}
+	for _, k := range []string{"linux", goarch} {
+		v, ok := assetReplacements[k]
+		if !ok {
+			v = k
+		}
+		pv, ok := pkgReplacements[k]
+		if !ok {
+			pv = k
+		}
+		if pv == v {
+			continue
+		}
+		replacements[k] = v
+	}
+	if len(replacements) == 0 {
+		return nil
+	}
+	return replacements
 }
 
 // normalizeOverridesByReplacements extracts common replacements from overrides to reduce duplication.
@@ -363,6 +443,9 @@ func ParseAssetName(assetName, version string) *AssetInfo { //nolint:cyclop
 	lowAssetName := strings.ToLower(assetName)
 	SetOS(assetName, lowAssetName, assetInfo)
 	SetArch(assetName, lowAssetName, assetInfo)
+	if assetInfo.OS == "linux" && strings.Contains(lowAssetName, libcMusl) {
+		assetInfo.Libc = libcMusl
+	}
 	if assetInfo.Arch == "" && assetInfo.OS == osDarwin {
 		if strings.Contains(lowAssetName, "_all") || strings.Contains(lowAssetName, "-all") || strings.Contains(lowAssetName, ".all") {
 			assetInfo.DarwinAll = true
@@ -71,6 +71,18 @@ func TestParseAssetName(t *testing.T) { //nolint:funlen
 				Score: 0,
 			},
 		},
+		{
+			name:      "musl",
+			assetName: "tool-v1.0.0-linux-amd64-musl.tar.gz",
+			version:   "v1.0.0",
+			expected: &asset.AssetInfo{
+				Template: "tool-{{.Version}}-{{.OS}}-{{.Arch}}-musl.{{.Format}}",
+				OS:       "linux",
+				Arch:     "amd64",
+				Libc:     "musl",
+				Format:   "tar.gz",
+			},
+		},
 		{
 			name:      "no platform info",
 			assetName: "tool-v1.0.0.tar.gz",
@@ -203,3 +215,36 @@ func TestParseAssetInfos(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAssetInfos_musl(t *testing.T) {
+	t.Parallel()
+	assetInfos := []*asset.AssetInfo{}
+	for _, assetName := range []string{
+		"tool-v1.0.0-linux-amd64.tar.gz",
+		"tool-v1.0.0-linux-amd64-musl.tar.gz",
+		"tool-v1.0.0-linux-arm64.tar.gz",
+		"tool-v1.0.0-linux-arm64-musl.tar.gz",
+		"tool-v1.0.0-darwin-amd64.tar.gz",
+		"tool-v1.0.0-darwin-arm64.tar.gz",
+		"tool-v1.0.0-windows-amd64.zip",
+		"tool-v1.0.0-windows-arm64.zip",
+	} {
+		assetInfos = append(assetInfos, asset.ParseAssetName(assetName, "v1.0.0"))
+	}
+	pkgInfo := &registry.PackageInfo{}
+	asset.ParseAssetInfos(pkgInfo, assetInfos)
+	exp := []*registry.Override{
+		{
+			GOOS:  "linux",
+			Libc:  "musl",
+			Asset: "tool-{{.Version}}-{{.OS}}-{{.Arch}}-musl.{{.Format}}",
+		},
+		{
+			GOOS:   "windows",
+			Format: "zip",
+		},
+	}
+	if diff := cmp.Diff(exp, pkgInfo.Overrides); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -25,6 +25,7 @@ type AssetInfo struct { //nolint:revive
 	OS                 string
 	Arch               string
 	DarwinAll          bool
+	Libc               string
 	Format             string
 	Replacements       map[string]string
 	Score              int
@@ -6,6 +6,7 @@ import (
 
 type MockClient struct {
 	Versions     []string
+	VersionInfos []*PayloadVersion
 	Err          error
 	CratePayload *CratePayload
 }
@@ -14,6 +15,10 @@ func (m *MockClient) ListVersions(ctx context.Context, crate string) ([]string,
 	return m.Versions, m.Err
 }
 
+func (m *MockClient) ListVersionInfos(ctx context.Context, crate string) ([]*PayloadVersion, error) {
+	return m.VersionInfos, m.Err
+}
+
 func (m *MockClient) GetLatestVersion(ctx context.Context, crate string) (string, error) {
 	if len(m.Versions) == 0 {
 		return "", m.Err
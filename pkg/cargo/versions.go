@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/aquaproj/aqua/v2/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -16,7 +17,8 @@ type Payload struct {
 }
 
 type PayloadVersion struct {
-	Num string `json:"num"`
+	Num       string    `json:"num"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type CratePayload struct {
@@ -40,6 +42,19 @@ func NewClient(client *http.Client) *Client {
 }
 
 func (c *Client) ListVersions(ctx context.Context, crate string) ([]string, error) {
+	payloadVersions, err := c.ListVersionInfos(ctx, crate)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]string, len(payloadVersions))
+	for i, v := range payloadVersions {
+		versions[i] = v.Num
+	}
+	return versions, nil
+}
+
+// ListVersionInfos returns versions of the crate with the published time.
+func (c *Client) ListVersionInfos(ctx context.Context, crate string) ([]*PayloadVersion, error) {
 	u := fmt.Sprintf("https://crates.io/api/v1/crates/%s/versions", crate)
 	versions, _, err := listInstallableVersions(ctx, c.client, u)
 	return versions, logerr.WithFields(err, logrus.Fields{ //nolint:wrapcheck
@@ -81,7 +96,7 @@ func getCrate(ctx context.Context, client *http.Client, uri string) (*CratePaylo
 	return payload, resp.StatusCode, nil
 }
 
-func listInstallableVersions(ctx context.Context, client *http.Client, uri string) ([]string, int, error) {
+func listInstallableVersions(ctx context.Context, client *http.Client, uri string) ([]*PayloadVersion, int, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, 0, fmt.Errorf("create a HTTP request: %w", err)
@@ -99,9 +114,5 @@ func listInstallableVersions(ctx context.Context, client *http.Client, uri strin
 	if err := json.NewDecoder(resp.Body).Decode(payload); err != nil {
 		return nil, resp.StatusCode, fmt.Errorf("decode the response body as JSON: %w", err)
 	}
-	versions := make([]string, len(payload.Versions))
-	for i, v := range payload.Versions {
-		versions[i] = v.Num
-	}
-	return versions, resp.StatusCode, nil
+	return payload.Versions, resp.StatusCode, nil
 }
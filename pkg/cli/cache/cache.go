@@ -0,0 +1,26 @@
+// Package cache implements the aqua cache commands for managing registry caches.
+// Registry caches store package definitions used by aqua exec and aqua which
+// so that large registry files don't need to be parsed every time.
+package cache
+
+import (
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/urfave/cli/v3"
+)
+
+// New creates and returns a new CLI command for managing registry caches.
+// The returned command provides subcommands for listing and clearing caches.
+func New(r *util.Param) *cli.Command {
+	return &cli.Command{
+		Name:  "cache",
+		Usage: "Manage registry caches",
+		Description: `Manage registry caches.
+
+Registry caches which haven't been used for over the expiration days are removed by "aqua vacuum".
+`,
+		Commands: []*cli.Command{
+			newList(r),
+			newClear(r),
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	"github.com/urfave/cli/v3"
+)
+
+// clearCommand holds the parameters and configuration for the cache clear command.
+type clearCommand struct {
+	r *util.Param
+}
+
+// newClear creates and returns a new CLI command for removing registry caches.
+func newClear(r *util.Param) *cli.Command {
+	i := &clearCommand{
+		r: r,
+	}
+	return &cli.Command{
+		Action:      i.action,
+		Name:        "clear",
+		Usage:       "Remove registry caches",
+		ArgsUsage:   `[<cache id>...]`,
+		Description: clearDescription,
+	}
+}
+
+// action implements the main logic for the cache clear command.
+func (cc *clearCommand) action(ctx context.Context, cmd *cli.Command) error {
+	profiler, err := profile.Start(cmd)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	param := &config.Param{}
+	if err := util.SetParam(cmd, cc.r.LogE, "cache-clear", param, cc.r.LDFlags); err != nil {
+		return fmt.Errorf("parse the command line arguments: %w", err)
+	}
+	ctrl := controller.InitializeCacheCommandController(ctx, param)
+	return ctrl.Clear(cc.r.LogE, param) //nolint:wrapcheck
+}
+
+const clearDescription = `Remove registry caches.
+
+$ aqua cache clear
+
+If no argument is given, all registry caches are removed.
+You can remove specific caches by passing cache ids outputted by "aqua cache list".
+
+$ aqua cache clear ed63789b776126ab5f8182aa7249be03c3865e73498833a376ec1a99744a47cf
+
+Removed caches are recreated when packages are looked up next time.
+`
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	"github.com/urfave/cli/v3"
+)
+
+// listCommand holds the parameters and configuration for the cache list command.
+type listCommand struct {
+	r *util.Param
+}
+
+// newList creates and returns a new CLI command for listing registry caches.
+func newList(r *util.Param) *cli.Command {
+	i := &listCommand{
+		r: r,
+	}
+	return &cli.Command{
+		Action:      i.action,
+		Name:        "list",
+		Aliases:     []string{"ls"},
+		Usage:       "List registry caches",
+		Description: listDescription,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Output format. One of json and table",
+			},
+		},
+	}
+}
+
+// action implements the main logic for the cache list command.
+func (lc *listCommand) action(ctx context.Context, cmd *cli.Command) error {
+	profiler, err := profile.Start(cmd)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	param := &config.Param{}
+	if err := util.SetParam(cmd, lc.r.LogE, "cache-list", param, lc.r.LDFlags); err != nil {
+		return fmt.Errorf("parse the command line arguments: %w", err)
+	}
+	ctrl := controller.InitializeCacheCommandController(ctx, param)
+	return ctrl.List(lc.r.LogE, param) //nolint:wrapcheck
+}
+
+const listDescription = `List registry caches.
+
+$ aqua cache list
+ID                                                                REGISTRY                                                    PACKAGES  SIZE
+ed63789b776126ab5f8182aa7249be03c3865e73498833a376ec1a99744a47cf  github_content:aquaproj/aqua-registry@v4.0.0:registry.yaml  2         243
+
+Registry caches are stored in $AQUA_ROOT_DIR/registry-cache.
+A registry cache is shared by all configuration files referencing the same registry.
+Caches are keyed by the registry type, repository, ref, path, and the hash of the registry content.
+
+Caches created by old aqua versions are listed without the registry.
+
+You can output caches as JSON by "--format json".
+`
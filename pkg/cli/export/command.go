@@ -0,0 +1,95 @@
+// Package export implements the aqua export command for generating snippets of other tools.
+// The export command outputs a Dockerfile, devcontainer.json, or GitHub Actions steps
+// to install packages of the configuration file so that they don't drift from aqua.yaml.
+package export
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	"github.com/urfave/cli/v3"
+)
+
+// command holds the parameters and configuration for the export command.
+type command struct {
+	r *util.Param
+}
+
+// New creates and returns a new CLI command for exporting snippets.
+func New(r *util.Param) *cli.Command {
+	i := &command{
+		r: r,
+	}
+	return &cli.Command{
+		Name:        "export",
+		Usage:       "Output a Dockerfile, devcontainer.json, or GitHub Actions steps to install packages",
+		Description: exportDescription,
+		Action:      i.action,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "target",
+				Aliases: []string{"t"},
+				Usage:   "The target of the snippet. One of dockerfile, devcontainer, and github-actions",
+			},
+			&cli.StringFlag{
+				Name:  "base-image",
+				Usage: "The base image of the Dockerfile. The default is debian:bookworm-slim",
+			},
+		},
+	}
+}
+
+// action implements the main logic for the export command.
+func (i *command) action(ctx context.Context, cmd *cli.Command) error {
+	profiler, err := profile.Start(cmd)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	param := &config.Param{}
+	if err := util.SetParam(cmd, i.r.LogE, "export", param, i.r.LDFlags); err != nil {
+		return fmt.Errorf("parse the command line arguments: %w", err)
+	}
+	ctrl := controller.InitializeExportCommandController(ctx, i.r.LogE, param, http.DefaultClient, i.r.Runtime)
+	return ctrl.Export(ctx, i.r.LogE, param) //nolint:wrapcheck
+}
+
+const exportDescription = `Output a snippet to install packages of the configuration file in other environments.
+
+Snippets are generated from the effective configuration including imported files and versions resolved by version_expr and go_version_file.
+Please run the command again when the configuration is changed.
+
+--target dockerfile
+
+Output a multi-stage Dockerfile.
+The first stage installs packages and copies executable files by "aqua cp".
+The second stage copies only the executable files.
+The configuration file, imported files, local registries, and files referred by go_version_file and version_expr are copied.
+If checksum verification is enabled and aqua-checksums.json exists, it's copied and checksum verification is enforced.
+Paths are relative to the current directory, so please run "docker build" in the current directory.
+aqua is installed by aqua-installer pinned with the commit hash.
+The base image is debian:bookworm-slim by default. Please pin it with the digest by --base-image if you need reproducible builds.
+
+$ aqua export --target dockerfile > Dockerfile
+$ aqua export --target dockerfile --base-image "debian:bookworm-slim@sha256:..." > Dockerfile
+
+--target devcontainer
+
+Output a part of devcontainer.json.
+aqua is installed by the Dev Container Feature and packages are installed by "aqua i -a" after the container is created.
+
+$ aqua export --target devcontainer
+
+--target github-actions
+
+Output steps of GitHub Actions to cache packages and install aqua.
+The cache key is computed from the effective configuration, the registry refs, and aqua-checksums.json.
+
+$ aqua export --target github-actions
+`
@@ -66,6 +66,14 @@ func New(r *util.Param) *cli.Command {
 				Aliases: []string{"s"},
 				Usage:   `Select the installed version interactively. Default to display 30 versions, use --limit/-l to change it.`,
 			},
+			&cli.BoolFlag{
+				Name:  "from-path",
+				Usage: `Find packages providing commands installed in $PATH without aqua`,
+			},
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: `Output all packages found by --from-path without fuzzy finder`,
+			},
 			&cli.IntFlag{
 				Name:    "limit",
 				Aliases: []string{"l"},
@@ -201,4 +209,27 @@ $ aqua g -g cli/cli
 You can add packages to a first global configuration file with -g and -i option.
 
 $ aqua g -g -i cli/cli
+
+With --from-path option, aqua searches commands installed in $PATH without aqua and packages providing them.
+This is useful to migrate tools installed by other ways to aqua.
+Commands in $AQUA_ROOT_DIR and packages already in the configuration file are ignored.
+You can select packages interactively.
+
+$ aqua g --from-path
+
+With --all option, all found packages are outputted without fuzzy finder.
+Commands provided by multiple packages are skipped.
+
+$ aqua g --from-path --all
+- name: cli/cli@v2.45.0
+- name: junegunn/fzf@0.50.0
+
+If the field "version_command" of the package is set in the registry, aqua runs the command with the arguments and guesses the installed version from the output.
+Otherwise, the latest version is outputted.
+
+  packages:
+    - type: github_release
+      repo_owner: cli
+      repo_name: cli
+      version_command: --version
 `
@@ -13,6 +13,8 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/cli/util"
 	"github.com/aquaproj/aqua/v2/pkg/config"
 	"github.com/aquaproj/aqua/v2/pkg/controller"
+	genrgst "github.com/aquaproj/aqua/v2/pkg/controller/generate-registry"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
 	"github.com/urfave/cli/v3"
 )
 
@@ -57,6 +59,25 @@ e.g.
 
 $ aqua gr --limit 100 suzuki-shunsuke/tfcmt
 
+aqua gr detects files to verify assets and generates the configuration of them.
+
+- Cosign: <asset>.sig, <asset>.pem, <asset>.bundle, <asset>.sigstore.json, and cosign.pub
+- SLSA Provenance: *.intoto.jsonl
+- Minisign: <asset>.minisig. Please set public_key manually
+- GitHub Artifact Attestations: aqua gets attestations of assets by GitHub API
+
+If --verify is set, aqua installs the generated package in all supported environments
+into a temporary directory and checks if the asset is downloaded, verified with the checksum, unarchived,
+and contains files. Environments are emulated like AQUA_GOOS and AQUA_GOARCH, so executables aren't run.
+The latest version of each version_constraint range is installed, so every version_overrides is verified.
+If the package has overrides of libc, Linux is verified with both glibc and musl.
+The result (pass, fail, or unsupported) is outputted per version and environment to the standard error output as a table,
+and aqua gr exits with a non-zero code if the package can't be installed in any environment.
+
+e.g.
+
+$ aqua gr --verify cli/cli
+
 If --out-testdata is set, aqua inserts testdata into the specified file.
 
 e.g.
@@ -81,6 +102,35 @@ $ aqua gr -cmd age,age-keygen FiloSottile/age
   files:
 	  - name: age
 	  - name: age-keygen
+
+aqua gr supports GitLab Releases too.
+The package name must start with the host of GitLab such as gitlab.com.
+Hosts starting with "gitlab." are treated as GitLab.
+Other self-managed GitLab hosts can be specified by --gitlab-host, the environment variable AQUA_GITLAB_HOSTS, or gitlab_hosts in the configuration file.
+A package of http type is generated from links of the release.
+If the environment variable GITLAB_TOKEN is set, it's used to access GitLab API.
+
+e.g.
+
+$ aqua gr gitlab.com/gitlab-org/cli
+$ aqua gr --gitlab-host git.example.com git.example.com/group/project
+
+To generate a package of http type from other sources, set url and versions in the configuration file.
+aqua infers the asset name template from asset names of the first version,
+which are given by assets or a local directory of release assets assets_dir.
+{{.Asset}} in url is replaced with the asset name template.
+
+e.g.
+
+$ cat aqua-generate-registry.yaml
+name: example.com/foo
+url: https://example.com/foo/{{.Version}}/{{.Asset}}
+versions:
+  - v1.1.0
+  - v1.0.0
+assets_dir: dist
+
+$ aqua gr -c aqua-generate-registry.yaml
 `
 
 type command struct {
@@ -133,6 +183,15 @@ func New(r *util.Param) *cli.Command {
 				Name:  "init",
 				Usage: "Generate a configuration file",
 			},
+			&cli.BoolFlag{
+				Name:  "verify",
+				Usage: "Install the generated package in all supported environments to verify it",
+			},
+			&cli.StringSliceFlag{
+				Name:    "gitlab-host",
+				Usage:   `A host of self-managed GitLab whose name doesn't start with "gitlab.". This option can be specified multiple times`,
+				Sources: cli.EnvVars("AQUA_GITLAB_HOSTS"),
+			},
 		},
 	}
 }
@@ -152,5 +211,10 @@ func (i *command) action(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("parse the command line arguments: %w", err)
 	}
 	ctrl := controller.InitializeGenerateRegistryCommandController(ctx, i.r.LogE, param, http.DefaultClient, os.Stdout)
+	ctrl.SetNewPackageInstaller(func(rt *runtime.Runtime, rootDir string) (genrgst.PackageInstaller, error) {
+		p := *param
+		p.RootDir = rootDir
+		return controller.InitializePackageInstaller(ctx, i.r.LogE, &p, http.DefaultClient, rt) //nolint:wrapcheck
+	})
 	return ctrl.GenerateRegistry(ctx, param, i.r.LogE, cmd.Args().Slice()...) //nolint:wrapcheck
 }
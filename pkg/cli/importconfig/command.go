@@ -0,0 +1,126 @@
+// Package importconfig implements the aqua import-config command for migrating from other version managers.
+// The import-config command converts configuration files of tools such as asdf and mise
+// into aqua's package entries.
+package importconfig
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	"github.com/urfave/cli/v3"
+)
+
+// command holds the parameters and configuration for the import-config command.
+type command struct {
+	r *util.Param
+}
+
+// New creates and returns a new CLI command for importing configuration files of other version managers.
+func New(r *util.Param) *cli.Command {
+	i := &command{
+		r: r,
+	}
+	return &cli.Command{
+		Name:        "import-config",
+		Usage:       "Convert configuration files of other version managers such as asdf and mise to aqua's configuration",
+		ArgsUsage:   `[<file path> ...]`,
+		Description: importConfigDescription,
+		Action:      i.action,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "i",
+				Usage: `Insert packages to configuration file`,
+			},
+			&cli.BoolFlag{
+				Name:  "pin",
+				Usage: `Pin version`,
+			},
+			&cli.BoolFlag{
+				Name:  "g",
+				Usage: `Insert packages in a global configuration file`,
+			},
+			&cli.BoolFlag{
+				Name:    "detail",
+				Aliases: []string{"d"},
+				Usage:   `Output additional fields such as description and link`,
+				Sources: cli.EnvVars("AQUA_GENERATE_WITH_DETAIL"),
+			},
+			&cli.StringFlag{
+				Name:  "o",
+				Usage: `inserted file`,
+			},
+		},
+	}
+}
+
+// action implements the main logic for the import-config command.
+func (i *command) action(ctx context.Context, cmd *cli.Command) error {
+	profiler, err := profile.Start(cmd)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	param := &config.Param{}
+	if err := util.SetParam(cmd, i.r.LogE, "import-config", param, i.r.LDFlags); err != nil {
+		return fmt.Errorf("parse the command line arguments: %w", err)
+	}
+	ctrl := controller.InitializeGenerateCommandController(ctx, i.r.LogE, param, http.DefaultClient, i.r.Runtime)
+	return ctrl.ImportConfig(ctx, i.r.LogE, param, cmd.Args().Slice()...) //nolint:wrapcheck
+}
+
+const importConfigDescription = `Convert configuration files of other version managers to aqua's configuration.
+
+The following files are supported.
+
+- .tool-versions (asdf)
+- mise.toml, .mise.toml (mise)
+- .go-version
+- .terraform-version
+- .nvmrc, .node-version
+
+If no file is passed, the above files in the current directory are imported.
+
+$ cat .tool-versions
+golang 1.22.1
+terraform 1.7.5
+nodejs 20
+
+$ aqua import-config
+- name: golang/go@go1.22.1
+- name: hashicorp/terraform@v1.7.5
+- name: nodejs/node@v20.12.0
+
+You can pass files with positional arguments.
+
+$ aqua import-config .tool-versions ci/mise.toml
+
+Tool names are mapped to packages by package names, aliases, command names, and search_words in registries.
+If multiple packages match, packages in the standard registry are preferred.
+mise's backends "aqua:", "ubi:", and "github:" are mapped to packages directly.
+
+  [tools]
+  "aqua:cli/cli" = "2.45.0"
+
+Partial versions such as "20" and "1.21" are converted to the latest version matching them.
+"latest" is converted to the latest version.
+
+Tools which can't be mapped to packages are reported as warnings.
+Please add them to the configuration file manually.
+For example, tools whose version is "system", "lts/*", "ref:<ref>", or "path:<path>" and tools of mise's backends such as "npm:" aren't supported.
+
+You can update the configuration file directly with "-i" option.
+
+$ aqua import-config -i
+
+You can update an imported file with "-o" option.
+
+$ aqua import-config -o aqua/pkgs.yaml
+
+The options "-pin", "-detail", and "-g" are same as "aqua generate".
+`
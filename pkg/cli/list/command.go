@@ -52,6 +52,32 @@ By default, the command doesn't list global configuration packages.
 If you want to list global configuration packages too, please set the option -a.
 
 $ aqua list -installed -a
+
+If the option --format is set, the command outputs the list in the given format.
+The format must be either json, yaml, or table.
+Structured outputs include package metadata such as description, search_words, aliases, type, and supported_envs.
+
+$ aqua list --format json
+[
+  {
+    "registry": "standard",
+    "name": "cli/cli",
+    "type": "github_release",
+    "description": "GitHub’s official command line tool",
+    "aliases": [
+      "github/cli"
+    ]
+  },
+  ...
+]
+
+With the option -installed, structured outputs include whether the package is installed for the current environment,
+the install path, and the size in bytes.
+
+$ aqua list -installed --format table
+NAME                     VERSION  REGISTRY  INSTALLED  SIZE      INSTALL_PATH
+golangci/golangci-lint   v1.56.2  standard  true       37.5 MiB  /home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/golangci/golangci-lint/v1.56.2/golangci-lint-1.56.2-linux-amd64.tar.gz
+goreleaser/goreleaser    v1.24.0  standard  false                /home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/goreleaser/goreleaser/v1.24.0/goreleaser_Linux_x86_64.tar.gz
 `,
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
@@ -64,6 +90,10 @@ $ aqua list -installed -a
 				Aliases: []string{"a"},
 				Usage:   "List global configuration packages too",
 			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Output format. One of json, yaml, and table",
+			},
 		},
 	}
 }
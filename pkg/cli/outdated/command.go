@@ -0,0 +1,117 @@
+// Package outdated implements the aqua outdated command for checking the latest versions of packages and registries.
+// Unlike "aqua update", the outdated command doesn't modify configuration files,
+// so it's useful for scheduled CI jobs and dashboards.
+package outdated
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	"github.com/urfave/cli/v3"
+)
+
+const description = `Output current and latest versions of registries and packages.
+
+  $ aqua outdated
+  REGISTRY  CURRENT   LATEST    TYPE   NOTE
+  standard  v4.300.0  v4.310.0  minor
+
+  PACKAGE  REGISTRY  CURRENT  LATEST   TYPE   NOTE
+  cli/cli  standard  v2.60.0  v2.61.1  minor
+  jq       standard  jq-1.7   jq-1.7
+
+This command doesn't update aqua.yaml.
+If some registries or packages are outdated, this command exits with the exit code 1.
+So it's useful to check if packages are up to date in CI.
+
+TYPE is one of major, minor, patch, and other.
+This command respects the field 'update' of packages in aqua.yaml.
+
+  packages:
+    - name: cli/cli@v2.60.0
+      update:
+        types: [minor, patch] # Major updates are ignored
+        allowed_version: semver("< 3.0.0")
+        min_age: 72h # Versions released within 72 hours are ignored
+
+Same as "aqua update", this command checks only a nearest aqua.yaml from the current directory.
+
+If you want to check only registries, please use the --only-registry [-r] option.
+
+  $ aqua outdated -r
+
+If you want to check only packages, please use the --only-package [-p] option.
+
+  $ aqua outdated -p
+
+If you want the machine-readable output, "--format json" option is useful.
+
+  $ aqua outdated --format json
+
+You can also filter packages using package tags.
+
+  $ aqua outdated -t foo
+  $ aqua outdated --exclude-tags foo
+`
+
+type command struct {
+	r *util.Param
+}
+
+// New creates and returns a new CLI command for checking outdated packages and registries.
+func New(r *util.Param) *cli.Command {
+	i := &command{
+		r: r,
+	}
+	return &cli.Command{
+		Name:        "outdated",
+		Usage:       "Output current and latest versions of registries and packages",
+		Description: description,
+		Action:      i.action,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "only-registry",
+				Aliases: []string{"r"},
+				Usage:   `Check only registries`,
+			},
+			&cli.BoolFlag{
+				Name:    "only-package",
+				Aliases: []string{"p"},
+				Usage:   `Check only packages`,
+			},
+			&cli.StringFlag{
+				Name:    "tags",
+				Aliases: []string{"t"},
+				Usage:   "filter packages with tags",
+			},
+			&cli.StringFlag{
+				Name:  "exclude-tags",
+				Usage: "exclude packages with tags",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Output format. One of json and table",
+			},
+		},
+	}
+}
+
+func (i *command) action(ctx context.Context, cmd *cli.Command) error {
+	profiler, err := profile.Start(cmd)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	param := &config.Param{}
+	if err := util.SetParam(cmd, i.r.LogE, "outdated", param, i.r.LDFlags); err != nil {
+		return fmt.Errorf("parse the command line arguments: %w", err)
+	}
+	ctrl := controller.InitializeOutdatedCommandController(ctx, i.r.LogE, param, http.DefaultClient, i.r.Runtime)
+	return ctrl.Outdated(ctx, i.r.LogE, param) //nolint:wrapcheck
+}
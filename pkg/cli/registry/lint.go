@@ -0,0 +1,67 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	"github.com/urfave/cli/v3"
+)
+
+// lintCommand holds the parameters and configuration for the registry lint command.
+type lintCommand struct {
+	r *util.Param
+}
+
+// newLint creates and returns a new CLI command for validating registry files.
+func newLint(r *util.Param) *cli.Command {
+	i := &lintCommand{
+		r: r,
+	}
+	return &cli.Command{
+		Action:      i.action,
+		Name:        "lint",
+		Usage:       "Validate registry files statically",
+		ArgsUsage:   `<registry file path> [<registry file path>...]`,
+		Description: lintDescription,
+	}
+}
+
+// action implements the main logic for the registry lint command.
+func (lc *lintCommand) action(ctx context.Context, cmd *cli.Command) error {
+	profiler, err := profile.Start(cmd)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	param := &config.Param{}
+	if err := util.SetParam(cmd, lc.r.LogE, "registry-lint", param, lc.r.LDFlags); err != nil {
+		return fmt.Errorf("parse the command line arguments: %w", err)
+	}
+	ctrl := controller.InitializeLintRegistryCommandController(ctx, param)
+	return ctrl.Lint(lc.r.LogE, param) //nolint:wrapcheck
+}
+
+const lintDescription = `Validate registry files statically.
+
+$ aqua registry lint registry.yaml
+
+Templates in asset, url, path, files, checksum, cosign, slsa_provenance, and minisign are rendered
+for every supported environment and sample versions.
+Sample versions are extracted from version constraints.
+References to undefined variables are reported.
+
+The following mistakes are also reported.
+
+- overrides which never match because of supported_envs or preceding overrides
+- version_overrides which are unreachable because preceding version constraints match all versions matching them
+- version constraints which can't be evaluated
+- unknown formats
+- duplicate package names and aliases
+
+Issues are output to the standard output and the command fails if any issue is found.
+`
@@ -0,0 +1,22 @@
+// Package registry implements the aqua registry commands for maintaining registries.
+// The registry commands help maintainers of registries to find mistakes in package definitions
+// before users on various platforms hit them.
+package registry
+
+import (
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/urfave/cli/v3"
+)
+
+// New creates and returns a new CLI command for registry maintenance.
+// The returned command provides subcommands for validating and testing registry files.
+func New(r *util.Param) *cli.Command {
+	return &cli.Command{
+		Name:  "registry",
+		Usage: "Maintain registries",
+		Commands: []*cli.Command{
+			newLint(r),
+			newTest(r),
+		},
+	}
+}
@@ -0,0 +1,115 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	"github.com/aquaproj/aqua/v2/pkg/controller/testregistry"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/urfave/cli/v3"
+)
+
+// testCommand holds the parameters and configuration for the registry test command.
+type testCommand struct {
+	r *util.Param
+}
+
+// newTest creates and returns a new CLI command for testing registries with testdata files.
+func newTest(r *util.Param) *cli.Command {
+	i := &testCommand{
+		r: r,
+	}
+	return &cli.Command{
+		Action:      i.action,
+		Name:        "test",
+		Usage:       "Test download plans of packages in a registry",
+		ArgsUsage:   `<testdata file path> [<testdata file path>...]`,
+		Description: testDescription,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "registry",
+				Aliases: []string{"r"},
+				Usage:   "The registry file path",
+				Value:   "registry.yaml",
+			},
+			&cli.StringFlag{
+				Name:  "golden",
+				Usage: "The golden file path. If this isn't set, download plans are output to the standard output",
+			},
+			&cli.BoolFlag{
+				Name:  "update-golden",
+				Usage: "Update the golden file",
+			},
+			&cli.StringFlag{
+				Name:  "fixture-dir",
+				Usage: "The directory of recorded fixtures. If this is set, packages are installed with fixtures",
+			},
+		},
+	}
+}
+
+// action implements the main logic for the registry test command.
+func (tc *testCommand) action(ctx context.Context, cmd *cli.Command) error {
+	profiler, err := profile.Start(cmd)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	param := &config.Param{}
+	if err := util.SetParam(cmd, tc.r.LogE, "registry-test", param, tc.r.LDFlags); err != nil {
+		return fmt.Errorf("parse the command line arguments: %w", err)
+	}
+	ctrl := controller.InitializeTestRegistryCommandController(ctx, param)
+	ctrl.SetNewPackageInstaller(func(rt *runtime.Runtime, rootDir string) (testregistry.PackageInstaller, error) {
+		p := *param
+		p.RootDir = rootDir
+		return controller.InitializePackageInstaller(ctx, tc.r.LogE, &p, http.DefaultClient, rt) //nolint:wrapcheck
+	})
+	return ctrl.Test(ctx, tc.r.LogE, param) //nolint:wrapcheck
+}
+
+const testDescription = `Test download plans of packages in a registry.
+
+For each package version in testdata files and each environment, the download plan is resolved.
+The plan consists of the asset name, the download URL, paths of files, the checksum file, and files to verify the package such as Cosign signatures and SLSA Provenance.
+Testdata files have the same format as files generated by "aqua gr --out-testdata".
+
+e.g. pkg.yaml
+
+packages:
+  - name: cli/cli@v2.45.0
+  - name: cli/cli
+    version: v2.0.0
+
+Output download plans.
+
+$ aqua registry test -r registry.yaml pkg.yaml
+
+Compare download plans with the golden file.
+The command fails if they are different, so you can refactor version_overrides safely.
+
+$ aqua registry test -r registry.yaml --golden plan.yaml pkg.yaml
+
+Update the golden file.
+
+$ aqua registry test -r registry.yaml --golden plan.yaml --update-golden pkg.yaml
+
+Install packages with recorded fixtures.
+A local HTTP server serves fixtures instead of real servers.
+The fixture of a URL is located at <fixture dir>/<host>/<path>.
+
+e.g. https://github.com/cli/cli/releases/download/v2.45.0/gh_2.45.0_linux_amd64.tar.gz
+=> fixtures/github.com/cli/cli/releases/download/v2.45.0/gh_2.45.0_linux_amd64.tar.gz
+
+$ aqua registry test -r registry.yaml --fixture-dir fixtures pkg.yaml
+
+Package versions whose assets aren't recorded are skipped.
+Checksums are verified if checksum files are recorded.
+Signatures, provenances, and attestations aren't verified.
+`
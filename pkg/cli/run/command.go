@@ -0,0 +1,90 @@
+// Package run implements the aqua run command for executing packages without editing aqua.yaml.
+// The run command resolves a package from registries, installs it,
+// and executes it like npx or "go run pkg@version".
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	"github.com/urfave/cli/v3"
+)
+
+var errPackageIsRequired = errors.New("package is required")
+
+// command holds the parameters and configuration for the run command.
+type command struct {
+	r *util.Param
+}
+
+// New creates and returns a new CLI command for running packages.
+// The returned command installs and executes a package
+// which isn't defined in configuration files.
+func New(r *util.Param) *cli.Command {
+	i := &command{
+		r: r,
+	}
+	return &cli.Command{
+		Name:      "run",
+		Usage:     "Install and execute a package without editing aqua.yaml",
+		ArgsUsage: `[<registry name>,]<package name>[@<version>] [--] [<arg> ...]`,
+		Description: `Install and execute a package without adding it to configuration files.
+
+e.g.
+$ aqua run cli/cli@v2.60.0 -- version
+gh version 2.60.0 (2024-10-23)
+
+Packages are searched from registries of the configuration file.
+If no configuration file is found, the latest standard registry is used.
+If the version isn't specified, the latest version is used.
+
+$ aqua run suzuki-shunsuke/tfcmt -- version
+
+The package is installed with the checksum verification and the policy check same as other commands.
+Checksums are verified with aqua-checksums.json, but aqua.yaml and aqua-checksums.json aren't updated.
+So if require_checksum is enabled, the checksum of the package must be in aqua-checksums.json.
+
+By default, the first command of the package is executed.
+You can specify the command by "--cmd" option.
+
+$ aqua run --cmd kubectl-convert kubernetes/kubectl -- --help`,
+		Action: i.action,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "cmd",
+				Usage: "The executed command name",
+			},
+		},
+	}
+}
+
+// action implements the main logic for the run command.
+// It parses the command arguments, initializes the run controller,
+// and executes the package with the provided arguments.
+func (i *command) action(ctx context.Context, cmd *cli.Command) error {
+	profiler, err := profile.Start(cmd)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	param := &config.Param{}
+	if err := util.SetParam(cmd, i.r.LogE, "run", param, i.r.LDFlags); err != nil {
+		return fmt.Errorf("parse the command line arguments: %w", err)
+	}
+	args := cmd.Args().Slice()
+	if len(args) == 0 {
+		return errPackageIsRequired
+	}
+	ctrl, err := controller.InitializeRunCommandController(ctx, i.r.LogE, param, http.DefaultClient, i.r.Runtime)
+	if err != nil {
+		return fmt.Errorf("initialize a RunController: %w", err)
+	}
+	return ctrl.Run(ctx, i.r.LogE, param, args[0], cmd.String("cmd"), args[1:]...) //nolint:wrapcheck
+}
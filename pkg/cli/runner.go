@@ -3,17 +3,24 @@ package cli
 import (
 	"context"
 
+	ccache "github.com/aquaproj/aqua/v2/pkg/cli/cache"
 	"github.com/aquaproj/aqua/v2/pkg/cli/cp"
 	"github.com/aquaproj/aqua/v2/pkg/cli/exec"
+	"github.com/aquaproj/aqua/v2/pkg/cli/export"
 	"github.com/aquaproj/aqua/v2/pkg/cli/generate"
 	"github.com/aquaproj/aqua/v2/pkg/cli/genr"
+	"github.com/aquaproj/aqua/v2/pkg/cli/importconfig"
 	"github.com/aquaproj/aqua/v2/pkg/cli/info"
 	"github.com/aquaproj/aqua/v2/pkg/cli/initcmd"
 	"github.com/aquaproj/aqua/v2/pkg/cli/install"
 	"github.com/aquaproj/aqua/v2/pkg/cli/list"
+	"github.com/aquaproj/aqua/v2/pkg/cli/outdated"
 	cpolicy "github.com/aquaproj/aqua/v2/pkg/cli/policy"
+	cregistry "github.com/aquaproj/aqua/v2/pkg/cli/registry"
 	"github.com/aquaproj/aqua/v2/pkg/cli/remove"
 	"github.com/aquaproj/aqua/v2/pkg/cli/root"
+	"github.com/aquaproj/aqua/v2/pkg/cli/run"
+	"github.com/aquaproj/aqua/v2/pkg/cli/search"
 	"github.com/aquaproj/aqua/v2/pkg/cli/token"
 	"github.com/aquaproj/aqua/v2/pkg/cli/upc"
 	"github.com/aquaproj/aqua/v2/pkg/cli/update"
@@ -21,6 +28,7 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/cli/util"
 	"github.com/aquaproj/aqua/v2/pkg/cli/vacuum"
 	"github.com/aquaproj/aqua/v2/pkg/cli/which"
+	"github.com/aquaproj/aqua/v2/pkg/cli/why"
 	"github.com/suzuki-shunsuke/urfave-cli-v3-util/urfave"
 	"github.com/urfave/cli/v3"
 )
@@ -98,10 +106,13 @@ func Run(ctx context.Context, param *util.Param, args ...string) error { //nolin
 			initcmd.New,
 			install.New,
 			generate.New,
+			importconfig.New,
 			updateaqua.New,
 			upc.New,
 			update.New,
+			outdated.New,
 			which.New,
+			why.New,
 			info.New,
 			remove.New,
 			vacuum.New,
@@ -110,18 +121,23 @@ func Run(ctx context.Context, param *util.Param, args ...string) error { //nolin
 			cpolicy.New,
 			cpolicy.NewInitPolicy,
 			exec.New,
+			export.New,
+			run.New,
 			list.New,
+			search.New,
 			genr.New,
+			cregistry.New,
+			ccache.New,
 			root.New,
 		),
 	}).Run(ctx, args)
 }
 
 // exitErrHandlerFunc handles exit errors for CLI commands.
-// It provides special handling for the "exec" command by skipping the default
-// error handling, allowing the exec command to manage its own exit codes.
+// It provides special handling for the "exec" and "run" commands by skipping the default
+// error handling, allowing these commands to manage their own exit codes.
 func exitErrHandlerFunc(_ context.Context, cmd *cli.Command, err error) {
-	if cmd.Name != "exec" {
+	if cmd.Name != "exec" && cmd.Name != "run" {
 		cli.HandleExitCoder(err)
 		return
 	}
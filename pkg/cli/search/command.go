@@ -0,0 +1,96 @@
+// Package search implements the aqua search command for searching packages in registries.
+// Unlike "aqua generate", the search command works without the interactive fuzzy finder,
+// so it's available in scripts, CI, and editor plugins.
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	csearch "github.com/aquaproj/aqua/v2/pkg/controller/search"
+	"github.com/urfave/cli/v3"
+)
+
+const defaultLimit = 20
+
+// command holds the parameters and configuration for the search command.
+type command struct {
+	r *util.Param
+}
+
+// New creates and returns a new CLI command for searching packages.
+func New(r *util.Param) *cli.Command {
+	i := &command{
+		r: r,
+	}
+	return &cli.Command{
+		Name:      "search",
+		Usage:     "Search packages in registries",
+		ArgsUsage: `<query> [<query> ...]`,
+		Description: `Search packages in registries of the configuration file.
+
+Packages are ranked by the package name, aliases, search_words, and description.
+If multiple queries are given, only packages matching all queries are outputted.
+The output format is <registry name>,<package name> <description>
+
+e.g.
+$ aqua search github comment
+standard,suzuki-shunsuke/github-comment  CLI to create and hide GitHub comments
+
+You can search only a specific registry by "--registry" option.
+
+$ aqua search --registry standard terraform
+
+By default, at most 20 packages are outputted.
+You can change the number by "--limit" option. Non-positive number refers to no limit.
+
+$ aqua search --limit 0 terraform
+
+If you want the machine-readable output, "--format json" option is useful.
+
+$ aqua search --format json terraform`,
+		Action: i.action,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "registry",
+				Aliases: []string{"r"},
+				Usage:   "Search only the given registry",
+			},
+			&cli.IntFlag{
+				Name:    "limit",
+				Aliases: []string{"l"},
+				Usage:   "The maximum number of packages. Non-positive number refers to no limit.",
+				Value:   defaultLimit,
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Output format. One of json and table",
+			},
+		},
+	}
+}
+
+func (i *command) action(ctx context.Context, cmd *cli.Command) error {
+	profiler, err := profile.Start(cmd)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	param := &config.Param{}
+	if err := util.SetParam(cmd, i.r.LogE, "search", param, i.r.LDFlags); err != nil {
+		return fmt.Errorf("parse the command line arguments: %w", err)
+	}
+	ctrl := controller.InitializeSearchCommandController(ctx, i.r.LogE, param, http.DefaultClient, i.r.Runtime)
+	return ctrl.Search(ctx, i.r.LogE, param, &csearch.Param{ //nolint:wrapcheck
+		Query:    strings.Join(cmd.Args().Slice(), " "),
+		Registry: cmd.String("registry"),
+		Limit:    cmd.Int("limit"),
+	})
+}
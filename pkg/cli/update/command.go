@@ -74,6 +74,17 @@ This command doesn't update packages if the field 'version' is used.
 
 So if you don't want to update specific packages, the field 'version' is useful.
 
+You can restrict versions with the field 'update'.
+The field 'types' restricts update types, and the field 'allowed_version' is an expression which returns true if the version is allowed.
+The field 'min_age' skips versions released recently to reduce the risk of supply chain attacks.
+
+  packages:
+    - name: cli/cli@v2.0.0
+      update:
+        types: [minor, patch] # Major updates are ignored
+        allowed_version: semver("< 3.0.0") # Versions which don't satisfy the expression are ignored
+        min_age: 72h # Versions released within 72 hours are ignored
+
 You can specify packages with command names. aqua finds packages that have these commands and updates them.
 
   $ aqua update <command name> [<command name> ...]
@@ -92,6 +103,59 @@ You can also filter updated packages using package tags.
 e.g.
 $ aqua up -t foo # Install only packages having a tag "foo"
 $ aqua up --exclude-tags foo # Install only packages not having a tag "foo"
+
+If you want to review changes of updated packages, please use the --release-notes option.
+aqua collects GitHub Releases between old and new versions of updated packages and outputs them as Markdown.
+Lines about breaking changes are highlighted.
+If the value is "-", release notes are outputted to stdout.
+
+  # Output release notes to stdout
+  $ aqua up --release-notes -
+  # Output release notes to a file
+  $ aqua up --release-notes release-notes.md
+
+By default, packages using go_version_file and version_expr aren't updated.
+If the --version-source option is set, this command writes new versions to files referred by them.
+
+- go_version_file: The go directive and the toolchain directive of go.mod are updated
+- version_expr: Only simple expressions such as readFile("<file path>"), readJSON("<file path>").<key>, and readYAML("<file path>").<key> are supported
+
+  packages:
+    - name: golang/go
+      go_version_file: go.mod
+    - name: hashicorp/terraform
+      version_expr: readFile(".terraform-version")
+    - name: nodejs/node
+      version_expr: readJSON("package.json").volta.node
+      version_expr_prefix: v
+
+  $ aqua up --version-source
+
+If you want to check changes without updating files, please use the --dry-run option.
+This command outputs unified diffs of aqua.yaml and imported files instead of updating them.
+
+  $ aqua up --dry-run
+
+With --format json, the result is outputted as JSON.
+The field "changed" is true if some files would be updated.
+
+  $ aqua up --dry-run --format json
+
+If you want to update all configuration files in a repository, please use the --recursive [-R] option.
+This command searches aqua.yaml, aqua.yml, .aqua.yaml, and .aqua.yml under the current directory and updates them and their imported files.
+The latest versions are looked up only once per package and registry, so this is much faster than running "aqua up -c" for each file.
+After updating files, this command outputs the list of updated registries and packages.
+
+  $ aqua up -R
+  FILE                  NAME                   CURRENT  NEW
+  aqua.yaml             standard               v4.0.0   v4.60.0
+  foo/aqua.yaml         cli/cli                v2.0.0   v2.30.0
+
+The directories .git, node_modules, and vendor are skipped, and so are paths ignored by git (git check-ignore).
+You can skip other paths with the --exclude option.
+Patterns are matched with paths relative to the current directory and their base names.
+
+  $ aqua up -R --exclude testdata --exclude "examples/*"
 `
 
 type command struct {
@@ -146,6 +210,31 @@ func New(r *util.Param) *cli.Command {
 				Name:  "exclude-tags",
 				Usage: "exclude installed packages with tags",
 			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Output unified diffs instead of updating files",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: `The output format of --dry-run. Only "json" is supported`,
+			},
+			&cli.BoolFlag{
+				Name:    "recursive",
+				Aliases: []string{"R"},
+				Usage:   "Update all configuration files under the current directory",
+			},
+			&cli.BoolFlag{
+				Name:  "version-source",
+				Usage: "Update files referred by go_version_file and version_expr",
+			},
+			&cli.StringSliceFlag{
+				Name:  "exclude",
+				Usage: "Glob patterns of paths skipped by --recursive. This option can be specified multiple times",
+			},
+			&cli.StringFlag{
+				Name:  "release-notes",
+				Usage: `Output release notes of updated packages as Markdown to the file. If the value is "-", they are outputted to stdout`,
+			},
 		},
 	}
 }
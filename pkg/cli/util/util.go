@@ -50,8 +50,17 @@ func SetParam(cmd *cli.Command, logE *logrus.Entry, commandName string, param *c
 	param.GenerateConfigFilePath = cmd.String("generate-config")
 	param.Dest = cmd.String("o")
 	param.OutTestData = cmd.String("out-testdata")
+	param.OutputFormat = cmd.String("format")
+	param.ReleaseNotes = cmd.String("release-notes")
+	param.ExportTarget = cmd.String("target")
+	param.ExportBaseImage = cmd.String("base-image")
 	param.OnlyLink = cmd.Bool("only-link")
 	param.InitConfig = cmd.Bool("init")
+	param.Verify = cmd.Bool("verify")
+	param.RegistryFilePath = cmd.String("registry")
+	param.GoldenFilePath = cmd.String("golden")
+	param.FixtureDir = cmd.String("fixture-dir")
+	param.UpdateGolden = cmd.Bool("update-golden")
 	if commandName == "generate-registry" {
 		param.InsertFile = cmd.String("i")
 	} else {
@@ -61,6 +70,12 @@ func SetParam(cmd *cli.Command, logE *logrus.Entry, commandName string, param *c
 	param.Global = cmd.Bool("g")
 	param.Detail = cmd.Bool("detail")
 	param.Prune = cmd.Bool("prune")
+	param.DryRun = cmd.Bool("dry-run")
+	param.Recursive = cmd.Bool("recursive")
+	param.FromPath = cmd.Bool("from-path")
+	param.UpdateVersionSource = cmd.Bool("version-source")
+	param.ExcludedPaths = cmd.StringSlice("exclude")
+	param.GitLabHosts = cmd.StringSlice("gitlab-host")
 	param.CosignDisabled = cmd.Bool("disable-cosign")
 	param.GitHubArtifactAttestationDisabled = cmd.Bool("disable-github-artifact-attestation")
 	param.GitHubReleaseAttestationDisabled = cmd.Bool("disable-github-release-attestation")
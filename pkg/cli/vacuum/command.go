@@ -42,6 +42,12 @@ To solve the problem, "aqua vacuum --init" is available.
 
 "aqua vacuum --init" can't record date times of install packages which are not found in aqua.yaml.
 If you want to record their date times, you need to remove them by "aqua rm" command and re-install them.
+
+Last used date times are recorded in a single file $AQUA_ROOT_DIR/metadata/timestamps.json.
+To reduce writes, a last used date time is updated at most once an hour.
+Timestamp files created by old aqua versions ($AQUA_ROOT_DIR/metadata/pkgs/**/timestamp.txt) are migrated to the file automatically.
+
+Registry cache files ($AQUA_ROOT_DIR/registry-cache/*.json) which haven't been used for over the expiration days are removed too.
 `
 
 type command struct {
@@ -63,7 +69,7 @@ func New(r *util.Param) *cli.Command {
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
 				Name:  "init",
-				Usage: "Create timestamp files.",
+				Usage: "Record last used date times of installed packages.",
 			},
 			&cli.IntFlag{
 				Name:    "days",
@@ -5,6 +5,7 @@ package which
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -55,6 +56,21 @@ If you want the package version, "--version" option is useful.
 
 $ aqua which --version gh
 v2.4.0
+
+If you want the machine-readable output, "--format json" option is useful.
+"--json" is same as "--format json".
+
+$ aqua which --format json gh
+{
+  "exe_name": "gh",
+  "exe_path": "/home/foo/.aqua/pkgs/github_release/github.com/cli/cli/v2.4.0/gh_2.4.0_macOS_amd64.tar.gz/gh_2.4.0_macOS_amd64/bin/gh",
+  "package_name": "cli/cli",
+  "package_version": "v2.4.0",
+  "registry_name": "standard",
+  "config_file_path": "/home/foo/aqua.yaml"
+}
+
+If you want to know why the command is resolved to the path, please run "aqua why".
 `,
 		Action: i.action,
 		Flags: []cli.Flag{
@@ -63,6 +79,14 @@ v2.4.0
 				Aliases: []string{"v"},
 				Usage:   "Output the given package version",
 			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: `Output format. Only "json" is supported`,
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: `Output the result as JSON. This is same as "--format json"`,
+			},
 		},
 	}
 }
@@ -78,6 +102,10 @@ func (i *command) action(ctx context.Context, cmd *cli.Command) error {
 	if err := util.SetParam(cmd, i.r.LogE, "which", param, i.r.LDFlags); err != nil {
 		return fmt.Errorf("parse the command line arguments: %w", err)
 	}
+	isJSON, err := IsJSON(cmd)
+	if err != nil {
+		return err
+	}
 	ctrl := controller.InitializeWhichCommandController(ctx, i.r.LogE, param, http.DefaultClient, i.r.Runtime)
 	exeName, _, err := ParseExecArgs(cmd.Args().Slice())
 	if err != nil {
@@ -90,6 +118,14 @@ func (i *command) action(ctx context.Context, cmd *cli.Command) error {
 			"exe_name": exeName,
 		})
 	}
+	if isJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(which.Output(exeName)); err != nil {
+			return fmt.Errorf("encode the result as JSON: %w", err)
+		}
+		return nil
+	}
 	if !param.ShowVersion {
 		fmt.Fprintln(os.Stdout, which.ExePath)
 		return nil
@@ -103,7 +139,22 @@ func (i *command) action(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
-var errCommandIsRequired = errors.New("command is required")
+var (
+	errCommandIsRequired = errors.New("command is required")
+	errInvalidFormat     = errors.New("the output format must be json")
+)
+
+// IsJSON returns true if the result should be outputted as JSON by "--format json" or "--json".
+func IsJSON(cmd *cli.Command) (bool, error) {
+	switch cmd.String("format") {
+	case "":
+		return cmd.Bool("json"), nil
+	case "json":
+		return true, nil
+	default:
+		return false, errInvalidFormat
+	}
+}
 
 func ParseExecArgs(args []string) (string, []string, error) {
 	if len(args) == 0 {
@@ -0,0 +1,166 @@
+// Package why implements the aqua why command for explaining command resolution.
+// The why command shows configuration files and packages checked to resolve a command,
+// helping users understand why a given package version is used.
+package why
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/cli/profile"
+	"github.com/aquaproj/aqua/v2/pkg/cli/util"
+	"github.com/aquaproj/aqua/v2/pkg/cli/which"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/controller"
+	cwhich "github.com/aquaproj/aqua/v2/pkg/controller/which"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+	"github.com/urfave/cli/v3"
+)
+
+// command holds the parameters and configuration for the why command.
+type command struct {
+	r *util.Param
+}
+
+// New creates and returns a new CLI command for explaining command resolution.
+func New(r *util.Param) *cli.Command {
+	i := &command{
+		r: r,
+	}
+	return &cli.Command{
+		Name:      "why",
+		Usage:     "Explain how the given command is resolved",
+		ArgsUsage: `<command name>`,
+		Description: `Explain how the given command is resolved.
+
+aqua searches the command from configuration files in order and the first package providing the command is used.
+This command outputs each checked configuration file and packages which may provide the command.
+Configuration files after the selected package aren't checked.
+If a package is skipped, the reason is also outputted.
+
+e.g.
+$ aqua why gh
+Resolving the command "gh"
+
+1. /home/foo/workspace/foo/aqua.yaml
+   - cli/cli@v2.0.0 (registry: standard): skipped: the package isn't supported on this environment (windows/arm64)
+2. /home/foo/workspace/aqua.yaml
+   - cli/cli@v2.4.0 (registry: standard, import: /home/foo/workspace/aqua/gh.yaml): selected
+
+Result: cli/cli@v2.4.0
+/home/foo/.local/share/aquaproj-aqua/pkgs/github_release/github.com/cli/cli/v2.4.0/gh_2.4.0_windows_arm64.zip/bin/gh.exe
+
+If you want the machine-readable output, "--format json" option is useful.
+"--json" is same as "--format json".
+
+$ aqua why --format json gh`,
+		Action: i.action,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: `Output format. Only "json" is supported`,
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: `Output the result as JSON. This is same as "--format json"`,
+			},
+		},
+	}
+}
+
+func (i *command) action(ctx context.Context, cmd *cli.Command) error {
+	profiler, err := profile.Start(cmd)
+	if err != nil {
+		return fmt.Errorf("start CPU Profile or tracing: %w", err)
+	}
+	defer profiler.Stop()
+
+	param := &config.Param{}
+	if err := util.SetParam(cmd, i.r.LogE, "why", param, i.r.LDFlags); err != nil {
+		return fmt.Errorf("parse the command line arguments: %w", err)
+	}
+	isJSON, err := which.IsJSON(cmd)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	ctrl := controller.InitializeWhichCommandController(ctx, i.r.LogE, param, http.DefaultClient, i.r.Runtime)
+	exeName, _, err := which.ParseExecArgs(cmd.Args().Slice())
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	logE := i.r.LogE.WithField("exe_name", exeName)
+	trace, err := ctrl.Why(ctx, logE, param, exeName)
+	if err != nil {
+		return logerr.WithFields(err, logrus.Fields{ //nolint:wrapcheck
+			"exe_name": exeName,
+		})
+	}
+	if isJSON {
+		encoder := json.NewEncoder(i.r.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(trace); err != nil {
+			return fmt.Errorf("encode the result as JSON: %w", err)
+		}
+		return nil
+	}
+	writeTrace(i.r.Stdout, trace)
+	return nil
+}
+
+// writeTrace outputs the trace in the human-readable format.
+func writeTrace(w io.Writer, trace *cwhich.Trace) {
+	fmt.Fprintf(w, "Resolving the command %q\n\n", trace.ExeName)
+	for idx, cfg := range trace.Configs {
+		if cfg.Global {
+			fmt.Fprintf(w, "%d. %s (global)\n", idx+1, cfg.ConfigFilePath)
+		} else {
+			fmt.Fprintf(w, "%d. %s\n", idx+1, cfg.ConfigFilePath)
+		}
+		if cfg.SkipReason != "" {
+			fmt.Fprintf(w, "   skipped: %s\n", cfg.SkipReason)
+			continue
+		}
+		if len(cfg.Candidates) == 0 {
+			fmt.Fprintln(w, "   no package provides the command")
+			continue
+		}
+		for _, candidate := range cfg.Candidates {
+			fmt.Fprintf(w, "   - %s@%s (%s): %s\n", candidate.PackageName, candidate.PackageVersion, candidateAttrs(candidate), candidateState(candidate))
+		}
+	}
+	fmt.Fprintln(w)
+	switch {
+	case trace.Result != nil && trace.Result.PackageName != "":
+		fmt.Fprintf(w, "Result: %s@%s\n%s\n", trace.Result.PackageName, trace.Result.PackageVersion, trace.Result.ExePath)
+	case trace.PATH != "":
+		fmt.Fprintf(w, "Result: the command isn't managed by aqua and is found in the environment variable PATH\n%s\n", trace.PATH)
+	default:
+		fmt.Fprintln(w, "Result: the command isn't found")
+	}
+}
+
+func candidateAttrs(candidate *cwhich.Candidate) string {
+	attrs := []string{"registry: " + candidate.RegistryName}
+	if candidate.ImportFilePath != "" {
+		attrs = append(attrs, "import: "+candidate.ImportFilePath)
+	}
+	if candidate.Command != "" {
+		attrs = append(attrs, "alias of "+candidate.Command)
+	}
+	if len(candidate.Tags) != 0 {
+		attrs = append(attrs, "tags: "+strings.Join(candidate.Tags, ","))
+	}
+	return strings.Join(attrs, ", ")
+}
+
+func candidateState(candidate *cwhich.Candidate) string {
+	if candidate.Selected {
+		return "selected"
+	}
+	return "skipped: " + candidate.SkipReason
+}
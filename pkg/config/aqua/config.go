@@ -12,21 +12,22 @@ import (
 // Package represents a package definition in aqua.yaml configuration.
 // It contains package identification, version constraints, and customization options.
 type Package struct {
-	Name              string          `json:"name,omitempty"`                                                                                                         // Package name
-	Registry          string          `yaml:",omitempty" json:"registry,omitempty" jsonschema:"description=Registry name,example=foo,example=local,default=standard"` // Registry containing the package
-	Version           string          `yaml:",omitempty" json:"version,omitempty"`                                                                                    // Package version
-	Import            string          `yaml:",omitempty" json:"import,omitempty"`                                                                                     // Import path for configuration inclusion
-	Tags              []string        `yaml:",omitempty" json:"tags,omitempty"`                                                                                       // Package tags for filtering
-	Description       string          `yaml:",omitempty" json:"description,omitempty"`                                                                                // Package description
-	Link              string          `yaml:",omitempty" json:"link,omitempty"`                                                                                       // Package homepage link
-	Update            *Update         `yaml:",omitempty" json:"update,omitempty"`                                                                                     // Update configuration
-	FilePath          string          `yaml:"-" json:"-"`                                                                                                             // File path where package is defined
-	GoVersionFile     string          `yaml:"go_version_file,omitempty" json:"go_version_file,omitempty"`                                                             // Go version file path
-	VersionExpr       string          `yaml:"version_expr,omitempty" json:"version_expr,omitempty"`                                                                   // Version expression for dynamic versions
-	VersionExprPrefix string          `yaml:"version_expr_prefix,omitempty" json:"version_expr_prefix,omitempty"`                                                     // Prefix for version expressions
-	Vars              map[string]any  `yaml:",omitempty" json:"vars,omitempty"`                                                                                       // Package-specific variables
-	CommandAliases    []*CommandAlias `yaml:"command_aliases,omitempty" json:"command_aliases,omitempty"`                                                             // Command aliases for the package
-	Pin               bool            `yaml:"-" json:"-"`                                                                                                             // Whether the package version is pinned
+	Name              string            `json:"name,omitempty"`                                                                                                         // Package name
+	Registry          string            `yaml:",omitempty" json:"registry,omitempty" jsonschema:"description=Registry name,example=foo,example=local,default=standard"` // Registry containing the package
+	Version           string            `yaml:",omitempty" json:"version,omitempty"`                                                                                    // Package version
+	Import            string            `yaml:",omitempty" json:"import,omitempty"`                                                                                     // Import path for configuration inclusion
+	Tags              []string          `yaml:",omitempty" json:"tags,omitempty"`                                                                                       // Package tags for filtering
+	Description       string            `yaml:",omitempty" json:"description,omitempty"`                                                                                // Package description
+	Link              string            `yaml:",omitempty" json:"link,omitempty"`                                                                                       // Package homepage link
+	Update            *Update           `yaml:",omitempty" json:"update,omitempty"`                                                                                     // Update configuration
+	FilePath          string            `yaml:"-" json:"-"`                                                                                                             // File path where package is defined
+	GoVersionFile     string            `yaml:"go_version_file,omitempty" json:"go_version_file,omitempty"`                                                             // Go version file path
+	VersionExpr       string            `yaml:"version_expr,omitempty" json:"version_expr,omitempty"`                                                                   // Version expression for dynamic versions
+	VersionExprPrefix string            `yaml:"version_expr_prefix,omitempty" json:"version_expr_prefix,omitempty"`                                                     // Prefix for version expressions
+	Vars              map[string]any    `yaml:",omitempty" json:"vars,omitempty"`                                                                                       // Package-specific variables
+	CommandAliases    []*CommandAlias   `yaml:"command_aliases,omitempty" json:"command_aliases,omitempty"`                                                             // Command aliases for the package
+	Env               map[string]string `yaml:",omitempty" json:"env,omitempty"`                                                                                        // Environment variables set when the package's commands are executed
+	Pin               bool              `yaml:"-" json:"-"`                                                                                                             // Whether the package version is pinned
 }
 
 // CommandAlias defines an alias for a package command.
@@ -51,6 +52,10 @@ type Update struct {
 	// Types specifies which update types are allowed (major, minor, patch, other).
 	// By default, all types are allowed.
 	Types []string `yaml:",omitempty" json:"types,omitempty"`
+	// MinAge is the minimum duration since a version is published such as "72h".
+	// Versions published more recently are ignored.
+	// The published time is got from GitHub Releases, GitHub Tags (the time of the tagged commit), and crates.io.
+	MinAge string `yaml:"min_age,omitempty" json:"min_age,omitempty"`
 }
 
 // GetEnabled returns whether updates are enabled for this package.
@@ -16,4 +16,8 @@ var (
 	errRefIsRequired = errors.New("ref is required for github_content registry")
 	// errRefCannotBeMainOrMaster is returned when github_content registry uses unstable refs
 	errRefCannotBeMainOrMaster = errors.New("ref cannot be 'main' or 'master' for github_content registry")
+	// errExtendedRegistryNotFound is returned when extends of a registry matches no registry
+	errExtendedRegistryNotFound = errors.New("the extended registry isn't found")
+	// errExtendedRegistryIsAmbiguous is returned when extends of a registry matches multiple registries
+	errExtendedRegistryIsAmbiguous = errors.New("the extended registry is ambiguous. Please specify the ref like <repo_owner>/<repo_name>@<ref>")
 )
@@ -2,6 +2,8 @@ package aqua
 
 import (
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/aquaproj/aqua/v2/pkg/osfile"
 	"github.com/sirupsen/logrus"
@@ -110,3 +112,45 @@ func (r *Registry) validateGitHubContent() error {
 	}
 	return nil
 }
+
+// ExtendedRegistryName returns the name of the registry referred by extends of a registry.
+// extends is either a registry name or <repo_owner>/<repo_name>[@<ref>] of a github_content registry.
+// Registry names are defined by each configuration file,
+// so registries shared among configuration files should refer to the extended registry by the repository.
+// If ref is omitted, the repository must match only one registry.
+func (r Registries) ExtendedRegistryName(extends string) (string, error) {
+	repo, ref, _ := strings.Cut(extends, "@")
+	repoOwner, repoName, ok := strings.Cut(repo, "/")
+	if !ok {
+		if _, ok := r[extends]; !ok {
+			return "", logerr.WithFields(errExtendedRegistryNotFound, logrus.Fields{ //nolint:wrapcheck
+				"extends": extends,
+			})
+		}
+		return extends, nil
+	}
+	var names []string
+	for name, rg := range r {
+		if rg.Type != RegistryTypeGitHubContent || rg.RepoOwner != repoOwner || rg.RepoName != repoName {
+			continue
+		}
+		if ref != "" && rg.Ref != ref {
+			continue
+		}
+		names = append(names, name)
+	}
+	switch len(names) {
+	case 0:
+		return "", logerr.WithFields(errExtendedRegistryNotFound, logrus.Fields{ //nolint:wrapcheck
+			"extends": extends,
+		})
+	case 1:
+		return names[0], nil
+	default:
+		slices.Sort(names)
+		return "", logerr.WithFields(errExtendedRegistryIsAmbiguous, logrus.Fields{ //nolint:wrapcheck
+			"extends":        extends,
+			"registry_names": strings.Join(names, ", "),
+		})
+	}
+}
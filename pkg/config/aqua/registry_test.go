@@ -145,3 +145,98 @@ func TestRegistry_FilePath(t *testing.T) {
 		})
 	}
 }
+
+func TestRegistries_ExtendedRegistryName(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	registries := aqua.Registries{
+		"std": {
+			Name:      "std",
+			Type:      "github_content",
+			RepoOwner: "aquaproj",
+			RepoName:  "aqua-registry",
+			Ref:       "v4.0.0",
+			Path:      "registry.yaml",
+		},
+		"overlay": {
+			Name: "overlay",
+			Type: "local",
+			Path: "overlay.yaml",
+		},
+	}
+	data := []struct {
+		title      string
+		registries aqua.Registries
+		extends    string
+		exp        string
+		isErr      bool
+	}{
+		{
+			title:   "name",
+			extends: "std",
+			exp:     "std",
+		},
+		{
+			title:   "the local name is different",
+			extends: "standard",
+			isErr:   true,
+		},
+		{
+			title:   "repository",
+			extends: "aquaproj/aqua-registry",
+			exp:     "std",
+		},
+		{
+			title:   "repository and ref",
+			extends: "aquaproj/aqua-registry@v4.0.0",
+			exp:     "std",
+		},
+		{
+			title:   "ref is different",
+			extends: "aquaproj/aqua-registry@v3.0.0",
+			isErr:   true,
+		},
+		{
+			title:   "repository isn't found",
+			extends: "suzuki-shunsuke/aqua-registry",
+			isErr:   true,
+		},
+		{
+			title: "ambiguous",
+			registries: aqua.Registries{
+				"std": registries["std"],
+				"std-old": {
+					Name:      "std-old",
+					Type:      "github_content",
+					RepoOwner: "aquaproj",
+					RepoName:  "aqua-registry",
+					Ref:       "v3.0.0",
+					Path:      "registry.yaml",
+				},
+			},
+			extends: "aquaproj/aqua-registry",
+			isErr:   true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			rgs := registries
+			if d.registries != nil {
+				rgs = d.registries
+			}
+			name, err := rgs.ExtendedRegistryName(d.extends)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if name != d.exp {
+				t.Fatalf("wanted %s, got %s", d.exp, name)
+			}
+		})
+	}
+}
@@ -54,6 +54,9 @@ func (p *Package) getRuntimeFromAsset(asset string) (*runtime.Runtime, error) {
 	if err != nil {
 		return nil, fmt.Errorf("get supported runtimes from supported_envs: %w", err)
 	}
+	if p.PackageInfo.DependsOnLibc() {
+		rts = runtime.ExpandLibc(rts)
+	}
 	for _, rt := range rts {
 		a, err := p.RenderAsset(rt)
 		if err != nil {
@@ -115,6 +118,7 @@ func (p *Package) RenderChecksumURL(rt *runtime.Runtime) (string, error) {
 		"SemVer":  p.semVer(),
 		"GOOS":    rt.GOOS,
 		"GOARCH":  rt.GOARCH,
+		"Libc":    rt.Libc,
 		"OS":      replace(rt.GOOS, replacements),
 		"Arch":    getArch(pkgInfo.Rosetta2, pkgInfo.WindowsARMEmulation, replacements, rt),
 		"Format":  pkgInfo.GetFormat(),
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/aquaproj/aqua/v2/pkg/template"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// RenderEnv renders environment variables which are set when the package's commands are executed.
+// Environment variables in aqua.yaml take precedence over ones in the registry.
+// Values are templates, and the absolute path of the package directory is available as PkgDir.
+func (p *Package) RenderEnv(rootDir string, rt *runtime.Runtime) (map[string]string, error) {
+	if len(p.PackageInfo.Env) == 0 && len(p.Package.Env) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+	envs := make(map[string]string, len(p.PackageInfo.Env)+len(p.Package.Env))
+	maps.Copy(envs, p.PackageInfo.Env)
+	maps.Copy(envs, p.Package.Env)
+
+	pkgDir, err := p.AbsPkgPath(rootDir, rt)
+	if err != nil {
+		return nil, fmt.Errorf("get the package directory: %w", err)
+	}
+	pkg := p.Package
+	pkgInfo := p.PackageInfo
+	input := map[string]any{
+		"Version": pkg.Version,
+		"SemVer":  p.semVer(),
+		"GOOS":    rt.GOOS,
+		"GOARCH":  rt.GOARCH,
+		"Libc":    rt.Libc,
+		"OS":      replace(rt.GOOS, pkgInfo.Replacements),
+		"Arch":    getArch(pkgInfo.Rosetta2, pkgInfo.WindowsARMEmulation, pkgInfo.Replacements, rt),
+		"Format":  pkgInfo.GetFormat(),
+		"Vars":    pkg.Vars,
+		"PkgDir":  pkgDir,
+	}
+	for k, v := range envs {
+		s, err := template.Execute(v, input)
+		if err != nil {
+			return nil, fmt.Errorf("render an environment variable: %w", logerr.WithFields(err, logrus.Fields{
+				"env_name": k,
+			}))
+		}
+		envs[k] = s
+	}
+	return envs, nil
+}
@@ -0,0 +1,79 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPackage_RenderEnv(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		exp   map[string]string
+		pkg   *config.Package
+	}{
+		{
+			title: "no env",
+			pkg: &config.Package{
+				PackageInfo: &registry.PackageInfo{
+					Type:      "github_archive",
+					RepoOwner: "adoptium",
+					RepoName:  "temurin",
+				},
+				Package: &aqua.Package{
+					Version: "v21.0.0",
+				},
+			},
+		},
+		{
+			title: "registry and aqua.yaml",
+			exp: map[string]string{
+				"JAVA_HOME": "/home/foo/.local/share/aquaproj-aqua/pkgs/github_archive/github.com/adoptium/temurin/v21.0.0/jdk-21.0.0",
+				"FOO":       "linux",
+				"BAR":       "yoo",
+			},
+			pkg: &config.Package{
+				PackageInfo: &registry.PackageInfo{
+					Type:      "github_archive",
+					RepoOwner: "adoptium",
+					RepoName:  "temurin",
+					Env: map[string]string{
+						"JAVA_HOME": "{{.PkgDir}}/jdk-{{trimV .Version}}",
+						"BAR":       "bar",
+					},
+				},
+				Package: &aqua.Package{
+					Version: "v21.0.0",
+					Env: map[string]string{
+						"FOO": "{{.OS}}",
+						"BAR": "{{.Vars.bar}}",
+					},
+					Vars: map[string]any{
+						"bar": "yoo",
+					},
+				},
+			},
+		},
+	}
+	rt := &runtime.Runtime{
+		GOOS:   "linux",
+		GOARCH: "amd64",
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			envs, err := d.pkg.RenderEnv("/home/foo/.local/share/aquaproj-aqua", rt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(d.exp, envs); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -183,6 +183,7 @@ func (p *Package) RenderDir(file *registry.File, rt *runtime.Runtime) (string, e
 		"SemVer":   p.semVer(),
 		"GOOS":     rt.GOOS,
 		"GOARCH":   rt.GOARCH,
+		"Libc":     rt.Libc,
 		"OS":       replace(rt.GOOS, pkgInfo.Replacements),
 		"Arch":     getArch(pkgInfo.Rosetta2, pkgInfo.WindowsARMEmulation, pkgInfo.Replacements, rt),
 		"Format":   pkgInfo.GetFormat(),
@@ -238,6 +239,7 @@ func (p *Package) renderSrc(assetName string, file *registry.File, rt *runtime.R
 		"SemVer":          p.semVer(),
 		"GOOS":            rt.GOOS,
 		"GOARCH":          rt.GOARCH,
+		"Libc":            rt.Libc,
 		"OS":              replace(rt.GOOS, pkgInfo.Replacements),
 		"Arch":            getArch(pkgInfo.Rosetta2, pkgInfo.WindowsARMEmulation, pkgInfo.Replacements, rt),
 		"Format":          format,
@@ -353,6 +355,13 @@ type Param struct {
 	Dest                              string
 	HomeDir                           string
 	OutTestData                       string
+	OutputFormat                      string
+	ReleaseNotes                      string
+	ExportTarget                      string
+	ExportBaseImage                   string
+	RegistryFilePath                  string
+	GoldenFilePath                    string
+	FixtureDir                        string
 	Limit                             int
 	MaxParallelism                    int
 	VacuumDays                        int
@@ -360,6 +369,8 @@ type Param struct {
 	Args                              []string
 	PolicyConfigFilePaths             []string
 	Commands                          []string
+	ExcludedPaths                     []string
+	GitLabHosts                       []string
 	Tags                              map[string]struct{}
 	ExcludedTags                      map[string]struct{}
 	DisableLazyInstall                bool
@@ -374,6 +385,10 @@ type Param struct {
 	SkipLink                          bool
 	Pin                               bool
 	Prune                             bool
+	DryRun                            bool
+	Recursive                         bool
+	FromPath                          bool
+	UpdateVersionSource               bool
 	Checksum                          bool
 	RequireChecksum                   bool
 	EnforceChecksum                   bool
@@ -388,6 +403,8 @@ type Param struct {
 	SLSADisabled                      bool
 	Installed                         bool
 	InitConfig                        bool
+	Verify                            bool
+	UpdateGolden                      bool
 }
 
 // appendExt appends the appropriate file extension based on format.
@@ -451,6 +468,7 @@ func (p *Package) renderChecksumFile(asset string, rt *runtime.Runtime) (string,
 		"SemVer":  p.semVer(),
 		"GOOS":    rt.GOOS,
 		"GOARCH":  rt.GOARCH,
+		"Libc":    rt.Libc,
 		"OS":      replace(rt.GOOS, replacements),
 		"Arch":    getArch(pkgInfo.Rosetta2, pkgInfo.WindowsARMEmulation, replacements, rt),
 		"Format":  pkgInfo.GetFormat(),
@@ -473,6 +491,7 @@ func (p *Package) renderTemplate(tpl *texttemplate.Template, rt *runtime.Runtime
 		"SemVer":  p.semVer(),
 		"GOOS":    rt.GOOS,
 		"GOARCH":  rt.GOARCH,
+		"Libc":    rt.Libc,
 		"OS":      replace(rt.GOOS, pkgInfo.Replacements),
 		"Arch":    getArch(pkgInfo.Rosetta2, pkgInfo.WindowsARMEmulation, pkgInfo.Replacements, rt),
 		"Format":  pkgInfo.GetFormat(),
@@ -0,0 +1,190 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Extend returns the registry where packages of the registry are deep-merged onto packages of the base registry.
+// Packages of the registry which aren't found in the base registry are added as they are.
+// Packages of the base registry which aren't patched are included as they are.
+func (c *Config) Extend(logE *logrus.Entry, base *Config) (*Config, error) {
+	baseM := base.PackageInfos.ToMap(logE)
+	patches := map[*PackageInfo]*PackageInfo{}
+	var added PackageInfos
+	for _, patch := range c.PackageInfos {
+		if patch == nil {
+			continue
+		}
+		basePkg, ok := baseM[patch.GetName()]
+		if !ok {
+			added = append(added, patch)
+			continue
+		}
+		if _, ok := patches[basePkg]; ok {
+			logE.WithField("registry_package_name", patch.GetName()).Debug("ignore a patch because the package is already patched")
+			continue
+		}
+		patches[basePkg] = patch
+	}
+	extended := &Config{
+		PackageInfos: make(PackageInfos, 0, len(base.PackageInfos)+len(added)),
+		PackageFiles: base.PackageFiles,
+	}
+	for _, basePkg := range base.PackageInfos {
+		patch, ok := patches[basePkg]
+		if !ok {
+			extended.PackageInfos = append(extended.PackageInfos, basePkg)
+			continue
+		}
+		pkg, err := MergePackage(basePkg, patch)
+		if err != nil {
+			return nil, fmt.Errorf("merge a patch onto a package: %w", err)
+		}
+		extended.PackageInfos = append(extended.PackageInfos, pkg)
+	}
+	extended.PackageInfos = append(extended.PackageInfos, added...)
+	return extended, nil
+}
+
+// Patch controls how a package of an extending registry is merged onto the package of the extended registry.
+// It is ignored in registries which don't extend other registries.
+type Patch struct {
+	// Append lists fields whose lists are appended to lists of the extended package instead of replacing them.
+	// Nested fields are separated by dots such as `build.excluded_envs`.
+	Append []string `yaml:",omitempty" json:"append,omitempty"`
+	// Unset lists fields removed from the extended package before the patch is merged.
+	// Nested fields are separated by dots such as `checksum.cosign`.
+	Unset []string `yaml:",omitempty" json:"unset,omitempty"`
+}
+
+// MergePackage deep-merges the patch onto the base package and returns a new package.
+// Maps are merged recursively, and overrides are merged by goos, goarch, libc, and envs.
+// The other values such as lists are replaced unless they are listed in patch.append.
+// Zero values such as empty strings and false are ignored because they can't be distinguished from unset values,
+// so fields listed in patch.unset are removed before merging.
+func MergePackage(base, patch *PackageInfo) (*PackageInfo, error) {
+	baseMap, err := toMap(base)
+	if err != nil {
+		return nil, err
+	}
+	patchMap, err := toMap(patch)
+	if err != nil {
+		return nil, err
+	}
+	delete(patchMap, "patch")
+	appended := map[string]struct{}{}
+	if patch.Patch != nil {
+		for _, field := range patch.Patch.Unset {
+			unsetField(baseMap, field)
+		}
+		for _, field := range patch.Patch.Append {
+			appended[field] = struct{}{}
+		}
+	}
+	mergeMap(baseMap, patchMap, appended, "")
+	b, err := json.Marshal(baseMap)
+	if err != nil {
+		return nil, fmt.Errorf("encode a merged package as JSON: %w", err)
+	}
+	pkg := &PackageInfo{}
+	if err := json.Unmarshal(b, pkg); err != nil {
+		return nil, fmt.Errorf("decode a merged package: %w", err)
+	}
+	pkg.ErrorMessage = base.ErrorMessage
+	return pkg, nil
+}
+
+func toMap(pkg *PackageInfo) (map[string]any, error) {
+	b, err := json.Marshal(pkg)
+	if err != nil {
+		return nil, fmt.Errorf("encode a package as JSON: %w", err)
+	}
+	m := map[string]any{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("decode a package as a map: %w", err)
+	}
+	return m, nil
+}
+
+// unsetField removes the dot-separated field from the map.
+func unsetField(m map[string]any, field string) {
+	parent, key, ok := strings.Cut(field, ".")
+	if !ok {
+		delete(m, field)
+		return
+	}
+	if child, ok := m[parent].(map[string]any); ok {
+		unsetField(child, key)
+	}
+}
+
+// mergeMap merges src onto dst.
+// appended is a set of dot-separated fields whose lists are appended, and prefix is the path of dst.
+func mergeMap(dst, src map[string]any, appended map[string]struct{}, prefix string) {
+	for k, v := range src {
+		field := prefix + k
+		switch v := v.(type) {
+		case nil:
+			continue
+		case string:
+			if v == "" {
+				continue
+			}
+		case bool:
+			if !v {
+				continue
+			}
+		case map[string]any:
+			if d, ok := dst[k].(map[string]any); ok {
+				mergeMap(d, v, appended, field+".")
+				continue
+			}
+		case []any:
+			d, ok := dst[k].([]any)
+			if !ok {
+				break
+			}
+			if _, ok := appended[field]; ok {
+				dst[k] = append(d, v...)
+				continue
+			}
+			if field == "overrides" {
+				dst[k] = mergeOverrides(d, v)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
+
+// mergeOverrides merges overrides of the patch onto overrides of the base package.
+// Overrides are matched by goos, goarch, libc, and envs, and overrides which don't match are appended.
+func mergeOverrides(dst, src []any) []any {
+	for _, s := range src {
+		so, ok := s.(map[string]any)
+		if !ok {
+			continue
+		}
+		key := overrideKey(so)
+		idx := slices.IndexFunc(dst, func(d any) bool {
+			do, ok := d.(map[string]any)
+			return ok && overrideKey(do) == key
+		})
+		if idx == -1 {
+			dst = append(dst, so)
+			continue
+		}
+		mergeMap(dst[idx].(map[string]any), so, nil, "") //nolint:forcetypeassert
+	}
+	return dst
+}
+
+func overrideKey(override map[string]any) string {
+	envs, _ := json.Marshal(override["envs"]) //nolint:errchkjson
+	return fmt.Sprintf("%v\x00%v\x00%v\x00%s", override["goos"], override["goarch"], override["libc"], envs)
+}
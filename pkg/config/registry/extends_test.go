@@ -0,0 +1,230 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+func boolP(b bool) *bool {
+	return &b
+}
+
+func TestMergePackage(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name  string
+		base  *registry.PackageInfo
+		patch *registry.PackageInfo
+		exp   *registry.PackageInfo
+	}{
+		{
+			name: "override scalar values and replace lists",
+			base: &registry.PackageInfo{
+				Type:          "github_release",
+				RepoOwner:     "cli",
+				RepoName:      "cli",
+				Asset:         "gh_{{.OS}}_{{.Arch}}.tar.gz",
+				SupportedEnvs: registry.SupportedEnvs{"darwin", "linux"},
+				Files: []*registry.File{
+					{Name: "gh", Src: "bin/gh"},
+				},
+			},
+			patch: &registry.PackageInfo{
+				Name:          "cli/cli",
+				Type:          "http",
+				URL:           "https://mirror.example.com/gh/{{.Version}}/gh_{{.OS}}_{{.Arch}}.tar.gz",
+				SupportedEnvs: registry.SupportedEnvs{"darwin", "linux", "windows"},
+			},
+			exp: &registry.PackageInfo{
+				Name:          "cli/cli",
+				Type:          "http",
+				RepoOwner:     "cli",
+				RepoName:      "cli",
+				Asset:         "gh_{{.OS}}_{{.Arch}}.tar.gz",
+				URL:           "https://mirror.example.com/gh/{{.Version}}/gh_{{.OS}}_{{.Arch}}.tar.gz",
+				SupportedEnvs: registry.SupportedEnvs{"darwin", "linux", "windows"},
+				Files: []*registry.File{
+					{Name: "gh", Src: "bin/gh"},
+				},
+			},
+		},
+		{
+			name: "merge maps",
+			base: &registry.PackageInfo{
+				Type:      "github_release",
+				RepoOwner: "cli",
+				RepoName:  "cli",
+				Checksum: &registry.Checksum{
+					Type:      "github_release",
+					Asset:     "gh_{{trimV .Version}}_checksums.txt",
+					Algorithm: "sha256",
+				},
+			},
+			patch: &registry.PackageInfo{
+				Name: "cli/cli",
+				Checksum: &registry.Checksum{
+					Asset: "checksums.txt",
+				},
+				Cosign: &registry.Cosign{
+					Enabled: boolP(true),
+				},
+			},
+			exp: &registry.PackageInfo{
+				Name:      "cli/cli",
+				Type:      "github_release",
+				RepoOwner: "cli",
+				RepoName:  "cli",
+				Checksum: &registry.Checksum{
+					Type:      "github_release",
+					Asset:     "checksums.txt",
+					Algorithm: "sha256",
+				},
+				Cosign: &registry.Cosign{
+					Enabled: boolP(true),
+				},
+			},
+		},
+		{
+			name: "merge overrides by goos and goarch",
+			base: &registry.PackageInfo{
+				Type:      "github_release",
+				RepoOwner: "cli",
+				RepoName:  "cli",
+				Overrides: []*registry.Override{
+					{GOOS: "windows", Format: "zip"},
+					{GOOS: "darwin", GOArch: "arm64", Asset: "gh_macOS_arm64.tar.gz"},
+				},
+			},
+			patch: &registry.PackageInfo{
+				Name: "cli/cli",
+				Overrides: []*registry.Override{
+					{GOOS: "windows", Asset: "gh_windows.zip"},
+					{GOOS: "linux", Libc: "musl", Asset: "gh_linux_musl.tar.gz"},
+				},
+			},
+			exp: &registry.PackageInfo{
+				Name:      "cli/cli",
+				Type:      "github_release",
+				RepoOwner: "cli",
+				RepoName:  "cli",
+				Overrides: []*registry.Override{
+					{GOOS: "windows", Format: "zip", Asset: "gh_windows.zip"},
+					{GOOS: "darwin", GOArch: "arm64", Asset: "gh_macOS_arm64.tar.gz"},
+					{GOOS: "linux", Libc: "musl", Asset: "gh_linux_musl.tar.gz"},
+				},
+			},
+		},
+		{
+			name: "append lists",
+			base: &registry.PackageInfo{
+				Type:          "github_release",
+				RepoOwner:     "cli",
+				RepoName:      "cli",
+				SupportedEnvs: registry.SupportedEnvs{"darwin", "linux"},
+				Files: []*registry.File{
+					{Name: "gh"},
+				},
+			},
+			patch: &registry.PackageInfo{
+				Name:          "cli/cli",
+				SupportedEnvs: registry.SupportedEnvs{"windows"},
+				Files: []*registry.File{
+					{Name: "gh-mirror"},
+				},
+				Patch: &registry.Patch{
+					Append: []string{"supported_envs"},
+				},
+			},
+			exp: &registry.PackageInfo{
+				Name:          "cli/cli",
+				Type:          "github_release",
+				RepoOwner:     "cli",
+				RepoName:      "cli",
+				SupportedEnvs: registry.SupportedEnvs{"darwin", "linux", "windows"},
+				Files: []*registry.File{
+					{Name: "gh-mirror"},
+				},
+			},
+		},
+		{
+			name: "unset fields",
+			base: &registry.PackageInfo{
+				Type:      "github_release",
+				RepoOwner: "cli",
+				RepoName:  "cli",
+				Rosetta2:  true,
+				Checksum: &registry.Checksum{
+					Type:  "github_release",
+					Asset: "checksums.txt",
+					Cosign: &registry.Cosign{
+						Enabled: boolP(true),
+					},
+				},
+			},
+			patch: &registry.PackageInfo{
+				Name: "cli/cli",
+				Patch: &registry.Patch{
+					Unset: []string{"rosetta2", "checksum.cosign"},
+				},
+			},
+			exp: &registry.PackageInfo{
+				Name:      "cli/cli",
+				Type:      "github_release",
+				RepoOwner: "cli",
+				RepoName:  "cli",
+				Checksum: &registry.Checksum{
+					Type:  "github_release",
+					Asset: "checksums.txt",
+				},
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			pkg, err := registry.MergePackage(d.base, d.patch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(d.exp, pkg); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestConfig_Extend(t *testing.T) {
+	t.Parallel()
+	logE := logrus.NewEntry(logrus.New())
+	base := &registry.Config{
+		PackageInfos: registry.PackageInfos{
+			{Type: "github_release", RepoOwner: "cli", RepoName: "cli", Asset: "gh.tar.gz"},
+			{Type: "github_release", RepoOwner: "suzuki-shunsuke", RepoName: "tfcmt", Asset: "tfcmt.tar.gz", Aliases: []*registry.Alias{{Name: "tfcmt"}}},
+		},
+	}
+	overlay := &registry.Config{
+		Extends: "standard",
+		PackageInfos: registry.PackageInfos{
+			{Name: "tfcmt", Asset: "tfcmt-mirror.tar.gz"},
+			{Type: "github_release", RepoOwner: "example", RepoName: "internal-tool", Asset: "tool.tar.gz"},
+		},
+	}
+	extended, err := overlay.Extend(logE, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := registry.PackageInfos{
+		{Type: "github_release", RepoOwner: "cli", RepoName: "cli", Asset: "gh.tar.gz"},
+		{Name: "tfcmt", Type: "github_release", RepoOwner: "suzuki-shunsuke", RepoName: "tfcmt", Asset: "tfcmt-mirror.tar.gz", Aliases: []*registry.Alias{{Name: "tfcmt"}}},
+		{Type: "github_release", RepoOwner: "example", RepoName: "internal-tool", Asset: "tool.tar.gz"},
+	}
+	if diff := cmp.Diff(exp, extended.PackageInfos); diff != "" {
+		t.Fatal(diff)
+	}
+	if extended.Extends != "" {
+		t.Fatal("the extended registry must not extend other registries")
+	}
+}
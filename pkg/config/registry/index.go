@@ -0,0 +1,181 @@
+package registry
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+// indexVersion is the version of the index format.
+// Indexes of other versions are ignored and rebuilt.
+const indexVersion = 1
+
+var errIndexVersionMismatch = errors.New("the version of the registry index is different")
+
+// Index is a prebuilt index of a registry.
+// Registries such as the standard registry are several megabytes,
+// so decoding the whole registry to look up a few packages is slow.
+//
+// An index file consists of a header line and package lines.
+// The header is Index encoded as JSON, and each package line is PackageInfo encoded as JSON.
+// Packages can be decoded individually by byte ranges in the header.
+type Index struct {
+	Version int `json:"version"`
+	// Packages maps package names and aliases to package lines.
+	Packages map[string]*IndexEntry `json:"packages"`
+	path     string
+	offset   int64
+}
+
+// IndexEntry is an entry of a package in the registry index.
+type IndexEntry struct {
+	// Name is the package name. If the entry is for an alias, Name is the name of the aliased package.
+	Name string `json:"name"`
+	// Commands is the list of commands which can be in the package.
+	Commands []string `json:"commands,omitempty"`
+	// Offset is the offset of the package line from the end of the header.
+	Offset int64 `json:"offset"`
+	Length int64 `json:"length"`
+}
+
+// IndexFilePath returns the path of the index file of the registry file.
+func IndexFilePath(registryFilePath string) string {
+	return registryFilePath + ".index"
+}
+
+// WriteIndex builds the index of the registry and writes it.
+// The index file is replaced atomically so that other processes never read a partial index.
+func WriteIndex(logE *logrus.Entry, fs afero.Fs, p string, cfg *Config) error {
+	idx := &Index{
+		Version:  indexVersion,
+		Packages: map[string]*IndexEntry{},
+	}
+	body := &bytes.Buffer{}
+	m := cfg.PackageInfos.ToMap(logE)
+	// Packages are written in the order of the registry so that the index is reproducible.
+	for _, pkgInfo := range cfg.PackageInfos {
+		if pkgInfo == nil {
+			continue
+		}
+		name := pkgInfo.GetName()
+		if m[name] != pkgInfo {
+			// duplicate packages are ignored
+			continue
+		}
+		b, err := json.Marshal(pkgInfo)
+		if err != nil {
+			return fmt.Errorf("encode a package as JSON: %w", err)
+		}
+		idx.Packages[name] = &IndexEntry{
+			Name:     name,
+			Commands: pkgInfo.CommandNames(),
+			Offset:   int64(body.Len()),
+			Length:   int64(len(b)),
+		}
+		body.Write(b)
+		body.WriteByte('\n')
+	}
+	for name, pkgInfo := range m {
+		if name != pkgInfo.GetName() {
+			idx.Packages[name] = idx.Packages[pkgInfo.GetName()]
+		}
+	}
+	header, err := json.Marshal(idx)
+	if err != nil {
+		return fmt.Errorf("encode a registry index as JSON: %w", err)
+	}
+
+	f, err := afero.TempFile(fs, filepath.Dir(p), filepath.Base(p)+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("create a temporary registry index: %w", err)
+	}
+	tmp := f.Name()
+	if err := writeIndex(f, header, body.Bytes()); err != nil {
+		f.Close()
+		_ = fs.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = fs.Remove(tmp)
+		return fmt.Errorf("close a temporary registry index: %w", err)
+	}
+	if err := fs.Rename(tmp, p); err != nil {
+		_ = fs.Remove(tmp)
+		return fmt.Errorf("rename a temporary registry index: %w", err)
+	}
+	return nil
+}
+
+func writeIndex(f afero.File, header, body []byte) error {
+	if _, err := f.Write(append(header, '\n')); err != nil {
+		return fmt.Errorf("write a registry index header: %w", err)
+	}
+	if _, err := f.Write(body); err != nil {
+		return fmt.Errorf("write packages to a registry index: %w", err)
+	}
+	return nil
+}
+
+// ReadIndex reads the header of the index file.
+// Packages are decoded by Package when they are needed.
+func ReadIndex(fs afero.Fs, p string) (*Index, error) {
+	f, err := fs.Open(p)
+	if err != nil {
+		return nil, fmt.Errorf("open a registry index: %w", err)
+	}
+	defer f.Close()
+	header, err := bufio.NewReader(f).ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("read a registry index header: %w", err)
+	}
+	idx := &Index{}
+	if err := json.Unmarshal(header, idx); err != nil {
+		return nil, fmt.Errorf("parse a registry index header: %w", err)
+	}
+	if idx.Version != indexVersion {
+		return nil, errIndexVersionMismatch
+	}
+	idx.path = p
+	idx.offset = int64(len(header))
+	return idx, nil
+}
+
+// MaybeHasCommand returns true if the package can have the command.
+// If the package isn't found in the index, the second return value is false.
+func (idx *Index) MaybeHasCommand(pkgName, exeName string) (bool, bool) {
+	entry, ok := idx.Packages[pkgName]
+	if !ok {
+		return false, false
+	}
+	return slices.Contains(entry.Commands, exeName), true
+}
+
+// Package decodes the package from the index file.
+// If the package isn't found, Package returns nil.
+func (idx *Index) Package(fs afero.Fs, pkgName string) (*PackageInfo, error) {
+	entry, ok := idx.Packages[pkgName]
+	if !ok {
+		return nil, nil //nolint:nilnil
+	}
+	f, err := fs.Open(idx.path)
+	if err != nil {
+		return nil, fmt.Errorf("open a registry index: %w", err)
+	}
+	defer f.Close()
+	b := make([]byte, entry.Length)
+	if _, err := f.ReadAt(b, idx.offset+entry.Offset); err != nil {
+		return nil, fmt.Errorf("read a package from a registry index: %w", err)
+	}
+	pkgInfo := &PackageInfo{}
+	if err := json.Unmarshal(b, pkgInfo); err != nil {
+		return nil, fmt.Errorf("parse a package in a registry index: %w", err)
+	}
+	return pkgInfo, nil
+}
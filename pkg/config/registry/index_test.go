@@ -0,0 +1,114 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+func TestIndex(t *testing.T) { //nolint:cyclop
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	logE := logrus.NewEntry(logrus.New())
+	cfg := &registry.Config{
+		PackageInfos: registry.PackageInfos{
+			{
+				Type:      "github_release",
+				RepoOwner: "suzuki-shunsuke",
+				RepoName:  "tfcmt",
+				Aliases: []*registry.Alias{
+					{Name: "tfcmt"},
+				},
+			},
+			{
+				Type:      "github_release",
+				RepoOwner: "cli",
+				RepoName:  "cli",
+				Files: []*registry.File{
+					{Name: "gh"},
+				},
+			},
+			{
+				// duplicate
+				Type:      "github_release",
+				RepoOwner: "cli",
+				RepoName:  "cli",
+			},
+		},
+	}
+	p := registry.IndexFilePath("/home/foo/registry.yaml")
+	if err := registry.WriteIndex(logE, fs, p, cfg); err != nil {
+		t.Fatal(err)
+	}
+	idx, err := registry.ReadIndex(fs, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"suzuki-shunsuke/tfcmt", "tfcmt"} {
+		pkgInfo, err := idx.Package(fs, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(cfg.PackageInfos[0], pkgInfo); diff != "" {
+			t.Fatal(diff)
+		}
+	}
+	pkgInfo, err := idx.Package(fs, "cli/cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(cfg.PackageInfos[1], pkgInfo); diff != "" {
+		t.Fatal(diff)
+	}
+	pkgInfo, err = idx.Package(fs, "foo/foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pkgInfo != nil {
+		t.Fatal("unknown package must not be found")
+	}
+
+	data := []struct {
+		pkgName    string
+		exeName    string
+		hasCommand bool
+		found      bool
+	}{
+		{pkgName: "tfcmt", exeName: "tfcmt", hasCommand: true, found: true},
+		{pkgName: "cli/cli", exeName: "gh", hasCommand: true, found: true},
+		{pkgName: "cli/cli", exeName: "cli", found: true},
+		{pkgName: "foo/foo", exeName: "foo"},
+	}
+	for _, d := range data {
+		hasCommand, found := idx.MaybeHasCommand(d.pkgName, d.exeName)
+		if hasCommand != d.hasCommand || found != d.found {
+			t.Errorf("MaybeHasCommand(%q, %q) = (%v, %v), wanted (%v, %v)", d.pkgName, d.exeName, hasCommand, found, d.hasCommand, d.found)
+		}
+	}
+
+	files, err := afero.ReadDir(fs, "/home/foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("temporary files must be removed: %d files", len(files))
+	}
+}
+
+func TestReadIndex(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "/home/foo/registry.yaml.index", []byte(`{"version":0,"packages":{}}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := registry.ReadIndex(fs, "/home/foo/registry.yaml.index"); err == nil {
+		t.Fatal("an index of other versions must be rejected")
+	}
+	if _, err := registry.ReadIndex(fs, "/home/foo/not-found.index"); err == nil {
+		t.Fatal("an error must be returned if the index doesn't exist")
+	}
+}
@@ -1,6 +1,8 @@
 package registry
 
 import (
+	"slices"
+
 	"github.com/aquaproj/aqua/v2/pkg/runtime"
 	"github.com/sirupsen/logrus"
 )
@@ -30,6 +32,9 @@ func (ov *Override) Match(rt *runtime.Runtime) bool {
 	if ov.GOArch != "" && ov.GOArch != rt.GOARCH {
 		return false
 	}
+	if ov.Libc != "" && ov.Libc != rt.Libc {
+		return false
+	}
 	if ov.Envs != nil {
 		if !matchEnvs(ov.Envs, rt.GOOS, rt.GOARCH, rt.GOOS+"/"+rt.GOARCH, false, false) {
 			return false
@@ -37,3 +42,20 @@ func (ov *Override) Match(rt *runtime.Runtime) bool {
 	}
 	return true
 }
+
+// DependsOnLibc returns true if some overrides of the package depend on libc.
+func (p *PackageInfo) DependsOnLibc() bool {
+	if slices.ContainsFunc(p.Overrides, overrideDependsOnLibc) {
+		return true
+	}
+	for _, vo := range p.VersionOverrides {
+		if slices.ContainsFunc(vo.Overrides, overrideDependsOnLibc) {
+			return true
+		}
+	}
+	return false
+}
+
+func overrideDependsOnLibc(ov *Override) bool {
+	return ov.Libc != ""
+}
@@ -157,6 +157,41 @@ func TestOverride_Match(t *testing.T) {
 				GOARCH: "amd64",
 			},
 		},
+		{
+			title: "libc doesn't match",
+			override: &registry.Override{
+				GOOS: "linux",
+				Libc: "musl",
+			},
+			rt: &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+				Libc:   "glibc",
+			},
+		},
+		{
+			title: "unknown libc doesn't match",
+			override: &registry.Override{
+				Libc: "musl",
+			},
+			rt: &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+			},
+		},
+		{
+			title: "libc matches",
+			exp:   true,
+			override: &registry.Override{
+				GOOS: "linux",
+				Libc: "musl",
+			},
+			rt: &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+				Libc:   "musl",
+			},
+		},
 		{
 			title: "match",
 			exp:   true,
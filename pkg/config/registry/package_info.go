@@ -17,6 +17,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/aquaproj/aqua/v2/pkg/runtime"
@@ -67,6 +68,7 @@ type PackageInfo struct {
 	WindowsARMEmulation        bool                        `yaml:"windows_arm_emulation,omitempty" json:"windows_arm_emulation,omitempty"`
 	NoAsset                    bool                        `yaml:"no_asset,omitempty" json:"no_asset,omitempty"`
 	VersionSource              string                      `yaml:"version_source,omitempty" json:"version_source,omitempty" jsonschema:"enum=github_tag"`
+	VersionCommand             string                      `yaml:"version_command,omitempty" json:"version_command,omitempty" jsonschema:"example=--version,example=version"`
 	CompleteWindowsExt         *bool                       `yaml:"complete_windows_ext,omitempty" json:"complete_windows_ext,omitempty"`
 	WindowsExt                 string                      `yaml:"windows_ext,omitempty" json:"windows_ext,omitempty"`
 	Private                    bool                        `yaml:",omitempty" json:"private,omitempty"`
@@ -86,8 +88,10 @@ type PackageInfo struct {
 	GitHubArtifactAttestations *GitHubArtifactAttestations `yaml:"github_artifact_attestations,omitempty" json:"github_artifact_attestations,omitempty"`
 	GitHubImmutableRelease     bool                        `yaml:"github_immutable_release,omitempty" json:"github_immutable_release,omitempty"`
 	Vars                       []*Var                      `yaml:",omitempty" json:"vars,omitempty"`
+	Env                        map[string]string           `yaml:",omitempty" json:"env,omitempty"`
 	VersionConstraints         string                      `yaml:"version_constraint,omitempty" json:"version_constraint,omitempty"`
 	VersionOverrides           []*VersionOverride          `yaml:"version_overrides,omitempty" json:"version_overrides,omitempty"`
+	Patch                      *Patch                      `yaml:",omitempty" json:"patch,omitempty"`
 }
 
 // Var represents a template variable that can be used in package configurations
@@ -174,6 +178,7 @@ type VersionOverride struct {
 	GitHubImmutableRelease     *bool                       `yaml:"github_immutable_release,omitempty" json:"github_immutable_release,omitempty"`
 	Build                      *Build                      `yaml:",omitempty" json:"build,omitempty"`
 	Vars                       []*Var                      `yaml:",omitempty" json:"vars,omitempty"`
+	Env                        map[string]string           `yaml:",omitempty" json:"env,omitempty"`
 	Overrides                  Overrides                   `yaml:",omitempty" json:"overrides,omitempty"`
 	SupportedEnvs              SupportedEnvs               `yaml:"supported_envs,omitempty" json:"supported_envs,omitempty"`
 }
@@ -183,6 +188,7 @@ type VersionOverride struct {
 type Override struct {
 	GOOS                       string                      `yaml:",omitempty" json:"goos,omitempty" jsonschema:"enum=darwin,enum=linux,enum=windows"`
 	GOArch                     string                      `yaml:",omitempty" json:"goarch,omitempty" jsonschema:"enum=amd64,enum=arm64"`
+	Libc                       string                      `yaml:",omitempty" json:"libc,omitempty" jsonschema:"enum=glibc,enum=musl"`
 	Type                       string                      `yaml:",omitempty" json:"type,omitempty" jsonschema:"enum=github_release,enum=github_content,enum=github_archive,enum=http,enum=go,enum=go_install,enum=cargo,enum=go_build"`
 	Format                     string                      `yaml:",omitempty" json:"format,omitempty" jsonschema:"example=tar.gz,example=raw,example=zip"`
 	Asset                      string                      `yaml:",omitempty" json:"asset,omitempty"`
@@ -236,6 +242,7 @@ func (p *PackageInfo) Copy() *PackageInfo {
 		WindowsARMEmulation:        p.WindowsARMEmulation,
 		Aliases:                    p.Aliases,
 		VersionSource:              p.VersionSource,
+		VersionCommand:             p.VersionCommand,
 		CompleteWindowsExt:         p.CompleteWindowsExt,
 		WindowsExt:                 p.WindowsExt,
 		Checksum:                   p.Checksum,
@@ -250,6 +257,7 @@ func (p *PackageInfo) Copy() *PackageInfo {
 		AppendExt:                  p.AppendExt,
 		Build:                      p.Build,
 		Vars:                       p.Vars,
+		Env:                        p.Env,
 	}
 	return pkg
 }
@@ -597,6 +605,16 @@ func doFilesContain(files []*File, exeName string, isEmpty *bool) bool {
 	return false
 }
 
+func addFileNames(names map[string]struct{}, files []*File, isEmpty *bool) {
+	if len(files) == 0 {
+		*isEmpty = true
+	}
+
+	for _, f := range files {
+		names[f.Name] = struct{}{}
+	}
+}
+
 // MaybeHasCommand returns true if the given exe name can be in this package.
 // This includes file lists that may only be used under specific versions or
 // host platforms.
@@ -642,6 +660,48 @@ func (p *PackageInfo) MaybeHasCommand(exeName string) bool { //nolint:cyclop
 	return false
 }
 
+// CommandNames returns sorted names of all commands which can be in this package.
+// This includes file lists that may only be used under specific versions or
+// host platforms.
+func (p *PackageInfo) CommandNames() []string {
+	return slices.Sorted(maps.Keys(p.commandNames()))
+}
+
+func (p *PackageInfo) commandNames() map[string]struct{} {
+	names := map[string]struct{}{}
+	anyListEmpty := false
+
+	addFileNames(names, p.Files, &anyListEmpty)
+
+	if p.Build != nil {
+		addFileNames(names, p.Build.Files, &anyListEmpty)
+	}
+
+	for _, v := range p.VersionOverrides {
+		addFileNames(names, v.Files, &anyListEmpty)
+
+		if v.Build != nil {
+			addFileNames(names, v.Build.Files, &anyListEmpty)
+		}
+
+		for _, o := range v.Overrides {
+			addFileNames(names, o.Files, &anyListEmpty)
+		}
+	}
+
+	for _, o := range p.Overrides {
+		addFileNames(names, o.Files, &anyListEmpty)
+	}
+
+	// If any of the file lists that could be used are empty, then the default
+	// command name would be used, so add it as well.
+	if anyListEmpty {
+		names[p.defaultCmdName()] = struct{}{}
+	}
+
+	return names
+}
+
 var placeHolderTemplate = regexp.MustCompile(`{{.*?}}`)
 
 // PkgPaths returns all possible package installation paths for this package.
@@ -839,6 +899,9 @@ func (p *PackageInfo) overrideVersion(child *VersionOverride) *PackageInfo { //n
 	if child.Vars != nil {
 		pkg.Vars = child.Vars
 	}
+	if child.Env != nil {
+		pkg.Env = child.Env
+	}
 	return pkg
 }
 
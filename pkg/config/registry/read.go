@@ -0,0 +1,30 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v2"
+)
+
+// ReadFile reads a registry configuration file.
+// A file with the extension .json is parsed as JSON and other files are parsed as YAML.
+func ReadFile(fs afero.Fs, p string, cfg *Config) error {
+	f, err := fs.Open(p)
+	if err != nil {
+		return fmt.Errorf("open the registry configuration file: %w", err)
+	}
+	defer f.Close()
+	if filepath.Ext(p) == ".json" {
+		if err := json.NewDecoder(f).Decode(cfg); err != nil {
+			return fmt.Errorf("parse the registry configuration as JSON: %w", err)
+		}
+		return nil
+	}
+	if err := yaml.NewDecoder(f).Decode(cfg); err != nil {
+		return fmt.Errorf("parse the registry configuration as YAML: %w", err)
+	}
+	return nil
+}
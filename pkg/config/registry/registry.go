@@ -9,6 +9,13 @@ import (
 type Config struct {
 	// PackageInfos contains all package definitions in the registry.
 	PackageInfos PackageInfos `yaml:"packages" json:"packages"`
+	// PackageFiles lists package files of a split registry.
+	// If PackageFiles is set, package definitions are loaded from package files lazily.
+	PackageFiles []*PackageFile `yaml:"package_files,omitempty" json:"package_files,omitempty"`
+	// Extends refers to the registry which this registry extends.
+	// It's either a registry name in the configuration file or <repo_owner>/<repo_name>[@<ref>] of a github_content registry.
+	// If Extends is set, packages are patches deep-merged onto packages of the extended registry.
+	Extends string `yaml:",omitempty" json:"extends,omitempty"`
 	// m is an internal cache of packages indexed by name (including aliases).
 	m map[string]*PackageInfo
 }
@@ -1,92 +1,226 @@
 package registry
 
 import (
-	"encoding/base64"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/aquaproj/aqua/v2/pkg/osfile"
 	"github.com/spf13/afero"
 )
 
+// CacheKey identifies a registry in the registry cache.
+// Registry caches are shared by all configuration files referencing the same registry,
+// so the key consists of the registry's identity and the hash of its content
+// rather than the path of the configuration file.
+type CacheKey struct {
+	Type        string `json:"type"`
+	RepoOwner   string `json:"repo_owner,omitempty"`
+	RepoName    string `json:"repo_name,omitempty"`
+	Ref         string `json:"ref,omitempty"`
+	Path        string `json:"path,omitempty"`
+	ContentHash string `json:"content_hash,omitempty"`
+}
+
+// ID returns the identifier of the key, which is used as the cache file name.
+// Fields are joined with NUL, which can't be included in them, so different keys have different IDs.
+func (k *CacheKey) ID() string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		k.Type, k.RepoOwner, k.RepoName, k.Ref, k.Path, k.ContentHash,
+	}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// CacheFile is the content of a registry cache file.
+type CacheFile struct {
+	Key      *CacheKey               `json:"key"`
+	Packages map[string]*PackageInfo `json:"packages"`
+}
+
+// Cache caches package definitions per registry to avoid parsing large registry files.
+// Cache files are read lazily and written atomically so that multiple processes can share them.
 type Cache struct {
-	m       map[string]map[string]*PackageInfo
 	fs      afero.Fs
-	path    string
-	updated bool
+	dir     string
+	entries map[string]*cacheEntry
 }
 
-func NewCache(fs afero.Fs, rootDir, cfgFilePath string) (*Cache, error) {
-	c := &Cache{
-		m:    map[string]map[string]*PackageInfo{},
-		fs:   fs,
-		path: filepath.Join(rootDir, "registry-cache", base64.StdEncoding.EncodeToString([]byte(cfgFilePath))+".json"),
+type cacheEntry struct {
+	key      *CacheKey
+	packages map[string]*PackageInfo
+	added    map[string]*PackageInfo
+}
+
+// CacheDir returns the directory where registry cache files are stored.
+func CacheDir(rootDir string) string {
+	return filepath.Join(rootDir, "registry-cache")
+}
+
+// NewCache returns a registry cache stored in the root directory.
+func NewCache(fs afero.Fs, rootDir string) *Cache {
+	return &Cache{
+		fs:      fs,
+		dir:     CacheDir(rootDir),
+		entries: map[string]*cacheEntry{},
 	}
-	return c, c.read()
 }
 
-func (c *Cache) Clean(keys map[string]map[string]struct{}) {
-	for rgPath, pkgInfos := range c.m {
-		a, ok := keys[rgPath]
-		if !ok {
-			delete(c.m, rgPath)
-			c.updated = true
+// PruneCache removes registry cache files which are expired.
+// The modification time of a cache file is the last used time because it's updated when the file is read.
+// It returns paths of removed files.
+func PruneCache(fs afero.Fs, rootDir string, expired func(time.Time) bool) ([]string, error) {
+	dir := CacheDir(rootDir)
+	infos, err := afero.ReadDir(fs, dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read the registry cache directory: %w", err)
+	}
+	var removed []string
+	for _, info := range infos {
+		if info.IsDir() || !expired(info.ModTime()) {
 			continue
 		}
-		for pkgName := range pkgInfos {
-			if _, ok := a[pkgName]; !ok {
-				delete(pkgInfos, pkgName)
-				c.updated = true
-			}
+		p := filepath.Join(dir, info.Name())
+		if err := fs.Remove(p); err != nil {
+			return removed, fmt.Errorf("remove a registry cache file: %w", err)
 		}
+		removed = append(removed, p)
 	}
+	return removed, nil
 }
 
-func (c *Cache) Write() error {
-	if !c.updated {
-		return nil
-	}
-	if err := osfile.MkdirAll(c.fs, filepath.Dir(c.path)); err != nil {
-		return fmt.Errorf("create a directory: %w", err)
-	}
-	f, err := c.fs.Create(c.path)
+// ReadCacheFile reads a registry cache file.
+func ReadCacheFile(fs afero.Fs, p string) (*CacheFile, error) {
+	f, err := fs.Open(p)
 	if err != nil {
-		return fmt.Errorf("create a registry cache: %w", err)
+		return nil, fmt.Errorf("open a registry cache: %w", err)
 	}
 	defer f.Close()
-	if err := json.NewEncoder(f).Encode(c.m); err != nil {
-		return fmt.Errorf("encode registry cache: %w", err)
+	cf := &CacheFile{}
+	if err := json.NewDecoder(f).Decode(cf); err != nil {
+		return nil, fmt.Errorf("parse the registry cache file: %w", err)
 	}
-	return nil
+	return cf, nil
 }
 
-func (c *Cache) Add(rgPath string, pkgInfo *PackageInfo) {
-	c.updated = true
-	m, ok := c.m[rgPath]
-	if !ok {
-		m = map[string]*PackageInfo{}
-		c.m[rgPath] = m
+// Get returns the cached package.
+// If the package isn't cached, Get returns nil.
+func (c *Cache) Get(key *CacheKey, pkgName string) (*PackageInfo, error) {
+	entry, err := c.entry(key)
+	if err != nil {
+		return nil, err
 	}
-	m[pkgInfo.GetName()] = pkgInfo
+	return entry.packages[pkgName], nil
 }
 
-func (c *Cache) Get(rgPath, pkgName string) *PackageInfo {
-	pkgInfos, ok := c.m[rgPath]
+// Add adds a package to the cache.
+// Added packages are written by Write.
+func (c *Cache) Add(key *CacheKey, pkgInfo *PackageInfo) {
+	entry, ok := c.entries[key.ID()]
 	if !ok {
-		return nil
+		entry = &cacheEntry{
+			key:      key,
+			packages: map[string]*PackageInfo{},
+		}
+		c.entries[key.ID()] = entry
+	}
+	if entry.added == nil {
+		entry.added = map[string]*PackageInfo{}
 	}
-	return pkgInfos[pkgName]
+	entry.packages[pkgInfo.GetName()] = pkgInfo
+	entry.added[pkgInfo.GetName()] = pkgInfo
 }
 
-func (c *Cache) read() error {
-	f, err := c.fs.Open(c.path)
+// Write writes added packages to cache files.
+// Packages are merged into the current cache file because other processes may update it concurrently,
+// and the cache file is replaced atomically.
+func (c *Cache) Write() error {
+	for id, entry := range c.entries {
+		if len(entry.added) == 0 {
+			continue
+		}
+		if err := c.write(id, entry); err != nil {
+			return err
+		}
+		entry.added = nil
+	}
+	return nil
+}
+
+func (c *Cache) path(id string) string {
+	return filepath.Join(c.dir, id+".json")
+}
+
+func (c *Cache) entry(key *CacheKey) (*cacheEntry, error) {
+	id := key.ID()
+	if entry, ok := c.entries[id]; ok {
+		return entry, nil
+	}
+	entry := &cacheEntry{
+		key:      key,
+		packages: map[string]*PackageInfo{},
+	}
+	c.entries[id] = entry
+	p := c.path(id)
+	cf, err := ReadCacheFile(c.fs, p)
 	if err != nil {
-		return fmt.Errorf("open a registry cache: %w", err)
+		if errors.Is(err, os.ErrNotExist) {
+			return entry, nil
+		}
+		return entry, err
 	}
-	defer f.Close()
-	if err := json.NewDecoder(f).Decode(&c.m); err != nil {
-		return fmt.Errorf("parse the registry cache file: %w", err)
+	// Record the last used time so that unused cache files are removed by PruneCache.
+	now := time.Now()
+	_ = c.fs.Chtimes(p, now, now)
+	for name, pkgInfo := range cf.Packages {
+		entry.packages[name] = pkgInfo
+	}
+	return entry, nil
+}
+
+func (c *Cache) write(id string, entry *cacheEntry) error {
+	p := c.path(id)
+	cf := &CacheFile{
+		Key:      entry.key,
+		Packages: map[string]*PackageInfo{},
+	}
+	// If the current cache file is broken, it's overwritten.
+	if current, err := ReadCacheFile(c.fs, p); err == nil {
+		for name, pkgInfo := range current.Packages {
+			cf.Packages[name] = pkgInfo
+		}
+	}
+	for name, pkgInfo := range entry.added {
+		cf.Packages[name] = pkgInfo
+	}
+	if err := osfile.MkdirAll(c.fs, c.dir); err != nil {
+		return fmt.Errorf("create a directory: %w", err)
+	}
+	f, err := afero.TempFile(c.fs, c.dir, id+"-*.json.tmp")
+	if err != nil {
+		return fmt.Errorf("create a temporary registry cache: %w", err)
+	}
+	tmp := f.Name()
+	if err := json.NewEncoder(f).Encode(cf); err != nil {
+		f.Close()
+		_ = c.fs.Remove(tmp)
+		return fmt.Errorf("encode registry cache: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		_ = c.fs.Remove(tmp)
+		return fmt.Errorf("close a temporary registry cache: %w", err)
+	}
+	if err := c.fs.Rename(tmp, p); err != nil {
+		_ = c.fs.Remove(tmp)
+		return fmt.Errorf("rename a temporary registry cache: %w", err)
 	}
 	return nil
 }
@@ -2,129 +2,115 @@
 package registry_test
 
 import (
-	"encoding/json"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/aquaproj/aqua/v2/pkg/config/registry"
 	"github.com/spf13/afero"
 )
 
-const cacheDir = "/tmp/test/registry-cache"
+const rootDir = "/tmp/test"
 
-func TestNewCache_WithExistingFile(t *testing.T) {
-	t.Parallel()
-	fs := afero.NewMemMapFs()
-
-	// Create cache directory and valid cache file
-	if err := fs.MkdirAll(cacheDir, 0o755); err != nil {
-		t.Fatalf("failed to create cache dir: %v", err)
-	}
-
-	// Create sample cache data
-	data := map[string]map[string]*registry.PackageInfo{
-		"registry1": {
-			"package1": {
-				Name: "package1",
-				Type: "github_release",
-			},
-		},
-	}
-
-	cacheFile := filepath.Join(cacheDir, "L3BhdGgvdG8vY29uZmlnLnlhbWw=.json")
-	file, err := fs.Create(cacheFile)
-	if err != nil {
-		t.Fatalf("failed to create cache file: %v", err)
+func newCacheKey() *registry.CacheKey {
+	return &registry.CacheKey{
+		Type:      "github_content",
+		RepoOwner: "aquaproj",
+		RepoName:  "aqua-registry",
+		Ref:       "v4.0.0",
+		Path:      "registry.yaml",
 	}
+}
 
-	if err := json.NewEncoder(file).Encode(data); err != nil {
-		file.Close()
-		t.Fatalf("failed to encode cache data: %v", err)
+func TestCacheKey_ID(t *testing.T) {
+	t.Parallel()
+	key := newCacheKey()
+	if key.ID() != newCacheKey().ID() {
+		t.Error("the same keys should have the same ID")
 	}
-	file.Close()
-
-	// Test NewCache with existing file
-	cache, err := registry.NewCache(fs, "/tmp/test", "/path/to/config.yaml")
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
+	other := newCacheKey()
+	other.Ref = "v4.0.1"
+	if key.ID() == other.ID() {
+		t.Error("keys with different refs should have different IDs")
 	}
-
-	if cache == nil {
-		t.Error("cache should not be nil")
+	other = newCacheKey()
+	other.ContentHash = "sha512:0123"
+	if key.ID() == other.ID() {
+		t.Error("keys with different content hashes should have different IDs")
 	}
-
-	// Verify data was loaded
-	result := cache.Get("registry1", "package1")
-	if result == nil {
-		t.Error("package1 should be found")
-	} else if result.GetName() != "package1" {
-		t.Errorf("expected name 'package1', got %q", result.GetName())
+	a := &registry.CacheKey{RepoOwner: "aquaproj", RepoName: "aqua-registry"}
+	b := &registry.CacheKey{RepoOwner: "aquaproj-aqua", RepoName: "registry"}
+	if a.ID() == b.ID() {
+		t.Error("keys whose fields are different should have different IDs even if the concatenation is same")
 	}
 }
 
-func TestNewCache_WithInvalidJSON(t *testing.T) {
+func TestPruneCache(t *testing.T) {
 	t.Parallel()
 	fs := afero.NewMemMapFs()
-
-	// Create cache directory and invalid cache file
-	if err := fs.MkdirAll(cacheDir, 0o755); err != nil {
-		t.Fatalf("failed to create cache dir: %v", err)
+	cache := registry.NewCache(fs, rootDir)
+	oldKey := newCacheKey()
+	newKey := newCacheKey()
+	newKey.Ref = "v4.0.1"
+	cache.Add(oldKey, &registry.PackageInfo{Name: "package1"})
+	cache.Add(newKey, &registry.PackageInfo{Name: "package1"})
+	if err := cache.Write(); err != nil {
+		t.Fatal(err)
 	}
-
-	cacheFile := filepath.Join(cacheDir, "L3BhdGgvdG8vY29uZmlnLnlhbWw=.json")
-	file, err := fs.Create(cacheFile)
+	old := time.Now().Add(-100 * 24 * time.Hour)
+	oldPath := filepath.Join(registry.CacheDir(rootDir), oldKey.ID()+".json")
+	newPath := filepath.Join(registry.CacheDir(rootDir), newKey.ID()+".json")
+	if err := fs.Chtimes(oldPath, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Chtimes(newPath, old, old); err != nil {
+		t.Fatal(err)
+	}
+	// Reading a cache file updates the last used time.
+	if _, err := registry.NewCache(fs, rootDir).Get(newKey, "package1"); err != nil {
+		t.Fatal(err)
+	}
+	threshold := time.Now().Add(-60 * 24 * time.Hour)
+	removed, err := registry.PruneCache(fs, rootDir, func(t time.Time) bool {
+		return t.Before(threshold)
+	})
 	if err != nil {
-		t.Fatalf("failed to create cache file: %v", err)
+		t.Fatal(err)
 	}
-
-	if _, err := file.WriteString("invalid json"); err != nil {
-		file.Close()
-		t.Fatalf("failed to write invalid json: %v", err)
+	if len(removed) != 1 || removed[0] != oldPath {
+		t.Fatalf("only the unused cache file should be removed: %v", removed)
 	}
-	file.Close()
-
-	// Test NewCache with invalid JSON
-	_, err = registry.NewCache(fs, "/tmp/test", "/path/to/config.yaml")
-	if err == nil {
-		t.Error("expected error for invalid JSON")
+	if _, err := fs.Stat(newPath); err != nil {
+		t.Fatalf("the used cache file should be kept: %v", err)
+	}
+	if removed, err := registry.PruneCache(afero.NewMemMapFs(), rootDir, func(time.Time) bool { return true }); err != nil || len(removed) != 0 {
+		t.Fatalf("nothing should be removed if the cache directory doesn't exist: %v, %v", removed, err)
 	}
 }
 
-// Test cache operations without using NewCache
-func TestCache_Operations(t *testing.T) { //nolint:cyclop
+func TestCache_Operations(t *testing.T) {
 	t.Parallel()
-
-	// Create cache with valid data using direct setup
 	fs := afero.NewMemMapFs()
-	if err := fs.MkdirAll(cacheDir, 0o755); err != nil {
-		t.Fatalf("failed to create cache dir: %v", err)
-	}
+	cache := registry.NewCache(fs, rootDir)
+	key := newCacheKey()
 
-	// Create initial cache file
-	cacheFile := filepath.Join(cacheDir, "L2NvbmZpZy55YW1s.json")
-	file, err := fs.Create(cacheFile)
+	// Test Get from a cache file which doesn't exist
+	result, err := cache.Get(key, "package1")
 	if err != nil {
-		t.Fatalf("failed to create cache file: %v", err)
-	}
-
-	initialData := map[string]map[string]*registry.PackageInfo{}
-	if err := json.NewEncoder(file).Encode(initialData); err != nil {
-		file.Close()
-		t.Fatalf("failed to encode initial data: %v", err)
+		t.Fatalf("unexpected error: %v", err)
 	}
-	file.Close()
-
-	cache, err := registry.NewCache(fs, "/tmp/test", "/config.yaml")
-	if err != nil {
-		t.Fatalf("failed to create cache: %v", err)
+	if result != nil {
+		t.Error("should return nil for non-existent cache")
 	}
 
 	// Test Add operation
-	pkg1 := &registry.PackageInfo{Name: "package1", Type: "github_release"}
-	cache.Add("registry1", pkg1)
+	cache.Add(key, &registry.PackageInfo{Name: "package1", Type: "github_release"})
 
 	// Test Get operation
-	result := cache.Get("registry1", "package1")
+	result, err = cache.Get(key, "package1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 	if result == nil {
 		t.Error("package1 should be found")
 	} else if result.GetName() != "package1" {
@@ -132,37 +118,23 @@ func TestCache_Operations(t *testing.T) { //nolint:cyclop
 	}
 
 	// Test Get non-existent package
-	result = cache.Get("registry1", "nonexistent")
-	if result != nil {
-		t.Error("should return nil for non-existent package")
+	result, err = cache.Get(key, "nonexistent")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
-
-	// Test Get from non-existent registry
-	result = cache.Get("nonexistent", "package1")
 	if result != nil {
-		t.Error("should return nil for non-existent registry")
-	}
-
-	// Test Clean operation
-	cache.Add("registry2", &registry.PackageInfo{Name: "package2"})
-	cache.Add("registry3", &registry.PackageInfo{Name: "package3"})
-
-	keys := map[string]map[string]struct{}{
-		"registry1": {"package1": {}},
-		// registry2 and registry3 not in keys, so they should be removed
+		t.Error("should return nil for non-existent package")
 	}
 
-	cache.Clean(keys)
-
-	// Verify cleaning results
-	if result := cache.Get("registry1", "package1"); result == nil {
-		t.Error("package1 should still exist in registry1")
-	}
-	if result := cache.Get("registry2", "package2"); result != nil {
-		t.Error("registry2 should be removed")
+	// Test Get from another registry
+	other := newCacheKey()
+	other.Ref = "v5.0.0"
+	result, err = cache.Get(other, "package1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
-	if result := cache.Get("registry3", "package3"); result != nil {
-		t.Error("registry3 should be removed")
+	if result != nil {
+		t.Error("should return nil for another registry")
 	}
 
 	// Test Write operation
@@ -170,113 +142,118 @@ func TestCache_Operations(t *testing.T) { //nolint:cyclop
 		t.Fatalf("failed to write cache: %v", err)
 	}
 
-	// Verify file was written
-	exists, err := afero.Exists(fs, cacheFile)
+	// Only the cache file of the updated registry should be written and temporary files should be removed
+	files, err := afero.ReadDir(fs, registry.CacheDir(rootDir))
 	if err != nil {
-		t.Fatalf("failed to check file existence: %v", err)
+		t.Fatalf("failed to read the cache directory: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(files))
 	}
-	if !exists {
-		t.Error("cache file should exist after write")
+	if files[0].Name() != key.ID()+".json" {
+		t.Errorf("expected %q, got %q", key.ID()+".json", files[0].Name())
 	}
 }
 
-func TestCache_WriteReadRoundTrip(t *testing.T) { //nolint:cyclop
+func TestCache_WriteReadRoundTrip(t *testing.T) {
 	t.Parallel()
 	fs := afero.NewMemMapFs()
+	key := newCacheKey()
 
-	// Create initial empty cache file
-	if err := fs.MkdirAll(cacheDir, 0o755); err != nil {
-		t.Fatalf("failed to create cache dir: %v", err)
+	cache1 := registry.NewCache(fs, rootDir)
+	cache1.Add(key, &registry.PackageInfo{
+		Name:      "testpkg",
+		Type:      "github_release",
+		RepoOwner: "owner",
+		RepoName:  "repo",
+	})
+	if err := cache1.Write(); err != nil {
+		t.Fatalf("failed to write cache: %v", err)
 	}
 
-	cacheFile := filepath.Join(cacheDir, "L2NvbmZpZy55YW1s.json")
-	file, err := fs.Create(cacheFile)
+	cf, err := registry.ReadCacheFile(fs, filepath.Join(registry.CacheDir(rootDir), key.ID()+".json"))
 	if err != nil {
-		t.Fatalf("failed to create cache file: %v", err)
+		t.Fatalf("failed to read the cache file: %v", err)
 	}
-
-	initialData := map[string]map[string]*registry.PackageInfo{}
-	if err := json.NewEncoder(file).Encode(initialData); err != nil {
-		file.Close()
-		t.Fatalf("failed to encode initial data: %v", err)
+	if cf.Key.Ref != "v4.0.0" {
+		t.Errorf("expected ref 'v4.0.0', got %q", cf.Key.Ref)
 	}
-	file.Close()
 
-	// Create first cache and add data
-	cache1, err := registry.NewCache(fs, "/tmp/test", "/config.yaml")
+	// The cache is shared regardless of the configuration file
+	cache2 := registry.NewCache(fs, rootDir)
+	result, err := cache2.Get(newCacheKey(), "testpkg")
 	if err != nil {
-		t.Fatalf("failed to create cache1: %v", err)
+		t.Fatalf("unexpected error: %v", err)
 	}
-
-	pkg1 := &registry.PackageInfo{
-		Name:      "testpkg",
-		Type:      "github_release",
-		RepoOwner: "owner",
-		RepoName:  "repo",
+	if result == nil {
+		t.Fatal("testpkg should be found")
+	}
+	if result.Type != "github_release" {
+		t.Errorf("expected type 'github_release', got %q", result.Type)
+	}
+	if result.RepoOwner != "owner" {
+		t.Errorf("expected repo owner 'owner', got %q", result.RepoOwner)
 	}
-	cache1.Add("registry1", pkg1)
+}
 
-	// Write cache
+func TestCache_WriteMerge(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	key := newCacheKey()
+
+	// Two processes read the cache before either of them writes it
+	cache1 := registry.NewCache(fs, rootDir)
+	cache2 := registry.NewCache(fs, rootDir)
+	for _, cache := range []*registry.Cache{cache1, cache2} {
+		if _, err := cache.Get(key, "pkg1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	cache1.Add(key, &registry.PackageInfo{Name: "pkg1"})
+	cache2.Add(key, &registry.PackageInfo{Name: "pkg2"})
 	if err := cache1.Write(); err != nil {
-		t.Fatalf("failed to write cache: %v", err)
+		t.Fatalf("failed to write cache1: %v", err)
 	}
-
-	// Create second cache (should read the written data)
-	cache2, err := registry.NewCache(fs, "/tmp/test", "/config.yaml")
-	if err != nil {
-		t.Fatalf("failed to create cache2: %v", err)
+	if err := cache2.Write(); err != nil {
+		t.Fatalf("failed to write cache2: %v", err)
 	}
 
-	// Verify data was loaded correctly
-	result := cache2.Get("registry1", "testpkg")
-	if result == nil {
-		t.Error("testpkg should be found in registry1")
-	} else {
-		if result.GetName() != "testpkg" {
-			t.Errorf("expected name 'testpkg', got %q", result.GetName())
+	cache3 := registry.NewCache(fs, rootDir)
+	for _, name := range []string{"pkg1", "pkg2"} {
+		result, err := cache3.Get(key, name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
 		}
-		if result.Type != "github_release" {
-			t.Errorf("expected type 'github_release', got %q", result.Type)
-		}
-		if result.RepoOwner != "owner" {
-			t.Errorf("expected repo owner 'owner', got %q", result.RepoOwner)
+		if result == nil {
+			t.Errorf("%s should be found", name)
 		}
 	}
 }
 
-func TestCache_WriteError(t *testing.T) {
+func TestCache_InvalidJSON(t *testing.T) {
 	t.Parallel()
-
-	// Start with a working filesystem to create the cache
-	normalFs := afero.NewMemMapFs()
-	if err := normalFs.MkdirAll(cacheDir, 0o755); err != nil {
-		t.Fatalf("failed to create cache dir: %v", err)
+	fs := afero.NewMemMapFs()
+	key := newCacheKey()
+	cacheFile := filepath.Join(registry.CacheDir(rootDir), key.ID()+".json")
+	if err := afero.WriteFile(fs, cacheFile, []byte("invalid json"), 0o644); err != nil {
+		t.Fatalf("failed to write invalid json: %v", err)
 	}
 
-	cacheFile := filepath.Join(cacheDir, "L2NvbmZpZy55YW1s.json")
-	file, err := normalFs.Create(cacheFile)
-	if err != nil {
-		t.Fatalf("failed to create cache file: %v", err)
+	cache := registry.NewCache(fs, rootDir)
+	if _, err := cache.Get(key, "pkg1"); err == nil {
+		t.Error("expected error for invalid JSON")
 	}
 
-	initialData := map[string]map[string]*registry.PackageInfo{}
-	if err := json.NewEncoder(file).Encode(initialData); err != nil {
-		file.Close()
-		t.Fatalf("failed to encode initial data: %v", err)
+	// The broken cache file is overwritten
+	cache.Add(key, &registry.PackageInfo{Name: "pkg1"})
+	if err := cache.Write(); err != nil {
+		t.Fatalf("failed to write cache: %v", err)
 	}
-	file.Close()
-
-	cache, err := registry.NewCache(normalFs, "/tmp/test", "/config.yaml")
+	result, err := registry.NewCache(fs, rootDir).Get(key, "pkg1")
 	if err != nil {
-		t.Fatalf("failed to create cache: %v", err)
+		t.Fatalf("unexpected error: %v", err)
 	}
-
-	// Add data to trigger write
-	cache.Add("registry1", &registry.PackageInfo{Name: "pkg1"})
-
-	// This test shows that write would succeed normally
-	err = cache.Write()
-	if err != nil {
-		t.Errorf("write should succeed normally: %v", err)
+	if result == nil {
+		t.Error("pkg1 should be found")
 	}
 }
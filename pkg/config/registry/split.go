@@ -0,0 +1,87 @@
+package registry
+
+import (
+	"errors"
+	"path"
+	"path/filepath"
+)
+
+var errInvalidPackageFilePath = errors.New("the path of the package file must be a relative path in the registry")
+
+// PackageFile is a package file of a split registry.
+// A split registry consists of an index file and package files such as pkgs/<owner>/<repo>/registry.yaml,
+// so that only package files referenced by configuration files are downloaded or read.
+// A package file is a registry file, which has packages.
+type PackageFile struct {
+	// Name is the package name.
+	Name string `json:"name"`
+	// Aliases are alternative names of the package.
+	Aliases []*Alias `yaml:",omitempty" json:"aliases,omitempty"`
+	// Path is the relative path from the directory of the index file to the package file.
+	// The default value is pkgs/<package name>/registry.yaml.
+	Path string `yaml:",omitempty" json:"path,omitempty"`
+}
+
+// GetPath returns the slash-separated relative path of the package file.
+// The path must not be outside of the registry.
+func (p *PackageFile) GetPath() (string, error) {
+	pkgPath := p.Path
+	if pkgPath == "" {
+		pkgPath = path.Join("pkgs", p.Name, "registry.yaml")
+	}
+	if !filepath.IsLocal(filepath.FromSlash(pkgPath)) {
+		return "", errInvalidPackageFilePath
+	}
+	return pkgPath, nil
+}
+
+// IsSplit returns true if the registry is a split registry.
+func (c *Config) IsSplit() bool {
+	return len(c.PackageFiles) != 0
+}
+
+// PackageFile returns the package file of the package.
+// pkgName can be either a package name or an alias.
+// If the package isn't found, PackageFile returns nil.
+func (c *Config) PackageFile(pkgName string) *PackageFile {
+	for _, pf := range c.PackageFiles {
+		if pf == nil {
+			continue
+		}
+		if pf.Name == pkgName {
+			return pf
+		}
+		for _, alias := range pf.Aliases {
+			if alias != nil && alias.Name == pkgName {
+				return pf
+			}
+		}
+	}
+	return nil
+}
+
+// UnloadedPackageInfos returns packages of package files which aren't loaded.
+// They have only names and aliases in the index file, so their package files must be installed to use them.
+// Commands such as aqua generate and aqua search use them to find packages which aren't in configuration files.
+func (c *Config) UnloadedPackageInfos() PackageInfos {
+	loaded := make(map[string]struct{}, len(c.PackageInfos))
+	for _, pkgInfo := range c.PackageInfos {
+		if pkgInfo != nil {
+			loaded[pkgInfo.GetName()] = struct{}{}
+		}
+	}
+	var pkgInfos PackageInfos
+	for _, pf := range c.PackageFiles {
+		if pf == nil || pf.Name == "" {
+			continue
+		}
+		if _, ok := loaded[pf.Name]; ok {
+			continue
+		}
+		pkgInfos = append(pkgInfos, &PackageInfo{
+			Name:    pf.Name,
+			Aliases: pf.Aliases,
+		})
+	}
+	return pkgInfos
+}
@@ -0,0 +1,109 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPackageFile_GetPath(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name  string
+		pf    *registry.PackageFile
+		exp   string
+		isErr bool
+	}{
+		{
+			name: "default",
+			pf:   &registry.PackageFile{Name: "suzuki-shunsuke/tfcmt"},
+			exp:  "pkgs/suzuki-shunsuke/tfcmt/registry.yaml",
+		},
+		{
+			name: "path",
+			pf:   &registry.PackageFile{Name: "cli/cli", Path: "gh/registry.yaml"},
+			exp:  "gh/registry.yaml",
+		},
+		{
+			name:  "outside of the registry",
+			pf:    &registry.PackageFile{Name: "cli/cli", Path: "../gh/registry.yaml"},
+			isErr: true,
+		},
+		{
+			name:  "absolute path",
+			pf:    &registry.PackageFile{Name: "cli/cli", Path: "/etc/registry.yaml"},
+			isErr: true,
+		},
+		{
+			name:  "invalid name",
+			pf:    &registry.PackageFile{Name: "../../../foo"},
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := d.pf.GetPath()
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if p != d.exp {
+				t.Fatalf("wanted %s, got %s", d.exp, p)
+			}
+		})
+	}
+}
+
+func TestConfig_PackageFile(t *testing.T) {
+	t.Parallel()
+	cfg := &registry.Config{
+		PackageFiles: []*registry.PackageFile{
+			{Name: "suzuki-shunsuke/tfcmt", Aliases: []*registry.Alias{{Name: "tfcmt"}}},
+			{Name: "cli/cli"},
+		},
+	}
+	if !cfg.IsSplit() {
+		t.Fatal("the registry must be split")
+	}
+	for name, exp := range map[string]string{
+		"suzuki-shunsuke/tfcmt": "suzuki-shunsuke/tfcmt",
+		"tfcmt":                 "suzuki-shunsuke/tfcmt",
+		"cli/cli":               "cli/cli",
+		"foo":                   "",
+	} {
+		pf := cfg.PackageFile(name)
+		got := ""
+		if pf != nil {
+			got = pf.Name
+		}
+		if got != exp {
+			t.Errorf("PackageFile(%q): wanted %q, got %q", name, exp, got)
+		}
+	}
+}
+
+func TestConfig_UnloadedPackageInfos(t *testing.T) {
+	t.Parallel()
+	cfg := &registry.Config{
+		PackageFiles: []*registry.PackageFile{
+			{Name: "suzuki-shunsuke/tfcmt", Aliases: []*registry.Alias{{Name: "tfcmt"}}},
+			{Name: "cli/cli"},
+		},
+		PackageInfos: registry.PackageInfos{
+			{Type: "github_release", RepoOwner: "cli", RepoName: "cli"},
+		},
+	}
+	exp := registry.PackageInfos{
+		{Name: "suzuki-shunsuke/tfcmt", Aliases: []*registry.Alias{{Name: "tfcmt"}}},
+	}
+	if diff := cmp.Diff(exp, cfg.UnloadedPackageInfos()); diff != "" {
+		t.Fatal(diff)
+	}
+}
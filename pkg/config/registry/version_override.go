@@ -8,12 +8,12 @@ import (
 	"github.com/suzuki-shunsuke/logrus-error/logerr"
 )
 
-func (p *PackageInfo) setTopVersion(logE *logrus.Entry, v string) *PackageInfo {
+func (p *PackageInfo) matchTopVersion(logE *logrus.Entry, v string) bool {
 	sv := v
 	if p.VersionPrefix != "" {
 		prefix := p.VersionPrefix
 		if !strings.HasPrefix(v, prefix) {
-			return nil
+			return false
 		}
 		sv = strings.TrimPrefix(v, prefix)
 	}
@@ -21,7 +21,7 @@ func (p *PackageInfo) setTopVersion(logE *logrus.Entry, v string) *PackageInfo {
 	if err != nil {
 		// If it fails to evaluate version_constraint, output a debug log and treats as version_constraint is false.
 		logerr.WithError(logE, err).Debug("evaluate the version_constraint")
-		return nil
+		return false
 	}
 	if a {
 		logE.WithFields(logrus.Fields{
@@ -29,22 +29,19 @@ func (p *PackageInfo) setTopVersion(logE *logrus.Entry, v string) *PackageInfo {
 			"package_version":    v,
 			"package_semver":     sv,
 		}).Debug("match the version_constraint")
-		return p.Copy()
 	}
-	return nil
+	return a
 }
 
-func (p *PackageInfo) SetVersion(logE *logrus.Entry, v string) (*PackageInfo, error) {
-	if p.VersionConstraints == "" {
-		logE.Debug("no version_constraint")
-		return p, nil
+// VersionOverrideIndex returns the index of the version override whose version_constraint matches the version.
+// It returns -1 if version_constraint isn't set or the top level version_constraint matches,
+// and len(p.VersionOverrides) if no version_constraint matches.
+func (p *PackageInfo) VersionOverrideIndex(logE *logrus.Entry, v string) int {
+	if p.VersionConstraints == "" || p.matchTopVersion(logE, v) {
+		return -1
 	}
 
-	if p2 := p.setTopVersion(logE, v); p2 != nil {
-		return p2, nil
-	}
-
-	for _, vo := range p.VersionOverrides {
+	for i, vo := range p.VersionOverrides {
 		sv := v
 		vp := p.VersionPrefix
 		if vo.VersionPrefix != nil {
@@ -69,9 +66,25 @@ func (p *PackageInfo) SetVersion(logE *logrus.Entry, v string) (*PackageInfo, er
 				"package_version":    v,
 				"package_semver":     sv,
 			}).Debug("match the version_constraint")
-			return p.overrideVersion(vo), nil
+			return i
 		}
 	}
+	return len(p.VersionOverrides)
+}
+
+func (p *PackageInfo) SetVersion(logE *logrus.Entry, v string) (*PackageInfo, error) {
+	if p.VersionConstraints == "" {
+		logE.Debug("no version_constraint")
+		return p, nil
+	}
+
+	idx := p.VersionOverrideIndex(logE, v)
+	if idx < 0 {
+		return p.Copy(), nil
+	}
+	if idx < len(p.VersionOverrides) {
+		return p.overrideVersion(p.VersionOverrides[idx]), nil
+	}
 	logE.WithFields(logrus.Fields{
 		"version_constraint": p.VersionConstraints,
 		"package_version":    v,
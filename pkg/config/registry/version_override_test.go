@@ -398,3 +398,39 @@ func TestPackageInfo_setTopVersion(t *testing.T) { //nolint:funlen
 		})
 	}
 }
+
+func TestPackageInfo_VersionOverrideIndex(t *testing.T) {
+	t.Parallel()
+	logE := logrus.NewEntry(logrus.New())
+	pkg := &registry.PackageInfo{
+		VersionConstraints: `semver(">= 1.0.0")`,
+		VersionOverrides: []*registry.VersionOverride{
+			{
+				VersionConstraints: `semver(">= 0.5.0")`,
+			},
+			{
+				VersionConstraints: `semver(">= 0.1.0")`,
+			},
+		},
+	}
+	data := []struct {
+		version string
+		exp     int
+	}{
+		{version: "v1.1.0", exp: -1},
+		{version: "v0.9.0", exp: 0},
+		{version: "v0.1.0", exp: 1},
+		{version: "v0.0.1", exp: 2},
+	}
+	for _, d := range data {
+		t.Run(d.version, func(t *testing.T) {
+			t.Parallel()
+			if idx := pkg.VersionOverrideIndex(logE, d.version); idx != d.exp {
+				t.Fatalf("got %d, wanted %d", idx, d.exp)
+			}
+		})
+	}
+	if idx := (&registry.PackageInfo{}).VersionOverrideIndex(logE, "v1.0.0"); idx != -1 {
+		t.Fatalf("got %d, wanted -1 if version_constraint isn't set", idx)
+	}
+}
@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/testutil"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+const rootDir = "/home/foo/.local/share/aquaproj-aqua"
+
+func newCacheFs(t *testing.T) (afero.Fs, *registry.CacheKey) {
+	t.Helper()
+	fs, err := testutil.NewFs(map[string]string{
+		// a cache file created by old aqua versions
+		filepath.Join(rootDir, "registry-cache", "L2NvbmZpZy55YW1s.json"): `{}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := &registry.CacheKey{
+		Type:      "github_content",
+		RepoOwner: "aquaproj",
+		RepoName:  "aqua-registry",
+		Ref:       "v4.0.0",
+		Path:      "registry.yaml",
+	}
+	cache := registry.NewCache(fs, rootDir)
+	cache.Add(key, &registry.PackageInfo{Name: "cli/cli"})
+	cache.Add(key, &registry.PackageInfo{Name: "suzuki-shunsuke/tfcmt"})
+	if err := cache.Write(); err != nil {
+		t.Fatal(err)
+	}
+	return fs, key
+}
+
+func TestController_List(t *testing.T) {
+	t.Parallel()
+	fs, key := newCacheFs(t)
+	ctrl := New(fs)
+	buf := &bytes.Buffer{}
+	ctrl.stdout = buf
+	if err := ctrl.List(logrus.NewEntry(logrus.New()), &config.Param{RootDir: rootDir}); err != nil {
+		t.Fatal(err)
+	}
+	exp := "ID                                                                REGISTRY                                                    PACKAGES  SIZE\n" +
+		key.ID() + "  github_content:aquaproj/aqua-registry@v4.0.0:registry.yaml  2         243\n" +
+		"L2NvbmZpZy55YW1s                                                  -                                                           0         2\n"
+	if diff := cmp.Diff(exp, buf.String()); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestController_Clear(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name  string
+		args  []string
+		isErr bool
+		exp   []string
+	}{
+		{
+			name: "all",
+		},
+		{
+			name: "id",
+			args: []string{"L2NvbmZpZy55YW1s"},
+			exp:  []string{"key"},
+		},
+		{
+			name:  "not found",
+			args:  []string{"foo"},
+			isErr: true,
+			exp:   []string{"L2NvbmZpZy55YW1s", "key"},
+		},
+		{
+			name:  "invalid id",
+			args:  []string{"../registries"},
+			isErr: true,
+			exp:   []string{"L2NvbmZpZy55YW1s", "key"},
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			fs, key := newCacheFs(t)
+			ctrl := New(fs)
+			err := ctrl.Clear(logE, &config.Param{RootDir: rootDir, Args: d.args})
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			entries, err := ctrl.list(logE, rootDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids := make([]string, 0, len(entries))
+			for _, entry := range entries {
+				if entry.ID == key.ID() {
+					ids = append(ids, "key")
+					continue
+				}
+				ids = append(ids, entry.ID)
+			}
+			exp := d.exp
+			if exp == nil {
+				exp = []string{}
+			}
+			if diff := cmp.Diff(exp, ids); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// Clear removes registry caches.
+// If cache IDs are given, only those caches are removed. Otherwise, all caches are removed.
+func (c *Controller) Clear(logE *logrus.Entry, param *config.Param) error {
+	dir := registry.CacheDir(param.RootDir)
+	if len(param.Args) == 0 {
+		if err := c.fs.RemoveAll(dir); err != nil {
+			return fmt.Errorf("remove the registry cache directory: %w", err)
+		}
+		logE.WithField("registry_cache_dir", dir).Info("removed registry caches")
+		return nil
+	}
+	for _, id := range param.Args {
+		p := filepath.Join(dir, id+".json")
+		if filepath.Base(p) != id+".json" {
+			return logerr.WithFields(errCacheNotFound, logrus.Fields{ //nolint:wrapcheck
+				"registry_cache_id": id,
+			})
+		}
+		if _, err := c.fs.Stat(p); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return logerr.WithFields(errCacheNotFound, logrus.Fields{ //nolint:wrapcheck
+					"registry_cache_id": id,
+				})
+			}
+			return fmt.Errorf("get a registry cache file stat: %w", err)
+		}
+		if err := c.fs.Remove(p); err != nil {
+			return fmt.Errorf("remove a registry cache file: %w", err)
+		}
+		logE.WithField("registry_cache_id", id).Info("removed a registry cache")
+	}
+	return nil
+}
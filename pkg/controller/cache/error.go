@@ -0,0 +1,8 @@
+package cache
+
+import "errors"
+
+var (
+	errInvalidFormat = errors.New("the output format must be either json or table")
+	errCacheNotFound = errors.New("the registry cache isn't found")
+)
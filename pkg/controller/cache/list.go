@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+const (
+	formatJSON  = "json"
+	formatTable = "table"
+)
+
+// Entry is a registry cache outputted by `aqua cache list`.
+type Entry struct {
+	ID string `json:"id"`
+	*registry.CacheKey
+	// Packages is the number of cached packages.
+	Packages int    `json:"packages"`
+	Size     int64  `json:"size"`
+	FilePath string `json:"file_path"`
+}
+
+// List outputs registry caches.
+// Cache files created by old aqua versions don't have keys, so they are outputted without registry information.
+func (c *Controller) List(logE *logrus.Entry, param *config.Param) error {
+	switch param.OutputFormat {
+	case "", formatJSON, formatTable:
+	default:
+		return errInvalidFormat
+	}
+	entries, err := c.list(logE, param.RootDir)
+	if err != nil {
+		return err
+	}
+	if param.OutputFormat == formatJSON {
+		encoder := json.NewEncoder(c.stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(entries); err != nil {
+			return fmt.Errorf("encode the list as JSON: %w", err)
+		}
+		return nil
+	}
+	w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0) //nolint:mnd
+	fmt.Fprintln(w, "ID\tREGISTRY\tPACKAGES\tSIZE")
+	for _, entry := range entries {
+		fmt.Fprintln(w, strings.Join([]string{entry.ID, registryName(entry.CacheKey), strconv.Itoa(entry.Packages), strconv.FormatInt(entry.Size, 10)}, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("output the list as a table: %w", err)
+	}
+	return nil
+}
+
+func (c *Controller) list(logE *logrus.Entry, rootDir string) ([]*Entry, error) {
+	dir := registry.CacheDir(rootDir)
+	files, err := afero.ReadDir(c.fs, dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []*Entry{}, nil
+		}
+		return nil, fmt.Errorf("read the registry cache directory: %w", err)
+	}
+	entries := make([]*Entry, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		entry := &Entry{
+			ID:       strings.TrimSuffix(file.Name(), ".json"),
+			Size:     file.Size(),
+			FilePath: filepath.Join(dir, file.Name()),
+		}
+		cf, err := registry.ReadCacheFile(c.fs, entry.FilePath)
+		if err != nil {
+			logerr.WithError(logE, err).WithField("registry_cache_file", entry.FilePath).Warn("read a registry cache file")
+		} else {
+			entry.CacheKey = cf.Key
+			entry.Packages = len(cf.Packages)
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].FilePath < entries[j].FilePath
+	})
+	return entries, nil
+}
+
+func registryName(key *registry.CacheKey) string {
+	if key == nil {
+		return "-"
+	}
+	if key.RepoOwner == "" {
+		return key.Type + ":" + key.ContentHash
+	}
+	return fmt.Sprintf("%s:%s/%s@%s:%s", key.Type, key.RepoOwner, key.RepoName, key.Ref, key.Path)
+}
@@ -4,6 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/aquaproj/aqua/v2/pkg/checksum"
@@ -19,7 +23,7 @@ import (
 	"github.com/suzuki-shunsuke/logrus-error/logerr"
 )
 
-func (c *Controller) Exec(ctx context.Context, logE *logrus.Entry, param *config.Param, exeName string, args ...string) (gErr error) { //nolint:cyclop
+func (c *Controller) Exec(ctx context.Context, logE *logrus.Entry, param *config.Param, exeName string, args ...string) (gErr error) {
 	logE = logE.WithField("exe_name", exeName)
 	defer func() {
 		if gErr != nil {
@@ -27,14 +31,9 @@ func (c *Controller) Exec(ctx context.Context, logE *logrus.Entry, param *config
 		}
 	}()
 
-	policyCfgs, err := c.policyReader.Read(param.PolicyConfigFilePaths)
+	policyCfgs, globalPolicyPaths, err := c.readPolicies(param)
 	if err != nil {
-		return fmt.Errorf("read policy files: %w", err)
-	}
-
-	globalPolicyPaths := make(map[string]struct{}, len(param.PolicyConfigFilePaths))
-	for _, p := range param.PolicyConfigFilePaths {
-		globalPolicyPaths[p] = struct{}{}
+		return err
 	}
 
 	findResult, err := c.which.Which(ctx, logE, param, exeName)
@@ -42,17 +41,54 @@ func (c *Controller) Exec(ctx context.Context, logE *logrus.Entry, param *config
 		return err //nolint:wrapcheck
 	}
 	if findResult.Package == nil {
-		return c.execCommandWithRetry(ctx, logE, findResult.ExePath, exeName, args...)
+		return c.execCommandWithRetry(ctx, logE, findResult.ExePath, exeName, nil, args...)
+	}
+
+	return c.execPackage(ctx, logE, param, findResult, policyCfgs, globalPolicyPaths, exeName, args...)
+}
+
+// ExecPackage installs the package if it isn't installed and executes the command.
+// Unlike Exec, the package is given by the caller, so the package doesn't have to be defined in configuration files.
+func (c *Controller) ExecPackage(ctx context.Context, logE *logrus.Entry, param *config.Param, findResult *which.FindResult, exeName string, args ...string) (gErr error) {
+	logE = logE.WithField("exe_name", exeName)
+	defer func() {
+		if gErr != nil {
+			gErr = logerr.WithFields(gErr, logE.Data)
+		}
+	}()
+
+	policyCfgs, globalPolicyPaths, err := c.readPolicies(param)
+	if err != nil {
+		return err
 	}
+	return c.execPackage(ctx, logE, param, findResult, policyCfgs, globalPolicyPaths, exeName, args...)
+}
 
+func (c *Controller) readPolicies(param *config.Param) ([]*policy.Config, map[string]struct{}, error) {
+	policyCfgs, err := c.policyReader.Read(param.PolicyConfigFilePaths)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read policy files: %w", err)
+	}
+
+	globalPolicyPaths := make(map[string]struct{}, len(param.PolicyConfigFilePaths))
+	for _, p := range param.PolicyConfigFilePaths {
+		globalPolicyPaths[p] = struct{}{}
+	}
+	return policyCfgs, globalPolicyPaths, nil
+}
+
+func (c *Controller) execPackage(ctx context.Context, logE *logrus.Entry, param *config.Param, findResult *which.FindResult, policyCfgs []*policy.Config, globalPolicyPaths map[string]struct{}, exeName string, args ...string) error {
 	logE = logE.WithFields(logrus.Fields{
 		"package_name":    findResult.Package.Package.Name,
 		"package_version": findResult.Package.Package.Version,
 	})
 
-	policyCfgs, err = c.policyReader.Append(logE, findResult.ConfigFilePath, policyCfgs, globalPolicyPaths)
-	if err != nil {
-		return err //nolint:wrapcheck
+	if findResult.ConfigFilePath != "" {
+		cfgs, err := c.policyReader.Append(logE, findResult.ConfigFilePath, policyCfgs, globalPolicyPaths)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		policyCfgs = cfgs
 	}
 
 	if param.DisableLazyInstall {
@@ -68,7 +104,33 @@ func (c *Controller) Exec(ctx context.Context, logE *logrus.Entry, param *config
 		logerr.WithError(logE, err).Warn("update the last used datetime")
 	}
 
-	return c.execCommandWithRetry(ctx, logE, findResult.ExePath, exeName, args...)
+	envs, err := findResult.Package.RenderEnv(param.RootDir, runtime.New())
+	if err != nil {
+		return fmt.Errorf("render the package's environment variables: %w", err)
+	}
+
+	return c.execCommandWithRetry(ctx, logE, findResult.ExePath, exeName, appendEnv(os.Environ(), envs), args...)
+}
+
+// appendEnv returns environment variables in the form "key=value" with envs applied.
+// Existing variables are overwritten by envs.
+// If envs is empty, it returns nil so that the process inherits the current environment variables.
+func appendEnv(environ []string, envs map[string]string) []string {
+	if len(envs) == 0 {
+		return nil
+	}
+	ret := make([]string, 0, len(environ)+len(envs))
+	for _, e := range environ {
+		k, _, _ := strings.Cut(e, "=")
+		if _, ok := envs[k]; ok {
+			continue
+		}
+		ret = append(ret, e)
+	}
+	for _, k := range slices.Sorted(maps.Keys(envs)) {
+		ret = append(ret, k+"="+envs[k])
+	}
+	return ret
 }
 
 func (c *Controller) updateTimestamp(pkg *config.Package) error {
@@ -83,13 +145,17 @@ func (c *Controller) updateTimestamp(pkg *config.Package) error {
 }
 
 func (c *Controller) install(ctx context.Context, logE *logrus.Entry, findResult *which.FindResult, policies []*policy.Config, param *config.Param) error {
-	checksums, updateChecksum, err := checksum.Open(
-		logE, c.fs, findResult.ConfigFilePath,
-		param.ChecksumEnabled(findResult.Config))
-	if err != nil {
-		return fmt.Errorf("read a checksum JSON: %w", err)
+	checksums := findResult.Checksums
+	if checksums == nil {
+		a, updateChecksum, err := checksum.Open(
+			logE, c.fs, findResult.ConfigFilePath,
+			param.ChecksumEnabled(findResult.Config))
+		if err != nil {
+			return fmt.Errorf("read a checksum JSON: %w", err)
+		}
+		defer updateChecksum()
+		checksums = a
 	}
-	defer updateChecksum()
 
 	if err := c.packageInstaller.InstallPackage(ctx, logE, &installpackage.ParamInstallPackage{
 		Pkg:             findResult.Package,
@@ -129,15 +195,19 @@ func wait(ctx context.Context, duration time.Duration) error {
 
 var errFailedToStartProcess = errors.New("it failed to start the process")
 
-func (c *Controller) execCommand(ctx context.Context, exePath, name string, args ...string) (bool, error) {
+func (c *Controller) execCommand(ctx context.Context, exePath, name string, environ []string, args ...string) (bool, error) {
 	if c.enabledXSysExec {
-		if err := c.executor.ExecXSys(exePath, name, args...); err != nil {
+		if environ == nil {
+			environ = os.Environ()
+		}
+		if err := c.executor.ExecXSys(exePath, name, environ, args...); err != nil {
 			return true, fmt.Errorf("call execve(2): %w", err)
 		}
 		return false, nil
 	}
 	cmd := osexec.Command(ctx, exePath, args...)
 	cmd.Args[0] = name
+	cmd.Env = environ
 	if exitCode, err := c.executor.Exec(cmd); err != nil {
 		// https://pkg.go.dev/os#ProcessState.ExitCode
 		// > ExitCode returns the exit code of the exited process,
@@ -150,10 +220,10 @@ func (c *Controller) execCommand(ctx context.Context, exePath, name string, args
 	return false, nil
 }
 
-func (c *Controller) execCommandWithRetry(ctx context.Context, logE *logrus.Entry, exePath, name string, args ...string) error {
+func (c *Controller) execCommandWithRetry(ctx context.Context, logE *logrus.Entry, exePath, name string, environ []string, args ...string) error {
 	for i := range 10 {
 		logE.Debug("execute the command")
-		retried, err := c.execCommand(ctx, exePath, name, args...)
+		retried, err := c.execCommand(ctx, exePath, name, environ, args...)
 		if !retried {
 			return err
 		}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/aquaproj/aqua/v2/pkg/osexec"
+	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
 )
 
@@ -14,6 +15,7 @@ func TestController_execCommand(t *testing.T) {
 		title    string
 		exePath  string
 		exeName  string
+		environ  []string
 		args     []string
 		executor Executor
 	}{
@@ -24,6 +26,14 @@ func TestController_execCommand(t *testing.T) {
 			args:     []string{},
 			executor: &osexec.Mock{},
 		},
+		{
+			title:    "environ",
+			exePath:  "/bin/date",
+			exeName:  "date",
+			environ:  []string{"TZ=UTC"},
+			args:     []string{},
+			executor: &osexec.Mock{},
+		},
 	}
 	logE := logrus.NewEntry(logrus.New())
 	for _, d := range data {
@@ -36,10 +46,42 @@ func TestController_execCommand(t *testing.T) {
 				stderr:   os.Stderr,
 				executor: d.executor,
 			}
-			err := ctrl.execCommandWithRetry(ctx, logE, d.exePath, d.exeName, d.args...)
+			err := ctrl.execCommandWithRetry(ctx, logE, d.exePath, d.exeName, d.environ, d.args...)
 			if err != nil {
 				t.Fatal(err)
 			}
 		})
 	}
 }
+
+func Test_appendEnv(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title   string
+		environ []string
+		envs    map[string]string
+		exp     []string
+	}{
+		{
+			title:   "no env",
+			environ: []string{"FOO=foo"},
+		},
+		{
+			title:   "override",
+			environ: []string{"FOO=foo", "JAVA_HOME=/usr/lib/jvm", "BAR=bar=bar"},
+			envs: map[string]string{
+				"JAVA_HOME": "/home/foo/.local/share/aquaproj-aqua/pkgs/jdk",
+				"ZOO":       "zoo",
+			},
+			exp: []string{"FOO=foo", "BAR=bar=bar", "JAVA_HOME=/home/foo/.local/share/aquaproj-aqua/pkgs/jdk", "ZOO=zoo"},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(d.exp, appendEnv(d.environ, d.envs)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
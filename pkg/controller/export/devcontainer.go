@@ -0,0 +1,52 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+)
+
+// devcontainerFeature is the Dev Container Feature to install aqua.
+// https://github.com/aquaproj/devcontainer-features/tree/main/src/aqua-installer
+const devcontainerFeature = "ghcr.io/aquaproj/devcontainer-features/aqua-installer:1"
+
+// devcontainer is a part of devcontainer.json.
+type devcontainer struct {
+	Features          map[string]map[string]string `json:"features"`
+	ContainerEnv      map[string]string            `json:"containerEnv"`
+	PostCreateCommand string                       `json:"postCreateCommand"`
+}
+
+// exportDevcontainer outputs a part of devcontainer.json.
+// aqua is installed by the Dev Container Feature and packages are installed after the container is created.
+// The configuration file is set as a global configuration file so that `aqua i -a` installs packages in any working directory.
+func (c *Controller) exportDevcontainer(param *config.Param, ec *exportedConfig) error {
+	rel, err := relPath(param.PWD, ec.cfgFilePath)
+	if err != nil {
+		return err
+	}
+	opts := map[string]string{}
+	if ec.aquaVersion != "" {
+		opts["aqua_version"] = ec.aquaVersion
+	}
+	dc := &devcontainer{
+		Features: map[string]map[string]string{
+			devcontainerFeature: opts,
+		},
+		ContainerEnv: map[string]string{
+			"AQUA_GLOBAL_CONFIG": "${containerWorkspaceFolder}/" + rel,
+		},
+		PostCreateCommand: "aqua i -a",
+	}
+	if ec.checksumFilePath != "" {
+		dc.ContainerEnv["AQUA_ENFORCE_CHECKSUM"] = "true"
+		dc.ContainerEnv["AQUA_ENFORCE_REQUIRE_CHECKSUM"] = "true"
+	}
+	b, err := json.MarshalIndent(dc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode devcontainer.json: %w", err)
+	}
+	fmt.Fprintln(c.stdout, string(b))
+	return nil
+}
@@ -0,0 +1,161 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/expr"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+const (
+	// defaultDockerBaseImage is used if the base image isn't specified by --base-image.
+	defaultDockerBaseImage = "debian:bookworm-slim"
+	// dockerConfigDir is a directory where configuration files are copied in the image.
+	dockerConfigDir = "/etc/aqua"
+)
+
+// exportDockerfile outputs a multi-stage Dockerfile.
+// The first stage installs packages with aqua cp and the second stage copies only executable files.
+// If the checksum file exists, it's copied and checksum verification is enforced.
+func (c *Controller) exportDockerfile(ctx context.Context, logE *logrus.Entry, param *config.Param, ec *exportedConfig) error {
+	cmds, err := c.listCommands(ctx, logE, param, ec)
+	if err != nil {
+		return err
+	}
+	if len(cmds) == 0 {
+		return errNoCommand
+	}
+	files := listDockerFiles(logE, ec)
+	cfgDir := filepath.Dir(ec.cfgFilePath)
+	baseImage := param.ExportBaseImage
+	if baseImage == "" {
+		baseImage = defaultDockerBaseImage
+	}
+
+	var b strings.Builder
+	b.WriteString(`# Generated by "aqua export --target dockerfile". Please run the command again when the configuration is changed.
+FROM ` + baseImage + ` AS aqua
+ENV PATH=/root/.local/share/aquaproj-aqua/bin:$PATH
+ENV AQUA_GLOBAL_CONFIG=` + path.Join(dockerConfigDir, filepath.Base(ec.cfgFilePath)) + "\n")
+	if ec.checksumFilePath != "" {
+		b.WriteString("ENV AQUA_ENFORCE_CHECKSUM=true\nENV AQUA_ENFORCE_REQUIRE_CHECKSUM=true\n")
+	}
+	for _, file := range files {
+		src, err := relPath(param.PWD, file)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(cfgDir, file)
+		if err != nil {
+			return fmt.Errorf("get a relative path: %w", err)
+		}
+		fmt.Fprintf(&b, "COPY %s %s\n", src, path.Join(dockerConfigDir, filepath.ToSlash(rel)))
+	}
+	installerOpts := ""
+	if ec.aquaVersion != "" {
+		installerOpts = " -v " + ec.aquaVersion
+	}
+	b.WriteString(`# aqua-installer ` + aquaInstallerVersion + `
+RUN apt-get update && \
+    apt-get install -y --no-install-recommends ca-certificates curl && \
+    curl -sSfL -O ` + aquaInstallerScriptURL() + ` && \
+    chmod +x aqua-installer && \
+    ./aqua-installer` + installerOpts + ` && \
+    rm aqua-installer
+RUN aqua cp -o /dist ` + strings.Join(cmds, " ") + `
+
+FROM ` + baseImage + `
+COPY --from=aqua /dist/* /usr/local/bin/
+`)
+	fmt.Fprint(c.stdout, b.String())
+	return nil
+}
+
+// listCommands returns sorted command names of packages available on Linux.
+func (c *Controller) listCommands(ctx context.Context, logE *logrus.Entry, param *config.Param, ec *exportedConfig) ([]string, error) {
+	checksums, updateChecksum, err := checksum.Open(
+		logE, c.fs, ec.cfgFilePath, param.ChecksumEnabled(ec.cfg))
+	if err != nil {
+		return nil, fmt.Errorf("read a checksum JSON: %w", err)
+	}
+	defer updateChecksum()
+
+	registryContents, err := c.registryInstaller.InstallRegistries(ctx, logE, ec.cfg, ec.cfgFilePath, checksums)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	rt := &runtime.Runtime{
+		GOOS:   "linux",
+		GOARCH: c.runtime.GOARCH,
+	}
+	pkgs, _ := config.ListPackages(logE, ec.cfg, rt, registryContents)
+	cmds := []string{}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.PackageInfo.GetFiles() {
+			if file.Name != "" && !slices.Contains(cmds, file.Name) {
+				cmds = append(cmds, file.Name)
+			}
+		}
+	}
+	slices.Sort(cmds)
+	return cmds, nil
+}
+
+// listDockerFiles returns files which need to be copied to the image.
+// In addition to the configuration file and the checksum file, imported files, local registries,
+// and files referred by go_version_file and version_expr are included.
+func listDockerFiles(logE *logrus.Entry, ec *exportedConfig) []string {
+	files := []string{}
+	add := func(p string) {
+		if !slices.Contains(files, p) && p != ec.cfgFilePath && p != ec.checksumFilePath {
+			files = append(files, p)
+		}
+	}
+	for _, rgst := range ec.cfg.Registries {
+		if rgst.Type == aqua.RegistryTypeLocal {
+			add(rgst.Path)
+		}
+	}
+	for _, pkg := range ec.cfg.Packages {
+		if pkg.FilePath == "" {
+			continue
+		}
+		add(pkg.FilePath)
+		dir := filepath.Dir(pkg.FilePath)
+		if pkg.GoVersionFile != "" {
+			add(filepath.Join(dir, pkg.GoVersionFile))
+		}
+		if pkg.VersionExpr == "" {
+			continue
+		}
+		src, err := expr.ParseVersionSource(pkg.VersionExpr)
+		if err != nil {
+			logerr.WithError(logE, err).WithFields(logrus.Fields{
+				"package_name": pkg.Name,
+				"version_expr": pkg.VersionExpr,
+			}).Warn("a file referred by version_expr isn't copied. Please copy it manually")
+			continue
+		}
+		p := src.Path
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(dir, p)
+		}
+		add(p)
+	}
+	slices.Sort(files)
+	head := []string{ec.cfgFilePath}
+	if ec.checksumFilePath != "" {
+		head = append(head, ec.checksumFilePath)
+	}
+	return append(head, files...)
+}
@@ -0,0 +1,10 @@
+package export
+
+import "errors"
+
+var (
+	errTargetRequired          = errors.New("--target is required. Supported targets are dockerfile, devcontainer, and github-actions")
+	errUnknownTarget           = errors.New("unknown target. Supported targets are dockerfile, devcontainer, and github-actions")
+	errOutsideCurrentDirectory = errors.New("the file must be in the current directory")
+	errNoCommand               = errors.New("no command is found in the configuration file")
+)
@@ -0,0 +1,113 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// Targets of aqua export.
+const (
+	TargetDockerfile    = "dockerfile"
+	TargetDevcontainer  = "devcontainer"
+	TargetGitHubActions = "github-actions"
+)
+
+// aquaVersionPattern matches released versions of aqua.
+// Development builds don't have a released version, so the version isn't pinned in snippets.
+var aquaVersionPattern = regexp.MustCompile(`^v\d+\.\d+\.\d+$`)
+
+// exportedConfig is the effective configuration exported as snippets.
+type exportedConfig struct {
+	cfg         *aqua.Config
+	cfgFilePath string
+	// checksumFilePath is empty if checksum verification is disabled or the checksum file doesn't exist.
+	checksumFilePath string
+	aquaVersion      string
+}
+
+// Export outputs a snippet to install packages of the configuration file in other environments.
+// Supported targets are Dockerfile, devcontainer.json, and GitHub Actions.
+func (c *Controller) Export(ctx context.Context, logE *logrus.Entry, param *config.Param) error {
+	switch param.ExportTarget {
+	case TargetDockerfile, TargetDevcontainer, TargetGitHubActions:
+	case "":
+		return errTargetRequired
+	default:
+		return logerr.WithFields(errUnknownTarget, logrus.Fields{ //nolint:wrapcheck
+			"target": param.ExportTarget,
+		})
+	}
+	cfgFilePath, err := c.configFinder.Find(param.PWD, param.ConfigFilePath, param.GlobalConfigFilePaths...)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	cfg := &aqua.Config{}
+	if err := c.configReader.Read(logE, cfgFilePath, cfg); err != nil {
+		return err //nolint:wrapcheck
+	}
+	ec := &exportedConfig{
+		cfg:         cfg,
+		cfgFilePath: cfgFilePath,
+	}
+	if aquaVersionPattern.MatchString(param.AQUAVersion) {
+		ec.aquaVersion = param.AQUAVersion
+	}
+	if param.ChecksumEnabled(cfg) {
+		p, err := c.getChecksumFilePath(cfgFilePath)
+		if err != nil {
+			return err
+		}
+		ec.checksumFilePath = p
+	}
+
+	switch param.ExportTarget {
+	case TargetDockerfile:
+		return c.exportDockerfile(ctx, logE, param, ec)
+	case TargetDevcontainer:
+		return c.exportDevcontainer(param, ec)
+	default:
+		return c.exportGitHubActions(ec)
+	}
+}
+
+// getChecksumFilePath returns the path of the checksum file.
+// It returns an empty string if the file doesn't exist.
+func (c *Controller) getChecksumFilePath(cfgFilePath string) (string, error) {
+	p, err := checksum.GetChecksumFilePathFromConfigFilePath(c.fs, cfgFilePath)
+	if err != nil {
+		return "", fmt.Errorf("get a checksum file path: %w", err)
+	}
+	f, err := afero.Exists(c.fs, p)
+	if err != nil {
+		return "", fmt.Errorf("check if a checksum file exists: %w", err)
+	}
+	if !f {
+		return "", nil
+	}
+	return p, nil
+}
+
+// relPath returns the slash separated relative path from base to p.
+// Snippets refer to files relative to the current directory, so files outside of it can't be exported.
+func relPath(base, p string) (string, error) {
+	rel, err := filepath.Rel(base, p)
+	if err != nil {
+		return "", fmt.Errorf("get a relative path: %w", err)
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", logerr.WithFields(errOutsideCurrentDirectory, logrus.Fields{ //nolint:wrapcheck
+			"file": p,
+		})
+	}
+	return filepath.ToSlash(rel), nil
+}
@@ -0,0 +1,264 @@
+package export
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	finder "github.com/aquaproj/aqua/v2/pkg/config-finder"
+	reader "github.com/aquaproj/aqua/v2/pkg/config-reader"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/cosign"
+	"github.com/aquaproj/aqua/v2/pkg/download"
+	registry "github.com/aquaproj/aqua/v2/pkg/install-registry"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/aquaproj/aqua/v2/pkg/slsa"
+	"github.com/aquaproj/aqua/v2/pkg/testutil"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+func TestController_Export(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	files := map[string]string{
+		"/home/foo/workspace/aqua/aqua.yaml": `checksum:
+  enabled: true
+registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: cli/cli@v2.45.0
+- import: imports/*.yaml
+`,
+		"/home/foo/workspace/aqua/imports/tfcmt.yaml": `packages:
+- name: suzuki-shunsuke/tfcmt@v4.14.0
+`,
+		"/home/foo/workspace/aqua/aqua-checksums.json": `{"checksums": []}`,
+		"/home/foo/workspace/aqua/registry.yaml": `packages:
+- type: github_release
+  repo_owner: cli
+  repo_name: cli
+  asset: gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.tar.gz
+  files:
+  - name: gh
+- type: github_release
+  repo_owner: suzuki-shunsuke
+  repo_name: tfcmt
+  asset: tfcmt_{{.OS}}_{{.Arch}}.tar.gz
+`,
+	}
+	data := []struct {
+		name   string
+		param  *config.Param
+		isErr  bool
+		expOut string
+	}{
+		{
+			name: "dockerfile",
+			param: &config.Param{
+				PWD:            "/home/foo/workspace",
+				ExportTarget:   "dockerfile",
+				MaxParallelism: 5,
+				AQUAVersion:    "v2.48.3",
+			},
+			expOut: `# Generated by "aqua export --target dockerfile". Please run the command again when the configuration is changed.
+FROM debian:bookworm-slim AS aqua
+ENV PATH=/root/.local/share/aquaproj-aqua/bin:$PATH
+ENV AQUA_GLOBAL_CONFIG=/etc/aqua/aqua.yaml
+ENV AQUA_ENFORCE_CHECKSUM=true
+ENV AQUA_ENFORCE_REQUIRE_CHECKSUM=true
+COPY aqua/aqua.yaml /etc/aqua/aqua.yaml
+COPY aqua/aqua-checksums.json /etc/aqua/aqua-checksums.json
+COPY aqua/imports/tfcmt.yaml /etc/aqua/imports/tfcmt.yaml
+COPY aqua/registry.yaml /etc/aqua/registry.yaml
+# aqua-installer v4.0.4
+RUN apt-get update && \
+    apt-get install -y --no-install-recommends ca-certificates curl && \
+    curl -sSfL -O https://raw.githubusercontent.com/aquaproj/aqua-installer/11dd79b4e498d471a9385aa9fb7f62bb5f52a73c/aqua-installer && \
+    chmod +x aqua-installer && \
+    ./aqua-installer -v v2.48.3 && \
+    rm aqua-installer
+RUN aqua cp -o /dist gh tfcmt
+
+FROM debian:bookworm-slim
+COPY --from=aqua /dist/* /usr/local/bin/
+`,
+		},
+		{
+			name: "devcontainer",
+			param: &config.Param{
+				PWD:          "/home/foo/workspace",
+				ExportTarget: "devcontainer",
+				AQUAVersion:  "",
+			},
+			expOut: `{
+  "features": {
+    "ghcr.io/aquaproj/devcontainer-features/aqua-installer:1": {}
+  },
+  "containerEnv": {
+    "AQUA_ENFORCE_CHECKSUM": "true",
+    "AQUA_ENFORCE_REQUIRE_CHECKSUM": "true",
+    "AQUA_GLOBAL_CONFIG": "${containerWorkspaceFolder}/aqua/aqua.yaml"
+  },
+  "postCreateCommand": "aqua i -a"
+}
+`,
+		},
+		{
+			name: "unknown target",
+			param: &config.Param{
+				PWD:          "/home/foo/workspace",
+				ExportTarget: "circleci",
+			},
+			isErr: true,
+		},
+		{
+			name: "outside of the current directory",
+			param: &config.Param{
+				PWD:          "/home/foo/workspace/aqua/imports",
+				ExportTarget: "devcontainer",
+			},
+			isErr: true,
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	downloader := download.NewGitHubContentFileDownloader(nil, download.NewHTTPDownloader(logE, http.DefaultClient))
+	rt := &runtime.Runtime{
+		GOOS:   "darwin",
+		GOARCH: "arm64",
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			fs, err := testutil.NewFs(files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stdout := &bytes.Buffer{}
+			ctrl := New(finder.NewConfigFinder(fs), reader.New(fs, d.param), registry.New(d.param, downloader, fs, rt, &cosign.MockVerifier{}, &slsa.MockVerifier{}), fs, rt)
+			ctrl.stdout = stdout
+			if err := ctrl.Export(t.Context(), logE, d.param); err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(d.expOut, stdout.String()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestController_cacheKey(t *testing.T) {
+	t.Parallel()
+	logE := logrus.NewEntry(logrus.New())
+	getKey := func(t *testing.T, dir, version, checksums string) string {
+		t.Helper()
+		fs, err := testutil.NewFs(map[string]string{
+			dir + "/aqua.yaml": `registries:
+- type: standard
+  ref: v4.155.1
+packages:
+- name: cli/cli@` + version + `
+`,
+			dir + "/aqua-checksums.json": checksums,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg := &aqua.Config{}
+		if err := reader.New(fs, &config.Param{}).Read(logE, dir+"/aqua.yaml", cfg); err != nil {
+			t.Fatal(err)
+		}
+		ctrl := &Controller{fs: fs}
+		key, err := ctrl.cacheKey(&exportedConfig{
+			cfg:              cfg,
+			cfgFilePath:      dir + "/aqua.yaml",
+			checksumFilePath: dir + "/aqua-checksums.json",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return key
+	}
+	key := getKey(t, "/home/foo/workspace", "v2.45.0", "{}")
+	if k := getKey(t, "/home/bar/repo", "v2.45.0", "{}"); k != key {
+		t.Fatalf("the cache key must not depend on the directory: %s != %s", k, key)
+	}
+	if k := getKey(t, "/home/foo/workspace", "v2.46.0", "{}"); k == key {
+		t.Fatal("the cache key must be changed when the package version is changed")
+	}
+	if k := getKey(t, "/home/foo/workspace", "v2.45.0", `{"checksums": []}`); k == key {
+		t.Fatal("the cache key must be changed when the checksum file is changed")
+	}
+}
+
+func TestController_Export_aquaInstaller(t *testing.T) {
+	t.Parallel()
+	files := map[string]string{
+		"/home/foo/workspace/aqua.yaml": `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: cli/cli@v2.45.0
+`,
+		"/home/foo/workspace/registry.yaml": `packages:
+- type: github_release
+  repo_owner: cli
+  repo_name: cli
+  asset: gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.tar.gz
+  files:
+  - name: gh
+`,
+	}
+	logE := logrus.NewEntry(logrus.New())
+	downloader := download.NewGitHubContentFileDownloader(nil, download.NewHTTPDownloader(logE, http.DefaultClient))
+	rt := &runtime.Runtime{
+		GOOS:   "linux",
+		GOARCH: "amd64",
+	}
+	export := func(t *testing.T, param *config.Param) string {
+		t.Helper()
+		fs, err := testutil.NewFs(files)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stdout := &bytes.Buffer{}
+		ctrl := New(finder.NewConfigFinder(fs), reader.New(fs, param), registry.New(param, downloader, fs, rt, &cosign.MockVerifier{}, &slsa.MockVerifier{}), fs, rt)
+		ctrl.stdout = stdout
+		if err := ctrl.Export(t.Context(), logE, param); err != nil {
+			t.Fatal(err)
+		}
+		return stdout.String()
+	}
+	dockerfile := export(t, &config.Param{
+		PWD:             "/home/foo/workspace",
+		ExportTarget:    TargetDockerfile,
+		ExportBaseImage: "debian:bookworm-slim@sha256:0123",
+		MaxParallelism:  5,
+	})
+	actions := export(t, &config.Param{
+		PWD:          "/home/foo/workspace",
+		ExportTarget: TargetGitHubActions,
+	})
+	// Both snippets must use the same pin of aqua-installer.
+	for name, out := range map[string]string{
+		TargetDockerfile:    dockerfile,
+		TargetGitHubActions: actions,
+	} {
+		if !strings.Contains(out, aquaInstallerCommit) || !strings.Contains(out, aquaInstallerVersion) {
+			t.Fatalf("%s must use aqua-installer %s (%s): %s", name, aquaInstallerVersion, aquaInstallerCommit, out)
+		}
+	}
+	if n := strings.Count(dockerfile, "FROM debian:bookworm-slim@sha256:0123"); n != 2 {
+		t.Fatalf("the base image must be used in both stages: %s", dockerfile)
+	}
+}
@@ -0,0 +1,134 @@
+package export
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/spf13/afero"
+)
+
+// cacheKeySource is the content of the cache key.
+// Paths are relative to the configuration file so that the key doesn't depend on the working directory.
+type cacheKeySource struct {
+	Registries []*cacheKeyRegistry `json:"registries"`
+	Packages   []*cacheKeyPackage  `json:"packages"`
+	Checksums  string              `json:"checksums,omitempty"`
+}
+
+type cacheKeyRegistry struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	RepoOwner string `json:"repo_owner,omitempty"`
+	RepoName  string `json:"repo_name,omitempty"`
+	Ref       string `json:"ref,omitempty"`
+	Path      string `json:"path,omitempty"`
+	// Content is the sha256 checksum of a local registry.
+	Content string `json:"content,omitempty"`
+}
+
+type cacheKeyPackage struct {
+	Registry string `json:"registry"`
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+}
+
+// exportGitHubActions outputs GitHub Actions steps to install aqua and cache packages.
+// The cache key is computed from the effective configuration, the registry refs, and the checksum file.
+// The effective configuration includes imported files and versions resolved by version_expr and go_version_file.
+func (c *Controller) exportGitHubActions(ec *exportedConfig) error {
+	key, err := c.cacheKey(ec)
+	if err != nil {
+		return err
+	}
+	aquaVersion := ec.aquaVersion
+	if aquaVersion == "" {
+		aquaVersion = "[SET AQUA VERSION]"
+	}
+	fmt.Fprintf(c.stdout, `# Generated by "aqua export --target github-actions". Please run the command again when the configuration is changed.
+- uses: actions/cache@v4
+  with:
+    path: ~/.local/share/aquaproj-aqua
+    key: aqua-${{ runner.os }}-${{ runner.arch }}-%s
+- uses: %s
+  with:
+    aqua_version: %s
+`, key, aquaInstallerAction(), aquaVersion)
+	return nil
+}
+
+func (c *Controller) cacheKey(ec *exportedConfig) (string, error) {
+	cfgDir := filepath.Dir(ec.cfgFilePath)
+	src := &cacheKeySource{
+		Registries: make([]*cacheKeyRegistry, 0, len(ec.cfg.Registries)),
+		Packages:   make([]*cacheKeyPackage, 0, len(ec.cfg.Packages)),
+	}
+	for _, rgst := range ec.cfg.Registries {
+		r := &cacheKeyRegistry{
+			Name:      rgst.Name,
+			Type:      rgst.Type,
+			RepoOwner: rgst.RepoOwner,
+			RepoName:  rgst.RepoName,
+			Ref:       rgst.Ref,
+		}
+		if rgst.Type == aqua.RegistryTypeLocal {
+			rel, err := filepath.Rel(cfgDir, rgst.Path)
+			if err != nil {
+				return "", fmt.Errorf("get a relative path of a local registry: %w", err)
+			}
+			r.Path = filepath.ToSlash(rel)
+			content, err := c.sha256(rgst.Path)
+			if err != nil {
+				return "", fmt.Errorf("read a local registry: %w", err)
+			}
+			r.Content = content
+		} else {
+			r.Path = rgst.Path
+		}
+		src.Registries = append(src.Registries, r)
+	}
+	slices.SortFunc(src.Registries, func(a, b *cacheKeyRegistry) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	for _, pkg := range ec.cfg.Packages {
+		src.Packages = append(src.Packages, &cacheKeyPackage{
+			Registry: pkg.Registry,
+			Name:     pkg.Name,
+			Version:  pkg.Version,
+		})
+	}
+	slices.SortFunc(src.Packages, func(a, b *cacheKeyPackage) int {
+		return cmp.Or(
+			cmp.Compare(a.Registry, b.Registry),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.Version, b.Version),
+		)
+	})
+	if ec.checksumFilePath != "" {
+		content, err := c.sha256(ec.checksumFilePath)
+		if err != nil {
+			return "", fmt.Errorf("read a checksum file: %w", err)
+		}
+		src.Checksums = content
+	}
+	b, err := json.Marshal(src)
+	if err != nil {
+		return "", fmt.Errorf("encode the source of the cache key: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (c *Controller) sha256(p string) (string, error) {
+	b, err := afero.ReadFile(c.fs, p)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
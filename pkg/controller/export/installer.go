@@ -0,0 +1,20 @@
+package export
+
+// aqua-installer is pinned with the commit hash of the release.
+// Both the Dockerfile and GitHub Actions steps use this pin, so update the version and the commit hash together.
+// The commit hash can be verified by `git ls-remote https://github.com/aquaproj/aqua-installer <version>`.
+const (
+	aquaInstallerVersion = "v4.0.4"
+	aquaInstallerCommit  = "11dd79b4e498d471a9385aa9fb7f62bb5f52a73c"
+)
+
+// aquaInstallerAction returns the reference of the GitHub Action aqua-installer.
+func aquaInstallerAction() string {
+	return "aquaproj/aqua-installer@" + aquaInstallerCommit + " # " + aquaInstallerVersion
+}
+
+// aquaInstallerScriptURL returns the URL of the shell script aqua-installer.
+// The script is downloaded with the commit hash so that it isn't changed.
+func aquaInstallerScriptURL() string {
+	return "https://raw.githubusercontent.com/aquaproj/aqua-installer/" + aquaInstallerCommit + "/aqua-installer"
+}
@@ -2,6 +2,7 @@ package genrgst
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/aquaproj/aqua/v2/pkg/expr"
 	"github.com/expr-lang/expr/vm"
@@ -14,6 +15,11 @@ type Config struct {
 	VersionFilter   *vm.Program
 	AllAssetsFilter *vm.Program
 	Package         string
+	URL             string
+	Versions        []string
+	Assets          []string
+	AssetsDir       string
+	GitLabHosts     []string
 }
 
 type RawConfig struct {
@@ -21,6 +27,20 @@ type RawConfig struct {
 	VersionPrefix   string `yaml:"version_prefix" json:"version_prefix,omitempty"`
 	AllAssetsFilter string `yaml:"all_assets_filter" json:"all_assets_filter,omitempty"`
 	Package         string `yaml:"name" json:"name"`
+	// URL is a template of the download URL to generate a package of http type.
+	// {{.Asset}} is replaced with the asset name template inferred from assets.
+	URL string `yaml:"url" json:"url,omitempty" jsonschema:"example=https://example.com/foo/{{.Version}}/{{.Asset}}"`
+	// Versions is a list of versions in descending order. The first version is used to infer the asset name template.
+	Versions []string `yaml:"versions" json:"versions,omitempty"`
+	// Assets is a list of asset names of the first version.
+	Assets []string `yaml:"assets" json:"assets,omitempty"`
+	// AssetsDir is a local directory containing release assets of the first version.
+	// A relative path is relative to the configuration file.
+	AssetsDir string `yaml:"assets_dir" json:"assets_dir,omitempty"`
+	// GitLabHosts is a list of self-managed GitLab hosts.
+	// A package name starting with one of them is generated from GitLab Releases.
+	// Hosts starting with "gitlab." are always treated as GitLab.
+	GitLabHosts []string `yaml:"gitlab_hosts" json:"gitlab_hosts,omitempty" jsonschema:"example=git.example.com"`
 }
 
 func (c *Config) FromRaw(raw *RawConfig) error {
@@ -30,6 +50,11 @@ func (c *Config) FromRaw(raw *RawConfig) error {
 
 	c.Package = raw.Package
 	c.VersionPrefix = raw.VersionPrefix
+	c.URL = raw.URL
+	c.Versions = raw.Versions
+	c.Assets = raw.Assets
+	c.AssetsDir = raw.AssetsDir
+	c.GitLabHosts = raw.GitLabHosts
 
 	if raw.VersionFilter != "" {
 		r, err := expr.CompileVersionFilter(raw.VersionFilter)
@@ -63,5 +88,8 @@ func readConfig(fs afero.Fs, path string, cfg *Config) error {
 	if err := yaml.NewDecoder(f).Decode(raw); err != nil {
 		return fmt.Errorf("decode a generate configuration file as YAML: %w", err)
 	}
+	if raw.AssetsDir != "" && !filepath.IsAbs(raw.AssetsDir) {
+		raw.AssetsDir = filepath.Join(filepath.Dir(path), raw.AssetsDir)
+	}
 	return cfg.FromRaw(raw)
 }
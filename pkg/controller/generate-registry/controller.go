@@ -3,6 +3,7 @@ package genrgst
 import (
 	"context"
 	"io"
+	"os"
 
 	"github.com/aquaproj/aqua/v2/pkg/cargo"
 	"github.com/aquaproj/aqua/v2/pkg/controller/generate/output"
@@ -10,24 +11,29 @@ import (
 )
 
 type Controller struct {
-	stdout            io.Writer
-	fs                afero.Fs
-	github            RepositoriesService
-	testdataOutputter TestdataOutputter
-	cargoClient       CargoClient
+	stdout              io.Writer
+	stderr              io.Writer
+	fs                  afero.Fs
+	github              RepositoriesService
+	testdataOutputter   TestdataOutputter
+	cargoClient         CargoClient
+	gitlab              GitLabClient
+	newPackageInstaller NewPackageInstaller
 }
 
 type TestdataOutputter interface {
 	Output(param *output.Param) error
 }
 
-func NewController(fs afero.Fs, gh RepositoriesService, testdataOutputter TestdataOutputter, cargoClient CargoClient, stdout io.Writer) *Controller {
+func NewController(fs afero.Fs, gh RepositoriesService, testdataOutputter TestdataOutputter, cargoClient CargoClient, gitlabClient GitLabClient, stdout io.Writer) *Controller {
 	return &Controller{
 		stdout:            stdout,
+		stderr:            os.Stderr,
 		fs:                fs,
 		github:            gh,
 		testdataOutputter: testdataOutputter,
 		cargoClient:       cargoClient,
+		gitlab:            gitlabClient,
 	}
 }
 
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"regexp"
 	"strings"
 
 	"github.com/aquaproj/aqua/v2/pkg/asset"
@@ -31,6 +30,7 @@ func (c *Controller) GenerateRegistry(ctx context.Context, param *config.Param,
 			return err
 		}
 	}
+	cfg.GitLabHosts = append(cfg.GitLabHosts, param.GitLabHosts...)
 
 	args, err := parseArgs(args, cfg)
 	if err != nil {
@@ -88,11 +88,12 @@ func (c *Controller) genRegistry(ctx context.Context, param *config.Param, logE
 		if err := encoder.EncodeContext(ctx, cfg); err != nil {
 			return fmt.Errorf("encode YAML: %w", err)
 		}
-		return nil
-	}
-	if err := c.insert(param.InsertFile, registry.PackageInfos{pkgInfo}); err != nil {
+	} else if err := c.insert(param.InsertFile, registry.PackageInfos{pkgInfo}); err != nil {
 		return err
 	}
+	if param.Verify {
+		return c.verify(ctx, logE, pkgInfo, versions)
+	}
 	return nil
 }
 
@@ -129,6 +130,12 @@ func (c *Controller) getPackageInfoMain(ctx context.Context, logE *logrus.Entry,
 	if strings.HasPrefix(pkgName, "crates.io/") {
 		return c.getCargoPackageInfo(ctx, logE, pkgName)
 	}
+	if cfg.URL != "" {
+		return c.getHTTPPackageInfo(logE, pkgName, version, cfg)
+	}
+	if _, _, ok := parseGitLabPkgName(pkgName, cfg.GitLabHosts); ok {
+		return c.getGitLabPackageInfo(ctx, logE, pkgName, version, limit, cfg)
+	}
 	splitPkgNames := strings.Split(pkgName, "/")
 	pkgInfo := &registry.PackageInfo{
 		Type:          "github_release",
@@ -179,7 +186,8 @@ func (c *Controller) getPackageInfoMain(ctx context.Context, logE *logrus.Entry,
 	}
 
 	c.patchRelease(logE, pkgInfo, pkgName, release.GetTagName(), assetNames)
-	return pkgInfo, []string{version}
+	c.patchGitHubArtifactAttestations(ctx, logE, pkgInfo, pkgName, release.GetTagName(), arr)
+	return pkgInfo, []string{release.GetTagName()}
 }
 
 func getChecksum(checksumNames map[string]struct{}, assetName string) *registry.Checksum {
@@ -210,6 +218,7 @@ func (c *Controller) patchRelease(logE *logrus.Entry, pkgInfo *registry.PackageI
 	pkgNameContainChecksum := strings.Contains(strings.ToLower(pkgName), "checksum")
 	assetNames := map[string]struct{}{}
 	checksumNames := map[string]struct{}{}
+	mainAssets := make([]string, 0, len(assets))
 	for _, assetName := range assets {
 		if !pkgNameContainChecksum {
 			chksum := checksum.GetChecksumConfigFromFilename(assetName, tagName)
@@ -223,6 +232,10 @@ func (c *Controller) patchRelease(logE *logrus.Entry, pkgInfo *registry.PackageI
 			continue
 		}
 		assetNames[assetName] = struct{}{}
+		if isSignatureFile(assetName) {
+			continue
+		}
+		mainAssets = append(mainAssets, assetName)
 		assetInfo := asset.ParseAssetName(assetName, tagName)
 		assetInfos = append(assetInfos, assetInfo)
 	}
@@ -245,11 +258,13 @@ func (c *Controller) patchRelease(logE *logrus.Entry, pkgInfo *registry.PackageI
 				assetInfo := asset.ParseAssetName(checksumName, tagName)
 				chksum.Asset = assetInfo.Template
 				chksum.Cosign = checkChecksumCosign(pkgInfo, checksumName, assetNames)
+				chksum.Minisign = checkMinisign(logE, checksumName, assetInfo.Template, assetNames)
 				pkgInfo.Checksum = chksum
 				break
 			}
 		}
 	}
+	patchSignatures(logE, pkgInfo, assetNames, mainAssets)
 	asset.ParseAssetInfos(pkgInfo, assetInfos)
 }
 
@@ -328,7 +343,7 @@ func findCosignBundle(assetNames map[string]struct{}, assetName string) string {
 
 func checkChecksumCosign(pkgInfo *registry.PackageInfo, checksumAssetName string, assetNames map[string]struct{}) *registry.Cosign { //nolint:cyclop
 	cosign := &registry.Cosign{
-		Opts: make([]string, 0, 8), //nolint:mnd // we generate max 8 arguments (certificate case)
+		Opts: make([]string, 0, 10), //nolint:mnd // we generate max 10 arguments (certificate case)
 	}
 	downloadURL := fmt.Sprintf("https://github.com/%s/%s/releases/download/{{.Version}}/",
 		pkgInfo.RepoOwner, pkgInfo.RepoName)
@@ -346,16 +361,7 @@ func checkChecksumCosign(pkgInfo *registry.PackageInfo, checksumAssetName string
 		)
 	}
 	if bundleAssetName != "" || certificateAssetName != "" {
-		cosign.Opts = append(cosign.Opts,
-			"--certificate-identity-regexp",
-			fmt.Sprintf(
-				`^https://github\.com/%s/%s/\.github/workflows/.+\.ya?ml@refs/tags/\Q{{.Version}}\E$`,
-				regexp.QuoteMeta(pkgInfo.RepoOwner),
-				regexp.QuoteMeta(pkgInfo.RepoName),
-			),
-			"--certificate-oidc-issuer",
-			"https://token.actions.githubusercontent.com",
-		)
+		cosign.Opts = append(cosign.Opts, cosignKeylessOpts(pkgInfo)...)
 	}
 
 	// If a bundle was found, nothing else is needed
@@ -8,6 +8,7 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/config"
 	"github.com/aquaproj/aqua/v2/pkg/config/registry"
 	"github.com/aquaproj/aqua/v2/pkg/github"
+	"github.com/aquaproj/aqua/v2/pkg/gitlab"
 	"github.com/aquaproj/aqua/v2/pkg/ptr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
@@ -144,7 +145,7 @@ func TestController_getPackageInfo(t *testing.T) { //nolint:funlen
 				CratePayload: d.crate,
 			}
 			var buf bytes.Buffer
-			ctrl := NewController(nil, gh, nil, cargoClient, &buf)
+			ctrl := NewController(nil, gh, nil, cargoClient, &gitlab.MockClient{}, &buf)
 			pkgInfo, _ := ctrl.getPackageInfo(ctx, logE, d.pkgName, &config.Param{}, &Config{})
 			if diff := cmp.Diff(d.exp, pkgInfo); diff != "" {
 				t.Fatal(diff)
@@ -180,9 +181,11 @@ func TestController_checkChecksumCosign(t *testing.T) { //nolint:funlen
 				},
 				Opts: []string{
 					"--certificate-identity-regexp",
-					`^https://github\.com/owner/repo/\.github/workflows/.+\.ya?ml@refs/tags/\Q{{.Version}}\E$`,
+					`^https://github\.com/[^/]+/[^/]+/\.github/workflows/[^@]+@.+$`,
 					"--certificate-oidc-issuer",
 					"https://token.actions.githubusercontent.com",
+					"--certificate-github-workflow-repository",
+					"owner/repo",
 				},
 			},
 		},
@@ -203,9 +206,11 @@ func TestController_checkChecksumCosign(t *testing.T) { //nolint:funlen
 					"--certificate",
 					"https://github.com/owner/repo/releases/download/{{.Version}}/checksums.txt-keyless.pem",
 					"--certificate-identity-regexp",
-					`^https://github\.com/owner/repo/\.github/workflows/.+\.ya?ml@refs/tags/\Q{{.Version}}\E$`,
+					`^https://github\.com/[^/]+/[^/]+/\.github/workflows/[^@]+@.+$`,
 					"--certificate-oidc-issuer",
 					"https://token.actions.githubusercontent.com",
+					"--certificate-github-workflow-repository",
+					"owner/repo",
 					"--signature",
 					"https://github.com/owner/repo/releases/download/{{.Version}}/checksums.txt-keyless.sig",
 				},
@@ -291,9 +296,11 @@ func TestController_checkChecksumCosign(t *testing.T) { //nolint:funlen
 				},
 				Opts: []string{
 					"--certificate-identity-regexp",
-					`^https://github\.com/owner/repo/\.github/workflows/.+\.ya?ml@refs/tags/\Q{{.Version}}\E$`,
+					`^https://github\.com/[^/]+/[^/]+/\.github/workflows/[^@]+@.+$`,
 					"--certificate-oidc-issuer",
 					"https://token.actions.githubusercontent.com",
+					"--certificate-github-workflow-repository",
+					"owner/repo",
 				},
 			},
 		},
@@ -317,9 +324,11 @@ func TestController_checkChecksumCosign(t *testing.T) { //nolint:funlen
 				},
 				Opts: []string{
 					"--certificate-identity-regexp",
-					`^https://github\.com/owner/repo/\.github/workflows/.+\.ya?ml@refs/tags/\Q{{.Version}}\E$`,
+					`^https://github\.com/[^/]+/[^/]+/\.github/workflows/[^@]+@.+$`,
 					"--certificate-oidc-issuer",
 					"https://token.actions.githubusercontent.com",
+					"--certificate-github-workflow-repository",
+					"owner/repo",
 				},
 			},
 		},
@@ -341,9 +350,11 @@ func TestController_checkChecksumCosign(t *testing.T) { //nolint:funlen
 					"--certificate",
 					"https://github.com/owner/repo/releases/download/{{.Version}}/checksums.txt-keyless.pem",
 					"--certificate-identity-regexp",
-					`^https://github\.com/owner/repo/\.github/workflows/.+\.ya?ml@refs/tags/\Q{{.Version}}\E$`,
+					`^https://github\.com/[^/]+/[^/]+/\.github/workflows/[^@]+@.+$`,
 					"--certificate-oidc-issuer",
 					"https://token.actions.githubusercontent.com",
+					"--certificate-github-workflow-repository",
+					"owner/repo",
 					"--signature",
 					"https://github.com/owner/repo/releases/download/{{.Version}}/checksums.txt-keyless.sig",
 				},
@@ -0,0 +1,172 @@
+package genrgst
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/gitlab"
+	"github.com/sirupsen/logrus"
+)
+
+var errNoRelease = errors.New("no release is found")
+
+const (
+	// maxGitLabReleases is the maximum page size of GitLab API.
+	maxGitLabReleases = 100
+	// maxGitLabPages is the maximum number of pages to list releases.
+	maxGitLabPages = 10
+)
+
+type GitLabClient interface {
+	GetProject(ctx context.Context, host, project string) (*gitlab.Project, error)
+	ListReleases(ctx context.Context, host, project string, opt *gitlab.ListOptions) ([]*gitlab.Release, int, error)
+	GetRelease(ctx context.Context, host, project, tag string) (*gitlab.Release, error)
+}
+
+// parseGitLabPkgName parses a package name such as gitlab.com/gitlab-org/cli.
+// The host must start with "gitlab." or be one of hosts so that it isn't confused with GitHub repositories and crates.
+// hosts are self-managed GitLab hosts whose names don't start with "gitlab.".
+func parseGitLabPkgName(pkgName string, hosts []string) (string, string, bool) {
+	host, project, ok := strings.Cut(pkgName, "/")
+	if !ok || !strings.Contains(project, "/") {
+		return "", "", false
+	}
+	if !strings.HasPrefix(host, "gitlab.") && !slices.Contains(hosts, host) {
+		return "", "", false
+	}
+	return host, project, true
+}
+
+// getGitLabPackageInfo generates a package of http type from links of GitLab Releases.
+func (c *Controller) getGitLabPackageInfo(ctx context.Context, logE *logrus.Entry, pkgName, version string, limit int, cfg *Config) (*registry.PackageInfo, []string) {
+	host, project, _ := parseGitLabPkgName(pkgName, cfg.GitLabHosts)
+	pkgInfo := &registry.PackageInfo{
+		Name:          pkgName,
+		Type:          "http",
+		Link:          fmt.Sprintf("https://%s/%s", host, project),
+		VersionPrefix: cfg.VersionPrefix,
+	}
+	if cfg.VersionFilter != nil {
+		pkgInfo.VersionFilter = cfg.VersionFilter.Source().String()
+	}
+	logE = logE.WithFields(logrus.Fields{
+		"gitlab_host":    host,
+		"gitlab_project": project,
+	})
+	if p, err := c.gitlab.GetProject(ctx, host, project); err != nil {
+		logE.WithError(err).Warn("get the project")
+	} else {
+		pkgInfo.Description = p.Description
+	}
+	release, versions, err := c.getGitLabRelease(ctx, logE, host, project, version, limit, cfg)
+	if err != nil {
+		logE.WithError(err).Warn("get the release")
+		return pkgInfo, versions
+	}
+	logE.WithField("version", release.TagName).Debug("got the release")
+	assets, urlTemplate := gitlabAssets(logE, host, project, release, cfg)
+	c.patchRelease(logE, pkgInfo, pkgName, release.TagName, assets)
+	toHTTP(pkgInfo, urlTemplate)
+	return pkgInfo, versions
+}
+
+func (c *Controller) getGitLabRelease(ctx context.Context, logE *logrus.Entry, host, project, version string, limit int, cfg *Config) (*gitlab.Release, []string, error) {
+	if version != "" {
+		release, err := c.gitlab.GetRelease(ctx, host, project, version)
+		return release, []string{version}, err //nolint:wrapcheck
+	}
+	releases, err := c.listGitLabReleases(ctx, host, project, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	var latest *gitlab.Release
+	versions := make([]string, 0, len(releases))
+	for _, release := range releases {
+		if excludeVersion(logE, release.TagName, cfg) {
+			continue
+		}
+		if latest == nil {
+			latest = release
+		}
+		versions = append(versions, release.TagName)
+	}
+	if latest == nil {
+		return nil, nil, errNoRelease
+	}
+	return latest, versions, nil
+}
+
+// listGitLabReleases lists releases following the pagination.
+// If limit is zero, releases are listed up to maxGitLabPages pages.
+func (c *Controller) listGitLabReleases(ctx context.Context, host, project string, limit int) ([]*gitlab.Release, error) {
+	opt := &gitlab.ListOptions{
+		PerPage: maxGitLabReleases,
+	}
+	if limit > 0 && limit < maxGitLabReleases {
+		opt.PerPage = limit
+	}
+	var arr []*gitlab.Release
+	for range maxGitLabPages {
+		releases, nextPage, err := c.gitlab.ListReleases(ctx, host, project, opt)
+		if err != nil {
+			return nil, fmt.Errorf("list releases: %w", err)
+		}
+		arr = append(arr, releases...)
+		if limit > 0 && len(arr) >= limit {
+			return arr[:limit], nil
+		}
+		if nextPage == 0 {
+			return arr, nil
+		}
+		opt.Page = nextPage
+	}
+	return arr, nil
+}
+
+// gitlabAssets returns asset names and a URL template from links of a release.
+// An asset name is the last path element of the link URL.
+// If link URLs don't have a common pattern such as uploaded files,
+// the permanent link of release assets is used instead.
+func gitlabAssets(logE *logrus.Entry, host, project string, release *gitlab.Release, cfg *Config) ([]string, string) {
+	fallback := fmt.Sprintf("https://%s/%s/-/releases/{{.Version}}/downloads/{{.Asset}}", host, project)
+	if release.Assets == nil {
+		return nil, fallback
+	}
+	assets := make([]string, 0, len(release.Assets.Links))
+	urlTemplate := ""
+	common := true
+	for _, link := range release.Assets.Links {
+		u := link.GetURL()
+		idx := strings.LastIndex(u, "/")
+		if idx == -1 {
+			continue
+		}
+		assetName, err := url.PathUnescape(u[idx+1:])
+		if err != nil {
+			assetName = u[idx+1:]
+		}
+		tpl := strings.ReplaceAll(u[:idx+1], release.TagName, "{{.Version}}") + "{{.Asset}}"
+		if urlTemplate == "" {
+			urlTemplate = tpl
+		} else if urlTemplate != tpl {
+			common = false
+		}
+		if excludeAsset(logE, assetName, cfg) {
+			continue
+		}
+		assets = append(assets, assetName)
+	}
+	if !common {
+		logE.Warn("URLs of release assets don't have a common pattern, so please fix url")
+		return assets, fallback
+	}
+	if urlTemplate == "" {
+		return assets, fallback
+	}
+	return assets, urlTemplate
+}
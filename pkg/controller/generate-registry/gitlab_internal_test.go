@@ -0,0 +1,176 @@
+package genrgst
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/gitlab"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+func TestController_getGitLabPackageInfo(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	releases := []*gitlab.Release{
+		{
+			TagName: "v1.2.0",
+			Assets: &gitlab.Assets{
+				Links: []*gitlab.Link{
+					{
+						Name:           "Linux amd64",
+						URL:            "https://gitlab.com/api/v4/projects/123/packages/generic/foo/v1.2.0/foo_linux_amd64.tar.gz",
+						DirectAssetURL: "https://gitlab.com/foo/foo/-/releases/v1.2.0/downloads/foo_linux_amd64.tar.gz",
+					},
+					{
+						Name:           "macOS arm64",
+						URL:            "https://gitlab.com/api/v4/projects/123/packages/generic/foo/v1.2.0/foo_darwin_arm64.tar.gz",
+						DirectAssetURL: "https://gitlab.com/foo/foo/-/releases/v1.2.0/downloads/foo_darwin_arm64.tar.gz",
+					},
+				},
+			},
+		},
+		{
+			TagName: "v1.1.0",
+			Assets: &gitlab.Assets{
+				Links: []*gitlab.Link{
+					{
+						Name: "foo_linux_amd64.tar.gz",
+						URL:  "https://gitlab.com/foo/foo/uploads/0123/foo_linux_amd64.tar.gz",
+					},
+					{
+						Name: "foo_darwin_arm64.tar.gz",
+						URL:  "https://gitlab.com/foo/foo/uploads/4567/foo_darwin_arm64.tar.gz",
+					},
+				},
+			},
+		},
+	}
+	data := []struct {
+		name     string
+		pkgName  string
+		exp      *registry.PackageInfo
+		versions []string
+	}{
+		{
+			name:    "latest",
+			pkgName: "gitlab.com/foo/foo",
+			exp: &registry.PackageInfo{
+				Name:        "gitlab.com/foo/foo",
+				Type:        "http",
+				Link:        "https://gitlab.com/foo/foo",
+				Description: "Hello",
+				URL:         "https://gitlab.com/foo/foo/-/releases/{{.Version}}/downloads/foo_{{.OS}}_{{.Arch}}.{{.Format}}",
+				Format:      "tar.gz",
+				SupportedEnvs: registry.SupportedEnvs{
+					"linux/amd64",
+					"darwin/arm64",
+				},
+			},
+			versions: []string{"v1.2.0", "v1.1.0"},
+		},
+		{
+			name:    "uploaded files",
+			pkgName: "gitlab.com/foo/foo@v1.1.0",
+			exp: &registry.PackageInfo{
+				Name:        "gitlab.com/foo/foo",
+				Type:        "http",
+				Link:        "https://gitlab.com/foo/foo",
+				Description: "Hello",
+				URL:         "https://gitlab.com/foo/foo/-/releases/{{.Version}}/downloads/foo_{{.OS}}_{{.Arch}}.{{.Format}}",
+				Format:      "tar.gz",
+				SupportedEnvs: registry.SupportedEnvs{
+					"linux/amd64",
+					"darwin/arm64",
+				},
+			},
+			versions: []string{"v1.1.0"},
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := NewController(nil, nil, nil, nil, &gitlab.MockClient{
+				Project: &gitlab.Project{
+					Description: "Hello.",
+				},
+				Releases: releases,
+			}, nil)
+			pkgInfo, versions := ctrl.getPackageInfo(t.Context(), logE, d.pkgName, &config.Param{}, &Config{})
+			if diff := cmp.Diff(d.exp, pkgInfo); diff != "" {
+				t.Fatal(diff)
+			}
+			if diff := cmp.Diff(d.versions, versions); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_parseGitLabPkgName(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		pkgName string
+		hosts   []string
+		host    string
+		project string
+		ok      bool
+	}{
+		{pkgName: "gitlab.com/gitlab-org/cli", host: "gitlab.com", project: "gitlab-org/cli", ok: true},
+		{pkgName: "gitlab.example.com/group/sub/project", host: "gitlab.example.com", project: "group/sub/project", ok: true},
+		{pkgName: "git.example.com/group/project", hosts: []string{"git.example.com"}, host: "git.example.com", project: "group/project", ok: true},
+		{pkgName: "git.example.com/group/project"},
+		{pkgName: "cli/cli"},
+		{pkgName: "cli/cli", hosts: []string{"git.example.com"}},
+		{pkgName: "gitlab.com/foo"},
+		{pkgName: "crates.io/ripgrep"},
+	}
+	for _, d := range data {
+		t.Run(d.pkgName, func(t *testing.T) {
+			t.Parallel()
+			host, project, ok := parseGitLabPkgName(d.pkgName, d.hosts)
+			if host != d.host || project != d.project || ok != d.ok {
+				t.Fatalf("got (%s, %s, %v), wanted (%s, %s, %v)", host, project, ok, d.host, d.project, d.ok)
+			}
+		})
+	}
+}
+
+func TestController_listGitLabReleases(t *testing.T) {
+	t.Parallel()
+	releases := make([]*gitlab.Release, 250)
+	for i := range releases {
+		releases[i] = &gitlab.Release{
+			TagName: fmt.Sprintf("v1.0.%d", len(releases)-i),
+		}
+	}
+	data := []struct {
+		name  string
+		limit int
+		exp   int
+	}{
+		{name: "no limit", exp: 250},
+		{name: "limit in a page", limit: 30, exp: 30},
+		{name: "limit over pages", limit: 150, exp: 150},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := NewController(nil, nil, nil, nil, &gitlab.MockClient{
+				Releases: releases,
+			}, nil)
+			arr, err := ctrl.listGitLabReleases(t.Context(), "gitlab.com", "foo/foo", d.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(arr) != d.exp {
+				t.Fatalf("got %d releases, wanted %d", len(arr), d.exp)
+			}
+			if diff := cmp.Diff(releases[:d.exp], arr); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package genrgst
 
 import (
+	"context"
 	"fmt"
 	"maps"
 	"reflect"
@@ -24,15 +25,18 @@ type Group struct {
 
 func ConvertPkgToVO(pkgInfo *registry.PackageInfo) *registry.VersionOverride {
 	return &registry.VersionOverride{
-		Asset:              pkgInfo.Asset,
-		Files:              pkgInfo.Files,
-		Format:             pkgInfo.Format,
-		Overrides:          pkgInfo.Overrides,
-		Replacements:       pkgInfo.Replacements,
-		SupportedEnvs:      pkgInfo.SupportedEnvs,
-		CompleteWindowsExt: pkgInfo.CompleteWindowsExt,
-		Checksum:           pkgInfo.Checksum,
-		SLSAProvenance:     pkgInfo.SLSAProvenance,
+		Asset:                      pkgInfo.Asset,
+		Files:                      pkgInfo.Files,
+		Format:                     pkgInfo.Format,
+		Overrides:                  pkgInfo.Overrides,
+		Replacements:               pkgInfo.Replacements,
+		SupportedEnvs:              pkgInfo.SupportedEnvs,
+		CompleteWindowsExt:         pkgInfo.CompleteWindowsExt,
+		Checksum:                   pkgInfo.Checksum,
+		SLSAProvenance:             pkgInfo.SLSAProvenance,
+		Cosign:                     pkgInfo.Cosign,
+		Minisign:                   pkgInfo.Minisign,
+		GitHubArtifactAttestations: pkgInfo.GitHubArtifactAttestations,
 	}
 }
 
@@ -272,7 +276,7 @@ func groupByExcludedAsset(groups []*Group) []*Group {
 	return newGroups
 }
 
-func (c *Controller) group(logE *logrus.Entry, pkgInfo *registry.PackageInfo, pkgName string, releases []*Release) []*Group {
+func (c *Controller) group(ctx context.Context, logE *logrus.Entry, pkgInfo *registry.PackageInfo, pkgName string, releases []*Release) []*Group {
 	if len(releases) == 0 {
 		return nil
 	}
@@ -295,6 +299,7 @@ func (c *Controller) group(logE *logrus.Entry, pkgInfo *registry.PackageInfo, pk
 	}
 
 	if len(groups) == 1 {
+		c.patchLatestGitHubArtifactAttestations(ctx, logE, pkgName, groups)
 		return groups
 	}
 	prevGroup := groups[0]
@@ -316,9 +321,34 @@ func (c *Controller) group(logE *logrus.Entry, pkgInfo *registry.PackageInfo, pk
 		return newGroups[:len(newGroups)-1]
 	}
 
-	return sortAndMergeGroups(newGroups)
+	newGroups = sortAndMergeGroups(newGroups)
+	c.patchLatestGitHubArtifactAttestations(ctx, logE, pkgName, newGroups)
+	return newGroups
+}
+
+// patchLatestGitHubArtifactAttestations sets github_artifact_attestations to the last group, which includes the latest release.
+// GitHub Artifact Attestations are checked only for the latest release to reduce API calls.
+// Old releases are rarely installed and attestations were often unavailable when they were released.
+func (c *Controller) patchLatestGitHubArtifactAttestations(ctx context.Context, logE *logrus.Entry, pkgName string, groups []*Group) {
+	if len(groups) == 0 {
+		return
+	}
+	group := groups[len(groups)-1]
+	if len(group.releases) == 0 {
+		return
+	}
+	latest := slices.MaxFunc(group.releases, func(a, b *Release) int {
+		if a.LessThan(b) {
+			return -1
+		}
+		if b.LessThan(a) {
+			return 1
+		}
+		return 0
+	})
+	c.patchGitHubArtifactAttestations(ctx, logE, group.pkg.Info, pkgName, latest.Tag, latest.assets)
 }
 
-func (c *Controller) generatePackage(logE *logrus.Entry, pkgInfo *registry.PackageInfo, pkgName string, releases []*Release) []string {
-	return mergeGroups(pkgInfo, c.group(logE, pkgInfo, pkgName, releases))
+func (c *Controller) generatePackage(ctx context.Context, logE *logrus.Entry, pkgInfo *registry.PackageInfo, pkgName string, releases []*Release) []string {
+	return mergeGroups(pkgInfo, c.group(ctx, logE, pkgInfo, pkgName, releases))
 }
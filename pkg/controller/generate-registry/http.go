@@ -0,0 +1,112 @@
+package genrgst
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+var errAssetsAreRequired = errors.New("either assets or assets_dir is required to generate a package of http type")
+
+// getHTTPPackageInfo generates a package of http type from a URL template, a list of versions, and asset names.
+// Asset names are given by the configuration file or a local directory of release assets.
+func (c *Controller) getHTTPPackageInfo(logE *logrus.Entry, pkgName, version string, cfg *Config) (*registry.PackageInfo, []string) {
+	pkgInfo := &registry.PackageInfo{
+		Name:          pkgName,
+		Type:          "http",
+		URL:           cfg.URL,
+		VersionPrefix: cfg.VersionPrefix,
+	}
+	if cfg.VersionFilter != nil {
+		pkgInfo.VersionFilter = cfg.VersionFilter.Source().String()
+	}
+	versions := cfg.Versions
+	if version != "" {
+		versions = []string{version}
+	}
+	if len(versions) == 0 {
+		logE.Warn("versions is empty, so the asset name template can't be inferred")
+		return pkgInfo, nil
+	}
+	assetNames, err := c.listHTTPAssets(cfg)
+	if err != nil {
+		logE.WithError(err).Warn("list assets")
+		return pkgInfo, versions
+	}
+	assets := make([]string, 0, len(assetNames))
+	for _, assetName := range assetNames {
+		if excludeAsset(logE, assetName, cfg) {
+			continue
+		}
+		assets = append(assets, assetName)
+	}
+	c.patchRelease(logE, pkgInfo, pkgName, versions[0], assets)
+	toHTTP(pkgInfo, cfg.URL)
+	return pkgInfo, versions
+}
+
+func (c *Controller) listHTTPAssets(cfg *Config) ([]string, error) {
+	if len(cfg.Assets) != 0 {
+		return cfg.Assets, nil
+	}
+	if cfg.AssetsDir == "" {
+		return nil, errAssetsAreRequired
+	}
+	infos, err := afero.ReadDir(c.fs, cfg.AssetsDir)
+	if err != nil {
+		return nil, fmt.Errorf("read a directory of assets: %w", err)
+	}
+	assets := make([]string, 0, len(infos))
+	for _, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+		assets = append(assets, info.Name())
+	}
+	sort.Strings(assets)
+	return assets, nil
+}
+
+// toHTTP converts a package of github_release type generated by patchRelease to http type.
+// {{.Asset}} in the URL template is replaced with the asset name template.
+// GitHub specific settings such as SLSA Provenance, Cosign, and Minisign are removed
+// because they can't be used as is.
+func toHTTP(pkgInfo *registry.PackageInfo, urlTemplate string) {
+	pkgInfo.Type = "http"
+	pkgInfo.NoAsset = false
+	if pkgInfo.Asset != "" {
+		pkgInfo.URL = renderURL(urlTemplate, pkgInfo.Asset)
+		pkgInfo.Asset = ""
+	}
+	for _, override := range pkgInfo.Overrides {
+		if override.Asset == "" {
+			continue
+		}
+		override.URL = renderURL(urlTemplate, override.Asset)
+		override.Asset = ""
+	}
+	if pkgInfo.Checksum != nil {
+		pkgInfo.Checksum.Type = "http"
+		if suffix, ok := strings.CutPrefix(pkgInfo.Checksum.Asset, "{{.Asset}}"); ok {
+			// {{.Asset}} isn't available in checksum.url of http type.
+			pkgInfo.Checksum.URL = "{{.AssetURL}}" + suffix
+		} else {
+			pkgInfo.Checksum.URL = renderURL(urlTemplate, pkgInfo.Checksum.Asset)
+		}
+		pkgInfo.Checksum.Asset = ""
+		pkgInfo.Checksum.Cosign = nil
+	}
+	pkgInfo.SLSAProvenance = nil
+	pkgInfo.Cosign = nil
+	pkgInfo.Minisign = nil
+	pkgInfo.GitHubArtifactAttestations = nil
+}
+
+func renderURL(urlTemplate, asset string) string {
+	return strings.ReplaceAll(urlTemplate, "{{.Asset}}", asset)
+}
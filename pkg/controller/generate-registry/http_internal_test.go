@@ -0,0 +1,136 @@
+package genrgst
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/testutil"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+func TestController_getHTTPPackageInfo(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name     string
+		pkgName  string
+		version  string
+		cfg      *Config
+		files    map[string]string
+		exp      *registry.PackageInfo
+		versions []string
+	}{
+		{
+			name:    "assets",
+			pkgName: "example.com/foo",
+			cfg: &Config{
+				URL:      "https://example.com/foo/{{.Version}}/{{.Asset}}",
+				Versions: []string{"v1.1.0", "v1.0.0"},
+				Assets: []string{
+					"foo_1.1.0_darwin_amd64.tar.gz",
+					"foo_1.1.0_darwin_arm64.tar.gz",
+					"foo_1.1.0_linux_amd64.tar.gz",
+					"foo_1.1.0_linux_arm64.tar.gz",
+					"foo_1.1.0_windows_amd64.zip",
+					"foo_1.1.0_checksums.txt",
+				},
+			},
+			exp: &registry.PackageInfo{
+				Name:   "example.com/foo",
+				Type:   "http",
+				URL:    "https://example.com/foo/{{.Version}}/foo_{{trimV .Version}}_{{.OS}}_{{.Arch}}.{{.Format}}",
+				Format: "tar.gz",
+				Overrides: []*registry.Override{
+					{
+						GOOS:   "windows",
+						Format: "zip",
+					},
+				},
+				Checksum: &registry.Checksum{
+					Type:      "http",
+					URL:       "https://example.com/foo/{{.Version}}/foo_{{trimV .Version}}_checksums.txt",
+					Algorithm: "sha256",
+				},
+				WindowsARMEmulation: true,
+			},
+			versions: []string{"v1.1.0", "v1.0.0"},
+		},
+		{
+			name:    "assets_dir",
+			pkgName: "example.com/bar",
+			version: "2.0.0",
+			cfg: &Config{
+				URL:       "https://example.com/bar/{{.Asset}}",
+				AssetsDir: "/home/foo/dist",
+			},
+			files: map[string]string{
+				"/home/foo/dist/bar-2.0.0-x86_64-unknown-linux-musl.tar.gz":        "",
+				"/home/foo/dist/bar-2.0.0-x86_64-unknown-linux-musl.tar.gz.sha256": "",
+				"/home/foo/dist/bar-2.0.0-aarch64-apple-darwin.tar.gz":             "",
+			},
+			exp: &registry.PackageInfo{
+				Name:   "example.com/bar",
+				Type:   "http",
+				URL:    "https://example.com/bar/bar-{{.Version}}-{{.Arch}}-{{.OS}}.{{.Format}}",
+				Format: "tar.gz",
+				Overrides: []*registry.Override{
+					{
+						GOOS: "linux",
+						Replacements: registry.Replacements{
+							"amd64": "x86_64",
+						},
+					},
+					{
+						GOOS: "darwin",
+						Replacements: registry.Replacements{
+							"arm64": "aarch64",
+						},
+					},
+				},
+				Replacements: registry.Replacements{
+					"darwin": "apple-darwin",
+					"linux":  "unknown-linux-musl",
+				},
+				Checksum: &registry.Checksum{
+					Type:      "http",
+					URL:       "{{.AssetURL}}.sha256",
+					Algorithm: "sha256",
+				},
+				SupportedEnvs: []string{"linux/amd64", "darwin/arm64"},
+			},
+			versions: []string{"2.0.0"},
+		},
+		{
+			name:    "no asset",
+			pkgName: "example.com/foo",
+			cfg: &Config{
+				URL:      "https://example.com/foo/{{.Version}}/{{.Asset}}",
+				Versions: []string{"v1.1.0"},
+			},
+			exp: &registry.PackageInfo{
+				Name: "example.com/foo",
+				Type: "http",
+				URL:  "https://example.com/foo/{{.Version}}/{{.Asset}}",
+			},
+			versions: []string{"v1.1.0"},
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			fs, err := testutil.NewFs(d.files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctrl := &Controller{fs: fs}
+			pkgInfo, versions := ctrl.getHTTPPackageInfo(logE, d.pkgName, d.version, d.cfg)
+			if diff := cmp.Diff(d.exp, pkgInfo); diff != "" {
+				t.Fatal(diff)
+			}
+			if diff := cmp.Diff(d.versions, versions); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
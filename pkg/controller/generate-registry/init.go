@@ -18,6 +18,14 @@ name: %%PACKAGE%%
 # version_filter: not (Version matches "-rc$")
 # version_prefix: cli-
 # all_assets_filter: not (Asset matches "-cli")
+# To generate a package of http type, set url and versions, and either assets or assets_dir.
+# url: https://example.com/foo/{{.Version}}/{{.Asset}}
+# versions:
+#   - v1.1.0
+#   - v1.0.0
+# assets: # asset names of the first version
+#   - foo_1.1.0_linux_amd64.tar.gz
+# assets_dir: dist # a local directory of release assets of the first version
 `
 
 func (c *Controller) initConfig(args ...string) error {
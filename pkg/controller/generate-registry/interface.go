@@ -12,4 +12,5 @@ type RepositoriesService interface {
 	GetReleaseByTag(ctx context.Context, owner, repo, tag string) (*github.RepositoryRelease, *github.Response, error)
 	ListReleaseAssets(ctx context.Context, owner, repo string, id int64, opts *github.ListOptions) ([]*github.ReleaseAsset, *github.Response, error)
 	ListReleases(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error)
+	ListAttestations(ctx context.Context, owner, repo, subjectDigest string, opts *github.ListOptions) (*github.AttestationsResponse, *github.Response, error)
 }
@@ -0,0 +1,225 @@
+package genrgst
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/asset"
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/github"
+	"github.com/sirupsen/logrus"
+)
+
+var errCertificateNotFound = errors.New("certificate isn't found in the bundle")
+
+// signatureSuffixes are suffixes of files to verify other assets.
+// These files are excluded from assets to infer the asset name template.
+var signatureSuffixes = []string{ //nolint:gochecknoglobals
+	".sig",
+	".pem",
+	".bundle",
+	".sigstore",
+	".sigstore.json",
+	".minisig",
+	".intoto.jsonl",
+	"cosign.pub",
+}
+
+func isSignatureFile(assetName string) bool {
+	for _, suffix := range signatureSuffixes {
+		if strings.HasSuffix(assetName, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// cosignKeylessOpts returns options of cosign to verify signatures generated by GitHub Actions keyless signing.
+// Signatures are often generated by reusable workflows in other repositories,
+// so the certificate identity accepts any workflow on GitHub Actions,
+// and instead the repository where the workflow ran is pinned to the package's repository.
+func cosignKeylessOpts(pkgInfo *registry.PackageInfo) []string {
+	return []string{
+		"--certificate-identity-regexp",
+		`^https://github\.com/[^/]+/[^/]+/\.github/workflows/[^@]+@.+$`,
+		"--certificate-oidc-issuer",
+		"https://token.actions.githubusercontent.com",
+		"--certificate-github-workflow-repository",
+		pkgInfo.RepoOwner + "/" + pkgInfo.RepoName,
+	}
+}
+
+func githubReleaseFile(asset string) *registry.DownloadedFile {
+	return &registry.DownloadedFile{
+		Type:  "github_release",
+		Asset: &asset,
+	}
+}
+
+// checkAssetCosign returns the cosign configuration to verify the asset itself.
+// Signature files must be named after the asset such as <asset>.sig, so {{.Asset}} is used in the template.
+func checkAssetCosign(pkgInfo *registry.PackageInfo, assetName string, assetNames map[string]struct{}) *registry.Cosign {
+	if bundle := findCosignBundle(assetNames, assetName); bundle != "" {
+		return &registry.Cosign{
+			Bundle: githubReleaseFile("{{.Asset}}" + strings.TrimPrefix(bundle, assetName)),
+			Opts:   cosignKeylessOpts(pkgInfo),
+		}
+	}
+	sig := findSignature(assetNames, assetName)
+	if sig == "" {
+		return nil
+	}
+	cos := &registry.Cosign{
+		Signature: githubReleaseFile("{{.Asset}}" + strings.TrimPrefix(sig, assetName)),
+	}
+	if cert := findCertificate(assetNames, assetName); cert != "" {
+		cos.Certificate = githubReleaseFile("{{.Asset}}" + strings.TrimPrefix(cert, assetName))
+		cos.Opts = cosignKeylessOpts(pkgInfo)
+		return cos
+	}
+	if strings.HasSuffix(sig, "-keyless.sig") {
+		return nil
+	}
+	pubKey := findPubKey(assetNames)
+	if pubKey == "" {
+		return nil
+	}
+	cos.Key = githubReleaseFile(pubKey)
+	return cos
+}
+
+// checkMinisign returns the minisign configuration if <asset>.minisig exists.
+// The public key can't be detected from assets, so it must be set manually.
+func checkMinisign(logE *logrus.Entry, assetName, assetTemplate string, assetNames map[string]struct{}) *registry.Minisign {
+	if _, ok := assetNames[assetName+".minisig"]; !ok {
+		return nil
+	}
+	logE.WithField("minisig", assetName+".minisig").Warn("a minisign signature is found. Please set public_key of minisign")
+	tpl := assetTemplate + ".minisig"
+	return &registry.Minisign{
+		Type:  "github_release",
+		Asset: &tpl,
+	}
+}
+
+// patchSignatures sets cosign and minisign to verify assets.
+// If the checksum file is verified with cosign, cosign isn't set to assets.
+func patchSignatures(logE *logrus.Entry, pkgInfo *registry.PackageInfo, assetNames map[string]struct{}, mainAssets []string) {
+	for _, assetName := range mainAssets {
+		if pkgInfo.Cosign == nil && pkgInfo.Checksum.GetCosign() == nil {
+			pkgInfo.Cosign = checkAssetCosign(pkgInfo, assetName, assetNames)
+		}
+		if pkgInfo.Minisign == nil {
+			pkgInfo.Minisign = checkMinisign(logE, assetName, "{{.Asset}}", assetNames)
+		}
+	}
+}
+
+// patchGitHubArtifactAttestations sets github_artifact_attestations if attestations of an asset exist.
+// The signer workflow is extracted from the certificate of the attestation.
+func (c *Controller) patchGitHubArtifactAttestations(ctx context.Context, logE *logrus.Entry, pkgInfo *registry.PackageInfo, pkgName, tagName string, assets []*github.ReleaseAsset) {
+	digest := findMainAssetDigest(pkgName, tagName, assets)
+	if digest == "" {
+		return
+	}
+	resp, _, err := c.github.ListAttestations(ctx, pkgInfo.RepoOwner, pkgInfo.RepoName, digest, &github.ListOptions{
+		PerPage: 1,
+	})
+	if err != nil {
+		// The API returns 404 if attestations don't exist.
+		logE.WithError(err).WithField("subject_digest", digest).Debug("list GitHub Artifact Attestations")
+		return
+	}
+	if resp == nil || len(resp.Attestations) == 0 {
+		return
+	}
+	gaa := &registry.GitHubArtifactAttestations{}
+	signerWorkflow, err := getSignerWorkflow(resp.Attestations[0].Bundle)
+	if err != nil {
+		logE.WithError(err).Warn("get a signer workflow from GitHub Artifact Attestations")
+	}
+	gaa.SignerWorkflow2 = signerWorkflow
+	pkgInfo.GitHubArtifactAttestations = gaa
+}
+
+func findMainAssetDigest(pkgName, tagName string, assets []*github.ReleaseAsset) string {
+	sorted := slices.Clone(assets)
+	slices.SortFunc(sorted, func(a, b *github.ReleaseAsset) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+	for _, a := range sorted {
+		name := a.GetName()
+		if a.GetDigest() == "" || isSignatureFile(name) || asset.Exclude(pkgName, name) {
+			continue
+		}
+		if checksum.GetChecksumConfigFromFilename(name, tagName) != nil {
+			continue
+		}
+		return a.GetDigest()
+	}
+	return ""
+}
+
+type attestationBundle struct {
+	VerificationMaterial *verificationMaterial `json:"verificationMaterial"`
+}
+
+type verificationMaterial struct {
+	Certificate          *bundleCertificate `json:"certificate"`
+	X509CertificateChain *struct {
+		Certificates []*bundleCertificate `json:"certificates"`
+	} `json:"x509CertificateChain"`
+}
+
+type bundleCertificate struct {
+	RawBytes []byte `json:"rawBytes"`
+}
+
+// getSignerWorkflow returns a signer workflow such as suzuki-shunsuke/go-release-workflow/.github/workflows/release.yaml
+// from the Fulcio certificate in a Sigstore bundle.
+func getSignerWorkflow(b []byte) (string, error) {
+	bundle := &attestationBundle{}
+	if err := json.Unmarshal(b, bundle); err != nil {
+		return "", fmt.Errorf("parse a Sigstore bundle as JSON: %w", err)
+	}
+	vm := bundle.VerificationMaterial
+	if vm == nil {
+		return "", errCertificateNotFound
+	}
+	cert := vm.Certificate
+	if cert == nil && vm.X509CertificateChain != nil && len(vm.X509CertificateChain.Certificates) > 0 {
+		cert = vm.X509CertificateChain.Certificates[0]
+	}
+	if cert == nil {
+		return "", errCertificateNotFound
+	}
+	c, err := x509.ParseCertificate(cert.RawBytes)
+	if err != nil {
+		return "", fmt.Errorf("parse a certificate: %w", err)
+	}
+	for _, u := range c.URIs {
+		if s := signerWorkflowFromURI(u); s != "" {
+			return s, nil
+		}
+	}
+	return "", nil
+}
+
+// signerWorkflowFromURI converts https://github.com/<owner>/<repo>/.github/workflows/<file>@<ref> to <owner>/<repo>/.github/workflows/<file>.
+func signerWorkflowFromURI(u *url.URL) string {
+	if u.Host != "github.com" {
+		return ""
+	}
+	p, _, _ := strings.Cut(strings.TrimPrefix(u.Path, "/"), "@")
+	if !strings.Contains(p, "/.github/workflows/") {
+		return ""
+	}
+	return p
+}
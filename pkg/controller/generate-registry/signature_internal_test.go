@@ -0,0 +1,283 @@
+package genrgst
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/github"
+	"github.com/aquaproj/aqua/v2/pkg/ptr"
+	"github.com/aquaproj/aqua/v2/pkg/versiongetter"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+func newAttestationBundle(t *testing.T, uri string) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{u},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(map[string]any{
+		"verificationMaterial": map[string]any{
+			"certificate": map[string]any{
+				"rawBytes": der,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func Test_getSignerWorkflow(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name  string
+		uri   string
+		exp   string
+		isErr bool
+	}{
+		{
+			name: "reusable workflow",
+			uri:  "https://github.com/suzuki-shunsuke/go-release-workflow/.github/workflows/release.yaml@refs/tags/v1.0.0",
+			exp:  "suzuki-shunsuke/go-release-workflow/.github/workflows/release.yaml",
+		},
+		{
+			name: "not GitHub Actions",
+			uri:  "https://example.com/foo",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			s, err := getSignerWorkflow(newAttestationBundle(t, d.uri))
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if s != d.exp {
+				t.Fatalf("wanted %s, got %s", d.exp, s)
+			}
+		})
+	}
+}
+
+func TestController_getPackageInfo_signature(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	bundle := newAttestationBundle(t, "https://github.com/foo/foo/.github/workflows/release.yaml@refs/tags/v1.0.0")
+	data := []struct {
+		name   string
+		assets []*github.ReleaseAsset
+		exp    *registry.PackageInfo
+	}{
+		{
+			name: "cosign keyless, minisign, slsa, and attestations",
+			assets: []*github.ReleaseAsset{
+				{Name: ptr.String("foo_darwin_arm64.tar.gz"), Digest: ptr.String("sha256:darwin")},
+				{Name: ptr.String("foo_darwin_arm64.tar.gz.minisig")},
+				{Name: ptr.String("foo_darwin_arm64.tar.gz.pem")},
+				{Name: ptr.String("foo_darwin_arm64.tar.gz.sig")},
+				{Name: ptr.String("foo_linux_amd64.tar.gz"), Digest: ptr.String("sha256:linux")},
+				{Name: ptr.String("foo_linux_amd64.tar.gz.minisig")},
+				{Name: ptr.String("foo_linux_amd64.tar.gz.pem")},
+				{Name: ptr.String("foo_linux_amd64.tar.gz.sig")},
+				{Name: ptr.String("multiple.intoto.jsonl")},
+			},
+			exp: &registry.PackageInfo{
+				Type:          "github_release",
+				RepoOwner:     "foo",
+				RepoName:      "foo",
+				Asset:         "foo_{{.OS}}_{{.Arch}}.{{.Format}}",
+				Format:        "tar.gz",
+				SupportedEnvs: registry.SupportedEnvs{"linux/amd64", "darwin/arm64"},
+				SLSAProvenance: &registry.SLSAProvenance{
+					Type:  "github_release",
+					Asset: ptr.String("multiple.intoto.jsonl"),
+				},
+				Cosign: &registry.Cosign{
+					Signature: &registry.DownloadedFile{
+						Type:  "github_release",
+						Asset: ptr.String("{{.Asset}}.sig"),
+					},
+					Certificate: &registry.DownloadedFile{
+						Type:  "github_release",
+						Asset: ptr.String("{{.Asset}}.pem"),
+					},
+					Opts: []string{
+						"--certificate-identity-regexp",
+						`^https://github\.com/[^/]+/[^/]+/\.github/workflows/[^@]+@.+$`,
+						"--certificate-oidc-issuer",
+						"https://token.actions.githubusercontent.com",
+						"--certificate-github-workflow-repository",
+						"foo/foo",
+					},
+				},
+				Minisign: &registry.Minisign{
+					Type:  "github_release",
+					Asset: ptr.String("{{.Asset}}.minisig"),
+				},
+				GitHubArtifactAttestations: &registry.GitHubArtifactAttestations{
+					SignerWorkflow2: "foo/foo/.github/workflows/release.yaml",
+				},
+			},
+		},
+		{
+			name: "cosign bundle",
+			assets: []*github.ReleaseAsset{
+				{Name: ptr.String("foo_linux_amd64.tar.gz")},
+				{Name: ptr.String("foo_linux_amd64.tar.gz.sigstore.json")},
+			},
+			exp: &registry.PackageInfo{
+				Type:          "github_release",
+				RepoOwner:     "foo",
+				RepoName:      "foo",
+				Asset:         "foo_{{.OS}}_{{.Arch}}.{{.Format}}",
+				Format:        "tar.gz",
+				SupportedEnvs: registry.SupportedEnvs{"linux/amd64"},
+				Cosign: &registry.Cosign{
+					Bundle: &registry.DownloadedFile{
+						Type:  "github_release",
+						Asset: ptr.String("{{.Asset}}.sigstore.json"),
+					},
+					Opts: []string{
+						"--certificate-identity-regexp",
+						`^https://github\.com/[^/]+/[^/]+/\.github/workflows/[^@]+@.+$`,
+						"--certificate-oidc-issuer",
+						"https://token.actions.githubusercontent.com",
+						"--certificate-github-workflow-repository",
+						"foo/foo",
+					},
+				},
+			},
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			gh := &github.MockRepositoriesService{
+				Releases: []*github.RepositoryRelease{
+					{
+						TagName: ptr.String("v1.0.0"),
+					},
+				},
+				Assets: d.assets,
+				Attestations: map[string]*github.AttestationsResponse{
+					"sha256:darwin": {
+						Attestations: []*github.Attestation{
+							{
+								Bundle: bundle,
+							},
+						},
+					},
+				},
+			}
+			ctrl := NewController(nil, gh, nil, nil, nil, nil)
+			pkgInfo, _ := ctrl.getPackageInfo(t.Context(), logE, "foo/foo", &config.Param{Limit: 1}, &Config{})
+			if diff := cmp.Diff(d.exp, pkgInfo); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestController_group_attestations(t *testing.T) {
+	t.Parallel()
+	bundle := newAttestationBundle(t, "https://github.com/foo/foo/.github/workflows/release.yaml@refs/tags/v2.0.0")
+	newRelease := func(tag, digest string) *Release {
+		v, prefix, err := versiongetter.GetVersionAndPrefix(tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &Release{
+			Tag:           tag,
+			Version:       v,
+			VersionPrefix: prefix,
+			assets: []*github.ReleaseAsset{
+				{
+					Name:   ptr.String("foo_linux_amd64_" + tag + ".tar.gz"),
+					Digest: ptr.String(digest),
+				},
+			},
+		}
+	}
+	data := []struct {
+		name   string
+		digest string
+		exp    *registry.GitHubArtifactAttestations
+	}{
+		{
+			name:   "latest release",
+			digest: "sha256:new",
+			exp: &registry.GitHubArtifactAttestations{
+				SignerWorkflow2: "foo/foo/.github/workflows/release.yaml",
+			},
+		},
+		{
+			name:   "attestations of old releases aren't checked",
+			digest: "sha256:old",
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			gh := &github.MockRepositoriesService{
+				Attestations: map[string]*github.AttestationsResponse{
+					d.digest: {
+						Attestations: []*github.Attestation{
+							{
+								Bundle: bundle,
+							},
+						},
+					},
+				},
+			}
+			ctrl := NewController(nil, gh, nil, nil, nil, nil)
+			groups := ctrl.group(t.Context(), logE, &registry.PackageInfo{
+				RepoOwner: "foo",
+				RepoName:  "foo",
+			}, "foo/foo", []*Release{
+				newRelease("v1.0.0", "sha256:old"),
+				newRelease("v2.0.0", "sha256:new"),
+			})
+			if len(groups) != 1 {
+				t.Fatalf("the number of groups should be 1: %d", len(groups))
+			}
+			if diff := cmp.Diff(d.exp, groups[0].pkg.Info.GitHubArtifactAttestations); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package genrgst
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/installpackage"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/go-error-with-exit-code/ecerror"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+var (
+	errVersionIsRequiredToVerify = errors.New("the version of the package is unknown, so the package can't be verified")
+	errVerificationFailed        = errors.New("the generated package configuration is broken in some environments")
+	errPackageInstallerIsNil     = errors.New("the package installer isn't set")
+)
+
+type PackageInstaller interface {
+	InstallPackage(ctx context.Context, logE *logrus.Entry, param *installpackage.ParamInstallPackage) error
+}
+
+// NewPackageInstaller returns a PackageInstaller to install packages for the runtime into rootDir.
+type NewPackageInstaller func(rt *runtime.Runtime, rootDir string) (PackageInstaller, error)
+
+// SetNewPackageInstaller sets the function to create package installers used by --verify.
+func (c *Controller) SetNewPackageInstaller(f NewPackageInstaller) {
+	c.newPackageInstaller = f
+}
+
+// verify installs the generated package for every supported environment into a temporary root directory.
+// It checks if the asset is rendered, downloaded, verified with checksum, unarchived, and contains files.
+// The latest version of each range of version_constraint is installed,
+// so that every version_override is verified.
+// If the package depends on libc, Linux is verified with both glibc and musl.
+// The result of each environment is output to the standard error output as a table,
+// because the standard output is used to output the generated configuration.
+func (c *Controller) verify(ctx context.Context, logE *logrus.Entry, pkgInfo *registry.PackageInfo, versions []string) error {
+	if c.newPackageInstaller == nil {
+		return errPackageInstallerIsNil
+	}
+	versions = versionsToVerify(logE, pkgInfo, versions)
+	if len(versions) == 0 {
+		return errVersionIsRequiredToVerify
+	}
+	rootDir, err := afero.TempDir(c.fs, "", "aqua-generate-registry-verify-")
+	if err != nil {
+		return fmt.Errorf("create a temporary root directory: %w", err)
+	}
+	defer func() {
+		if err := c.fs.RemoveAll(rootDir); err != nil {
+			logE.WithError(err).Warn("remove a temporary root directory")
+		}
+	}()
+	rts, err := runtime.GetRuntimesFromEnvs(nil)
+	if err != nil {
+		return fmt.Errorf("get runtimes: %w", err)
+	}
+	if pkgInfo.DependsOnLibc() {
+		rts = runtime.ExpandLibc(rts)
+	}
+	sort.Slice(rts, func(i, j int) bool {
+		return rts[i].LibcEnv() < rts[j].LibcEnv()
+	})
+	results := make([]*verifyResult, 0, len(versions)*len(rts))
+	failed := false
+	for _, version := range versions {
+		for _, rt := range rts {
+			env := rt.LibcEnv()
+			logE := logE.WithFields(logrus.Fields{
+				"verified_env":    env,
+				"package_version": version,
+			})
+			result := &verifyResult{
+				version: version,
+				env:     env,
+				result:  verifyResultPass,
+			}
+			results = append(results, result)
+			supported, err := c.verifyEnv(ctx, logE, pkgInfo, version, rt, rootDir)
+			if err != nil {
+				logerr.WithError(logE, err).Error("the package can't be installed")
+				result.result = verifyResultFail
+				failed = true
+				continue
+			}
+			if !supported {
+				result.result = verifyResultUnsupported
+			}
+		}
+	}
+	if err := c.outputVerifyResults(results); err != nil {
+		return err
+	}
+	if failed {
+		return ecerror.Wrap(errVerificationFailed, 1)
+	}
+	return nil
+}
+
+const (
+	verifyResultPass        = "pass"
+	verifyResultFail        = "fail"
+	verifyResultUnsupported = "unsupported"
+)
+
+type verifyResult struct {
+	version string
+	env     string
+	result  string
+}
+
+func (c *Controller) outputVerifyResults(results []*verifyResult) error {
+	w := tabwriter.NewWriter(c.stderr, 0, 0, 2, ' ', 0) //nolint:mnd
+	fmt.Fprintln(w, "VERSION\tENV\tRESULT")
+	for _, result := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", result.version, result.env, result.result)
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("output the result of the verification: %w", err)
+	}
+	return nil
+}
+
+// versionsToVerify returns the latest version of each range of version_constraint.
+// versions must be sorted in descending order.
+// Versions matching no version_constraint are skipped because they can't be installed.
+func versionsToVerify(logE *logrus.Entry, pkgInfo *registry.PackageInfo, versions []string) []string {
+	var arr []string
+	verified := map[int]struct{}{}
+	for _, version := range versions {
+		if version == "" {
+			continue
+		}
+		idx := pkgInfo.VersionOverrideIndex(logE, version)
+		if idx == len(pkgInfo.VersionOverrides) {
+			continue
+		}
+		if _, ok := verified[idx]; ok {
+			continue
+		}
+		verified[idx] = struct{}{}
+		arr = append(arr, version)
+	}
+	return arr
+}
+
+func (c *Controller) verifyEnv(ctx context.Context, logE *logrus.Entry, pkgInfo *registry.PackageInfo, version string, rt *runtime.Runtime, rootDir string) (bool, error) {
+	pi, err := pkgInfo.Copy().Override(logE, version, rt)
+	if err != nil {
+		return false, fmt.Errorf("evaluate version constraints: %w", err)
+	}
+	supported, err := pi.CheckSupported(rt, rt.Env())
+	if err != nil {
+		return false, fmt.Errorf("check if the package is supported in the environment: %w", err)
+	}
+	if !supported {
+		logE.Debug("the package isn't supported in the environment")
+		return false, nil
+	}
+	installer, err := c.newPackageInstaller(rt, rootDir)
+	if err != nil {
+		return false, fmt.Errorf("create a package installer: %w", err)
+	}
+	if err := installer.InstallPackage(ctx, logE, &installpackage.ParamInstallPackage{
+		Pkg: &config.Package{
+			Package: &aqua.Package{
+				Name:    pi.GetName(),
+				Version: version,
+			},
+			PackageInfo: pi,
+		},
+		Checksums:     checksum.New(),
+		DisablePolicy: true,
+	}); err != nil {
+		return false, fmt.Errorf("install the package: %w", err)
+	}
+	return true, nil
+}
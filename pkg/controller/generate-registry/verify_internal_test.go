@@ -0,0 +1,207 @@
+package genrgst
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/installpackage"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+var errInstall = errors.New("install a package")
+
+type mockPackageInstaller struct {
+	rt       *runtime.Runtime
+	mutex    *sync.Mutex
+	assets   *[]string
+	failures map[string]struct{}
+}
+
+func (m *mockPackageInstaller) InstallPackage(ctx context.Context, logE *logrus.Entry, param *installpackage.ParamInstallPackage) error {
+	asset, err := param.Pkg.RenderAsset(m.rt)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	m.mutex.Lock()
+	*m.assets = append(*m.assets, asset)
+	m.mutex.Unlock()
+	if _, ok := m.failures[m.rt.LibcEnv()]; ok {
+		return errInstall
+	}
+	return nil
+}
+
+func TestController_verify(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	pkgInfo := &registry.PackageInfo{
+		Type:      "github_release",
+		RepoOwner: "foo",
+		RepoName:  "foo",
+		Asset:     "foo_{{.OS}}_{{.Arch}}.{{.Format}}",
+		Format:    "tar.gz",
+		Overrides: []*registry.Override{
+			{
+				GOOS:   "windows",
+				Format: "zip",
+			},
+		},
+		SupportedEnvs: registry.SupportedEnvs{"linux/amd64", "windows"},
+	}
+	voPkgInfo := &registry.PackageInfo{
+		Type:               "github_release",
+		RepoOwner:          "foo",
+		RepoName:           "foo",
+		Asset:              "foo_{{.Version}}_{{.OS}}_{{.Arch}}.tar.gz",
+		VersionConstraints: `semver(">= 1.0.0")`,
+		SupportedEnvs:      registry.SupportedEnvs{"linux/amd64"},
+		VersionOverrides: []*registry.VersionOverride{
+			{
+				VersionConstraints: `semver(">= 0.5.0")`,
+				Asset:              "foo-{{.Version}}-{{.OS}}-{{.Arch}}.tar.gz",
+			},
+			{
+				VersionConstraints: "true",
+				Asset:              "foo-{{.OS}}.tar.gz",
+			},
+		},
+	}
+	libcPkgInfo := &registry.PackageInfo{
+		Type:      "github_release",
+		RepoOwner: "foo",
+		RepoName:  "foo",
+		Asset:     "foo_{{.OS}}_{{.Arch}}.tar.gz",
+		Overrides: []*registry.Override{
+			{
+				GOOS:  "linux",
+				Libc:  "musl",
+				Asset: "foo_{{.OS}}_{{.Arch}}_musl.tar.gz",
+			},
+		},
+		SupportedEnvs: registry.SupportedEnvs{"linux/amd64"},
+	}
+	data := []struct {
+		name     string
+		pkgInfo  *registry.PackageInfo
+		versions []string
+		failures map[string]struct{}
+		assets   []string
+		report   string
+		isErr    bool
+	}{
+		{
+			name:     "normal",
+			versions: []string{"v1.0.0", "v0.9.0"},
+			assets: []string{
+				"foo_linux_amd64.tar.gz",
+				"foo_windows_amd64.zip",
+				"foo_windows_arm64.zip",
+			},
+		},
+		{
+			name:     "failure",
+			versions: []string{"v1.0.0"},
+			failures: map[string]struct{}{
+				"windows/arm64": {},
+			},
+			assets: []string{
+				"foo_linux_amd64.tar.gz",
+				"foo_windows_amd64.zip",
+				"foo_windows_arm64.zip",
+			},
+			report: `VERSION  ENV            RESULT
+v1.0.0   darwin/amd64   unsupported
+v1.0.0   darwin/arm64   unsupported
+v1.0.0   linux/amd64    pass
+v1.0.0   linux/arm64    unsupported
+v1.0.0   windows/amd64  pass
+v1.0.0   windows/arm64  fail
+`,
+			isErr: true,
+		},
+		{
+			name:     "version overrides",
+			pkgInfo:  voPkgInfo,
+			versions: []string{"v1.1.0", "v1.0.0", "v0.9.0", "v0.5.0", "v0.1.0"},
+			assets: []string{
+				"foo_v1.1.0_linux_amd64.tar.gz",
+				"foo-v0.9.0-linux-amd64.tar.gz",
+				"foo-linux.tar.gz",
+			},
+		},
+		{
+			name:     "libc",
+			pkgInfo:  libcPkgInfo,
+			versions: []string{"v1.0.0"},
+			failures: map[string]struct{}{
+				"linux/amd64/musl": {},
+			},
+			assets: []string{
+				"foo_linux_amd64.tar.gz",
+				"foo_linux_amd64_musl.tar.gz",
+			},
+			report: `VERSION  ENV                RESULT
+v1.0.0   darwin/amd64       unsupported
+v1.0.0   darwin/arm64       unsupported
+v1.0.0   linux/amd64/glibc  pass
+v1.0.0   linux/amd64/musl   fail
+v1.0.0   linux/arm64/glibc  unsupported
+v1.0.0   linux/arm64/musl   unsupported
+v1.0.0   windows/amd64      unsupported
+v1.0.0   windows/arm64      unsupported
+`,
+			isErr: true,
+		},
+		{
+			name:  "no version",
+			isErr: true,
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			var assets []string
+			mutex := &sync.Mutex{}
+			stderr := &bytes.Buffer{}
+			ctrl := &Controller{
+				fs:     afero.NewMemMapFs(),
+				stderr: stderr,
+			}
+			ctrl.SetNewPackageInstaller(func(rt *runtime.Runtime, rootDir string) (PackageInstaller, error) {
+				return &mockPackageInstaller{
+					rt:       rt,
+					mutex:    mutex,
+					assets:   &assets,
+					failures: d.failures,
+				}, nil
+			})
+			pi := pkgInfo
+			if d.pkgInfo != nil {
+				pi = d.pkgInfo
+			}
+			if err := ctrl.verify(t.Context(), logE, pi, d.versions); err != nil {
+				if !d.isErr {
+					t.Fatal(err)
+				}
+			} else if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(d.assets, assets); diff != "" {
+				t.Fatal(diff)
+			}
+			if d.report == "" {
+				return
+			}
+			if diff := cmp.Diff(d.report, stderr.String()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -154,7 +154,7 @@ func (c *Controller) getPackageInfoWithVersionOverrides(ctx context.Context, log
 		release.assets = assets
 	}
 
-	versions := c.generatePackage(logE, pkgInfo, pkgName, releases)
+	versions := c.generatePackage(ctx, logE, pkgInfo, pkgName, releases)
 	if len(pkgInfo.VersionOverrides) != 0 {
 		pkgInfo.VersionConstraints = "false"
 	}
@@ -10,8 +10,11 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/config/registry"
 	"github.com/aquaproj/aqua/v2/pkg/controller/generate/output"
 	"github.com/aquaproj/aqua/v2/pkg/fuzzyfinder"
+	"github.com/aquaproj/aqua/v2/pkg/osexec"
+	"github.com/aquaproj/aqua/v2/pkg/versiongetter"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
 )
 
 type Controller struct {
@@ -24,6 +27,12 @@ type Controller struct {
 	fs                afero.Fs
 	outputter         Outputter
 	fuzzyGetter       FuzzyGetter
+	osEnv             osenv.OSEnv
+	executor          CommandExecutor
+}
+
+type CommandExecutor interface {
+	ExecAndGetOutput(cmd *osexec.Cmd) (string, int, error)
 }
 
 type ConfigReader interface {
@@ -31,7 +40,7 @@ type ConfigReader interface {
 }
 
 type FuzzyGetter interface {
-	Get(ctx context.Context, logE *logrus.Entry, pkg *registry.PackageInfo, currentVersion string, useFinder bool, limit int) string
+	Get(ctx context.Context, logE *logrus.Entry, pkg *registry.PackageInfo, currentVersion string, useFinder bool, limit int, rule *versiongetter.UpdateRule) string
 }
 
 type FuzzyFinder interface {
@@ -39,7 +48,7 @@ type FuzzyFinder interface {
 	FindMulti(items []*fuzzyfinder.Item, hasPreview bool) ([]int, error)
 }
 
-func New(configFinder ConfigFinder, configReader ConfigReader, registryInstaller RegistryInstaller, gh RepositoriesService, fs afero.Fs, fuzzyFinder FuzzyFinder, fuzzyGetter FuzzyGetter) *Controller {
+func New(configFinder ConfigFinder, configReader ConfigReader, registryInstaller RegistryInstaller, gh RepositoriesService, fs afero.Fs, fuzzyFinder FuzzyFinder, fuzzyGetter FuzzyGetter, osEnv osenv.OSEnv, executor CommandExecutor) *Controller {
 	return &Controller{
 		stdin:             os.Stdin,
 		configFinder:      configFinder,
@@ -50,9 +59,12 @@ func New(configFinder ConfigFinder, configReader ConfigReader, registryInstaller
 		fuzzyFinder:       fuzzyFinder,
 		outputter:         output.New(os.Stdout, fs),
 		fuzzyGetter:       fuzzyGetter,
+		osEnv:             osEnv,
+		executor:          executor,
 	}
 }
 
 type RegistryInstaller interface {
 	InstallRegistries(ctx context.Context, logE *logrus.Entry, cfg *aqua.Config, cfgFilePath string, checksums *checksum.Checksums) (map[string]*registry.Config, error)
+	InstallRegistry(ctx context.Context, logE *logrus.Entry, regist *aqua.Registry, cfgFilePath string, checksums *checksum.Checksums, pkgNames ...string) (*registry.Config, error)
 }
@@ -2,4 +2,14 @@ package generate
 
 import "errors"
 
-var errUnknownPkg = errors.New("unknown package")
+var (
+	errUnknownPkg      = errors.New("unknown package")
+	errVersionNotFound = errors.New("no version is found in the output of the command")
+
+	errUnsupportedImportedFile    = errors.New("the file isn't supported. Supported files are .tool-versions, mise.toml, .go-version, .terraform-version, .nvmrc, and .node-version")
+	errUnsupportedImportedVersion = errors.New("the version isn't supported. Only exact versions, partial versions such as 1.21, and latest are supported")
+	errImportedVersionNotFound    = errors.New("no version matching the partial version is found")
+	errNoPackageForTool           = errors.New("no package is found for the tool")
+	errMultiplePackagesForTool    = errors.New("multiple packages are found for the tool")
+	errUnsupportedToolBackend     = errors.New("the backend of the tool isn't supported")
+)
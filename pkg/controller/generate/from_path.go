@@ -0,0 +1,290 @@
+package generate
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/fuzzyfinder"
+	"github.com/aquaproj/aqua/v2/pkg/osexec"
+	"github.com/aquaproj/aqua/v2/pkg/osfile"
+	"github.com/aquaproj/aqua/v2/pkg/versiongetter"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// versionCommandTimeout is the timeout of a command executed to get the version of an installed command.
+const versionCommandTimeout = 10 * time.Second
+
+var installedVersionPattern = regexp.MustCompile(`v?\d+\.\d+(?:\.\d+)?(?:-[0-9A-Za-z.-]+)?`)
+
+// pathCommand is a command found in $PATH and packages providing the command.
+type pathCommand struct {
+	name    string
+	exePath string
+	pkgs    []*fuzzyfinder.Package
+}
+
+// listPkgsFromPath lists packages providing commands installed in $PATH without aqua.
+// If param.All is false, users select packages with the fuzzy finder.
+func (c *Controller) listPkgsFromPath(ctx context.Context, logE *logrus.Entry, param *config.Param, cfg *aqua.Config, registryContents map[string]*registry.Config, loader *packageFileLoader) ([]*config.Package, error) {
+	cmds := c.findPathCommands(logE, param, cfg, registryContents)
+	if len(cmds) == 0 {
+		logE.Info("no command installed without aqua is found in $PATH")
+		return nil, nil
+	}
+	if param.All {
+		pkgs := make([]*config.Package, 0, len(cmds))
+		for _, cmd := range cmds {
+			if len(cmd.pkgs) > 1 {
+				names := make([]string, len(cmd.pkgs))
+				for i, pkg := range cmd.pkgs {
+					names[i] = pkg.RegistryName + "," + pkg.PackageInfo.GetName()
+				}
+				logE.WithFields(logrus.Fields{
+					"exe_name": cmd.name,
+					"packages": strings.Join(names, ", "),
+				}).Warn("skip a command because multiple packages provide it. Please run the command without --all to select a package")
+				continue
+			}
+			pkg, err := c.getPathPkg(ctx, logE, param, loader, cmd, cmd.pkgs[0])
+			if err != nil {
+				return nil, err
+			}
+			pkgs = append(pkgs, pkg)
+		}
+		return pkgs, nil
+	}
+
+	var items []*fuzzyfinder.Item
+	var cands []*pathCommand
+	for _, cmd := range cmds {
+		for _, pkg := range cmd.pkgs {
+			items = append(items, &fuzzyfinder.Item{
+				Item:    fmt.Sprintf("%s (%s)", pkg.Item(), cmd.exePath),
+				Preview: fuzzyfinder.PreviewPackage(pkg),
+			})
+			cands = append(cands, &pathCommand{
+				name:    cmd.name,
+				exePath: cmd.exePath,
+				pkgs:    []*fuzzyfinder.Package{pkg},
+			})
+		}
+	}
+	idxes, err := c.fuzzyFinder.FindMulti(items, true)
+	if err != nil {
+		if errors.Is(err, fuzzyfinder.ErrAbort) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find the package: %w", err)
+	}
+	pkgs := make([]*config.Package, len(idxes))
+	for i, idx := range idxes {
+		pkg, err := c.getPathPkg(ctx, logE, param, loader, cands[idx], cands[idx].pkgs[0])
+		if err != nil {
+			return nil, err
+		}
+		pkgs[i] = pkg
+	}
+	return pkgs, nil
+}
+
+// findPathCommands finds commands in $PATH and packages providing them.
+// Commands installed by aqua and packages already added to aqua.yaml are excluded.
+// If the same command is found in multiple directories, the first one is used like shells do.
+func (c *Controller) findPathCommands(logE *logrus.Entry, param *config.Param, cfg *aqua.Config, registryContents map[string]*registry.Config) []*pathCommand {
+	installedPkgs := make(map[string]struct{}, len(cfg.Packages))
+	for _, pkg := range cfg.Packages {
+		installedPkgs[getRegistryName(pkg.Registry)+","+pkg.Name] = struct{}{}
+	}
+	cmdPkgs := map[string][]*fuzzyfinder.Package{}
+	for _, registryName := range slices.Sorted(maps.Keys(registryContents)) {
+		for _, pkgInfo := range registryContents[registryName].PackageInfos {
+			if _, ok := installedPkgs[registryName+","+pkgInfo.GetName()]; ok {
+				continue
+			}
+			pkg := &fuzzyfinder.Package{
+				PackageInfo:  pkgInfo,
+				RegistryName: registryName,
+			}
+			for _, name := range commandNames(pkgInfo) {
+				cmdPkgs[name] = append(cmdPkgs[name], pkg)
+			}
+		}
+	}
+
+	found := map[string]struct{}{}
+	var cmds []*pathCommand
+	for _, dir := range filepath.SplitList(c.osEnv.Getenv("PATH")) {
+		if dir == "" || isUnderDir(dir, param.RootDir) {
+			// Commands in $AQUA_ROOT_DIR are managed by aqua
+			continue
+		}
+		entries, err := afero.ReadDir(c.fs, dir)
+		if err != nil {
+			logerr.WithError(logE, err).WithField("dir", dir).Debug("read a directory in $PATH")
+			continue
+		}
+		for _, entry := range entries {
+			name := strings.TrimSuffix(entry.Name(), ".exe")
+			if entry.IsDir() || (name == entry.Name() && !osfile.IsOwnerExecutable(entry.Mode())) {
+				continue
+			}
+			if _, ok := found[name]; ok {
+				continue
+			}
+			pkgs, ok := cmdPkgs[name]
+			if !ok {
+				continue
+			}
+			found[name] = struct{}{}
+			cmds = append(cmds, &pathCommand{
+				name:    name,
+				exePath: filepath.Join(dir, entry.Name()),
+				pkgs:    sortPathPkgs(name, pkgs),
+			})
+		}
+	}
+	slices.SortFunc(cmds, func(a, b *pathCommand) int {
+		return cmp.Compare(a.name, b.name)
+	})
+	return cmds
+}
+
+// commandNames returns command names of the package.
+// In addition to files, the base names of package aliases are used.
+// If the package has no file such as a package whose package file isn't installed,
+// the base name of the package name is used.
+func commandNames(pkgInfo *registry.PackageInfo) []string {
+	names := []string{}
+	files := pkgInfo.GetFiles()
+	for _, file := range files {
+		if file.Name != "" && !slices.Contains(names, file.Name) {
+			names = append(names, file.Name)
+		}
+	}
+	if len(files) == 0 {
+		if name := pkgInfo.GetName(); name != "" {
+			names = append(names, path.Base(name))
+		}
+	}
+	for _, alias := range pkgInfo.Aliases {
+		if alias.Name == "" {
+			continue
+		}
+		if name := path.Base(alias.Name); !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// sortPathPkgs sorts packages providing the command so that likely packages come first.
+// Packages in the standard registry and packages whose name ends with the command name are preferred.
+func sortPathPkgs(cmdName string, pkgs []*fuzzyfinder.Package) []*fuzzyfinder.Package {
+	score := func(pkg *fuzzyfinder.Package) int {
+		s := 0
+		if pkg.RegistryName == registryStandard {
+			s++
+		}
+		if path.Base(pkg.PackageInfo.GetName()) == cmdName {
+			s += 2
+		}
+		return s
+	}
+	slices.SortStableFunc(pkgs, func(a, b *fuzzyfinder.Package) int {
+		return cmp.Compare(score(b), score(a))
+	})
+	return pkgs
+}
+
+// getPathPkg returns a package entry of the command found in $PATH.
+// If the field version_command of the package is set, the version is guessed from the output of the command.
+// Otherwise, the latest version is used or users select the version with the fuzzy finder (-s).
+func (c *Controller) getPathPkg(ctx context.Context, logE *logrus.Entry, param *config.Param, loader *packageFileLoader, cmd *pathCommand, pkg *fuzzyfinder.Package) (*config.Package, error) {
+	logE = logE.WithFields(logrus.Fields{
+		"exe_path":     cmd.exePath,
+		"package_name": pkg.PackageInfo.GetName(),
+	})
+	if err := loader.load(ctx, logE, pkg); err != nil {
+		return nil, err
+	}
+	p := &fuzzyfinder.Package{
+		PackageInfo:  pkg.PackageInfo,
+		RegistryName: pkg.RegistryName,
+	}
+	if pkg.PackageInfo.VersionCommand != "" && !param.SelectVersion {
+		p.Version = c.getInstalledVersion(ctx, logE, param, cmd.exePath, pkg.PackageInfo)
+	}
+	return c.getOutputtedPkg(ctx, logE, param, p), nil
+}
+
+// getInstalledVersion runs the command to guess the installed version.
+func (c *Controller) getInstalledVersion(ctx context.Context, logE *logrus.Entry, param *config.Param, exePath string, pkgInfo *registry.PackageInfo) string {
+	version, err := c.runVersionCommand(ctx, exePath, pkgInfo.VersionCommand)
+	if err != nil {
+		logerr.WithError(logE, err).Warn("get the installed version")
+		return ""
+	}
+	return c.completeVersionPrefix(ctx, logE, param, pkgInfo, version)
+}
+
+// completeVersionPrefix complements the prefix of the version such as "v" from the latest version.
+func (c *Controller) completeVersionPrefix(ctx context.Context, logE *logrus.Entry, param *config.Param, pkgInfo *registry.PackageInfo, version string) string {
+	latest := c.fuzzyGetter.Get(ctx, logE, pkgInfo, "", false, param.Limit, nil)
+	if latest == "" {
+		return pkgInfo.VersionPrefix + version
+	}
+	v, prefix, err := versiongetter.GetVersionAndPrefix(latest)
+	if err != nil || v == nil {
+		return pkgInfo.VersionPrefix + version
+	}
+	if strings.HasPrefix(strings.TrimPrefix(latest, prefix), "v") {
+		prefix += "v"
+	}
+	return prefix + strings.TrimPrefix(version, "v")
+}
+
+func (c *Controller) runVersionCommand(ctx context.Context, exePath, args string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, versionCommandTimeout)
+	defer cancel()
+	out, _, err := c.executor.ExecAndGetOutput(osexec.Command(ctx, exePath, strings.Fields(args)...))
+	if err != nil {
+		return "", fmt.Errorf("execute a command to get the version: %w", err)
+	}
+	version := installedVersionPattern.FindString(out)
+	if version == "" {
+		return "", errVersionNotFound
+	}
+	return version, nil
+}
+
+func isUnderDir(p, dir string) bool {
+	if dir == "" {
+		return false
+	}
+	rel, err := filepath.Rel(dir, p)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
+
+func getRegistryName(name string) string {
+	if name == "" {
+		return registryStandard
+	}
+	return name
+}
@@ -0,0 +1,139 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/osexec"
+	"github.com/aquaproj/aqua/v2/pkg/testutil"
+	"github.com/aquaproj/aqua/v2/pkg/versiongetter"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
+)
+
+func TestController_listPkgsFromPath(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	registries := map[string]*registry.Config{
+		"standard": {
+			PackageInfos: registry.PackageInfos{
+				{
+					Type:           "github_release",
+					RepoOwner:      "cli",
+					RepoName:       "cli",
+					VersionCommand: "--version",
+					Files: []*registry.File{
+						{
+							Name: "gh",
+						},
+					},
+				},
+				{
+					Type:      "github_release",
+					RepoOwner: "suzuki-shunsuke",
+					RepoName:  "tfcmt",
+				},
+				{
+					Type:      "github_release",
+					RepoOwner: "jqlang",
+					RepoName:  "jq",
+				},
+			},
+		},
+	}
+	data := []struct {
+		name        string
+		files       map[string]string
+		executables []string
+		cfg         *aqua.Config
+		versions    map[string]string
+		output      string
+		exp         []*config.Package
+	}{
+		{
+			name: "normal",
+			files: map[string]string{
+				"/usr/local/bin/gh":          "",
+				"/usr/local/bin/tfcmt":       "",
+				"/usr/local/bin/jq":          "",
+				"/usr/local/bin/README":      "",
+				"/home/foo/.aqua/bin/tfcmt":  "",
+				"/home/foo/.aqua/bin/gh":     "",
+				"/home/foo/workspace/go.mod": "",
+			},
+			executables: []string{
+				"/usr/local/bin/gh",
+				"/usr/local/bin/tfcmt",
+				"/usr/local/bin/jq",
+				"/home/foo/.aqua/bin/tfcmt",
+				"/home/foo/.aqua/bin/gh",
+			},
+			cfg: &aqua.Config{
+				Packages: []*aqua.Package{
+					{
+						Name:     "jqlang/jq",
+						Registry: "standard",
+						Version:  "jq-1.7",
+					},
+				},
+			},
+			versions: map[string]string{
+				"cli/cli":               "v2.60.0",
+				"suzuki-shunsuke/tfcmt": "v4.14.0",
+			},
+			output: "gh version 2.45.0 (2024-03-04)\n",
+			exp: []*config.Package{
+				{
+					Package: &aqua.Package{
+						Name: "cli/cli@v2.45.0",
+					},
+					PackageInfo: registries["standard"].PackageInfos[0],
+				},
+				{
+					Package: &aqua.Package{
+						Name: "suzuki-shunsuke/tfcmt@v4.14.0",
+					},
+					PackageInfo: registries["standard"].PackageInfos[1],
+				},
+			},
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			fs, err := testutil.NewFs(d.files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range d.executables {
+				if err := fs.Chmod(p, 0o755); err != nil { //nolint:mnd
+					t.Fatal(err)
+				}
+			}
+			ctrl := &Controller{
+				fs:          fs,
+				fuzzyGetter: versiongetter.NewMockFuzzyGetter(d.versions),
+				osEnv: osenv.NewMock(map[string]string{
+					"PATH": "/home/foo/.aqua/bin:/usr/local/bin",
+				}),
+				executor: &osexec.Mock{
+					Output: d.output,
+				},
+			}
+			param := &config.Param{
+				RootDir: "/home/foo/.aqua",
+				All:     true,
+			}
+			pkgs, err := ctrl.listPkgsFromPath(t.Context(), logE, param, d.cfg, registries, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(d.exp, pkgs); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
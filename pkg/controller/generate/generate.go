@@ -21,7 +21,7 @@ import (
 // Generate searches packages in registries and outputs the configuration to standard output.
 // If no package is specified, the interactive fuzzy finder is launched.
 // If the package supports, the latest version is gotten by GitHub API.
-func (c *Controller) Generate(ctx context.Context, logE *logrus.Entry, param *config.Param, args ...string) error { //nolint:cyclop
+func (c *Controller) Generate(ctx context.Context, logE *logrus.Entry, param *config.Param, args ...string) error {
 	// Find and read a configuration file (aqua.yaml).
 	// Install registries
 	// List outputted packages
@@ -36,6 +36,17 @@ func (c *Controller) Generate(ctx context.Context, logE *logrus.Entry, param *co
 	//       merge version with package name
 	//       set default value
 	//   Output to Stdout or Update aqua.yaml (-i)
+	return c.generate(ctx, logE, param, func(cfg *aqua.Config, registryContents map[string]*registry.Config, loader *packageFileLoader) ([]*config.Package, error) {
+		return c.listPkgs(ctx, logE, param, cfg, registryContents, loader, args...)
+	})
+}
+
+// pkgLister returns packages outputted by the generate controller.
+// Packages of split registries whose package files aren't installed must be loaded by loader before they are used.
+type pkgLister func(cfg *aqua.Config, registryContents map[string]*registry.Config, loader *packageFileLoader) ([]*config.Package, error)
+
+// generate reads a configuration file, installs registries, and outputs packages returned by listPkgs.
+func (c *Controller) generate(ctx context.Context, logE *logrus.Entry, param *config.Param, listPkgs pkgLister) error { //nolint:cyclop
 	cfgFilePath, err := c.getConfigFile(param)
 	if err != nil {
 		return err
@@ -46,7 +57,7 @@ func (c *Controller) Generate(ctx context.Context, logE *logrus.Entry, param *co
 		return err //nolint:wrapcheck
 	}
 
-	list, err := c.listPkgs(ctx, logE, param, cfg, cfgFilePath, args...)
+	list, err := c.installRegistriesAndListPkgs(ctx, logE, param, cfg, cfgFilePath, listPkgs)
 	if err != nil {
 		return err
 	}
@@ -102,7 +113,7 @@ func (c *Controller) getConfigFile(param *config.Param) (string, error) {
 	return param.GlobalConfigFilePaths[0], nil
 }
 
-func (c *Controller) listPkgs(ctx context.Context, logE *logrus.Entry, param *config.Param, cfg *aqua.Config, cfgFilePath string, args ...string) ([]*config.Package, error) {
+func (c *Controller) installRegistriesAndListPkgs(ctx context.Context, logE *logrus.Entry, param *config.Param, cfg *aqua.Config, cfgFilePath string, listPkgs pkgLister) ([]*config.Package, error) {
 	checksums, updateChecksum, err := checksum.Open(
 		logE, c.fs, cfgFilePath, param.ChecksumEnabled(cfg))
 	if err != nil {
@@ -114,15 +125,22 @@ func (c *Controller) listPkgs(ctx context.Context, logE *logrus.Entry, param *co
 	if err != nil {
 		return nil, err //nolint:wrapcheck
 	}
+	return listPkgs(cfg, registryContents, c.newPackageFileLoader(registryContents, cfg, cfgFilePath, checksums))
+}
+
+func (c *Controller) listPkgs(ctx context.Context, logE *logrus.Entry, param *config.Param, cfg *aqua.Config, registryContents map[string]*registry.Config, loader *packageFileLoader, args ...string) ([]*config.Package, error) {
+	if param.FromPath {
+		return c.listPkgsFromPath(ctx, logE, param, cfg, registryContents, loader)
+	}
 
 	if param.File != "" || len(args) != 0 {
-		return c.listPkgsWithoutFinder(ctx, logE, param, registryContents, args...)
+		return c.listPkgsWithoutFinder(ctx, logE, param, registryContents, loader, args...)
 	}
 
-	return c.listPkgsWithFinder(ctx, logE, param, registryContents)
+	return c.listPkgsWithFinder(ctx, logE, param, registryContents, loader)
 }
 
-func (c *Controller) listPkgsWithFinder(ctx context.Context, logE *logrus.Entry, param *config.Param, registryContents map[string]*registry.Config) ([]*config.Package, error) {
+func (c *Controller) listPkgsWithFinder(ctx context.Context, logE *logrus.Entry, param *config.Param, registryContents map[string]*registry.Config, loader *packageFileLoader) ([]*config.Package, error) {
 	// maps the package and the registry
 	var items []*fuzzyfinder.Item
 	var pkgs []*fuzzyfinder.Package
@@ -150,6 +168,9 @@ func (c *Controller) listPkgsWithFinder(ctx context.Context, logE *logrus.Entry,
 	}
 	arr := make([]*config.Package, len(idxes))
 	for i, idx := range idxes {
+		if err := loader.load(ctx, logE, pkgs[idx]); err != nil {
+			return nil, err
+		}
 		arr[i] = c.getOutputtedPkg(ctx, logE, param, pkgs[idx])
 	}
 
@@ -186,7 +207,7 @@ func getGeneratePkg(s string) string {
 	return s
 }
 
-func (c *Controller) listPkgsWithoutFinder(ctx context.Context, logE *logrus.Entry, param *config.Param, registryContents map[string]*registry.Config, pkgNames ...string) ([]*config.Package, error) {
+func (c *Controller) listPkgsWithoutFinder(ctx context.Context, logE *logrus.Entry, param *config.Param, registryContents map[string]*registry.Config, loader *packageFileLoader, pkgNames ...string) ([]*config.Package, error) {
 	m := map[string]*fuzzyfinder.Package{}
 	c.setPkgMap(logE, registryContents, m)
 
@@ -199,12 +220,15 @@ func (c *Controller) listPkgsWithoutFinder(ctx context.Context, logE *logrus.Ent
 			return nil, logerr.WithFields(errUnknownPkg, logrus.Fields{"package_name": pkgName}) //nolint:wrapcheck
 		}
 		findingPkg.Version = version
+		if err := loader.load(ctx, logE, findingPkg); err != nil {
+			return nil, err
+		}
 		outputPkg := c.getOutputtedPkg(ctx, logE, param, findingPkg)
 		outputPkgs = append(outputPkgs, outputPkg)
 	}
 
 	if param.File != "" {
-		pkgs, err := c.readGeneratedPkgsFromFile(ctx, logE, param, outputPkgs, m)
+		pkgs, err := c.readGeneratedPkgsFromFile(ctx, logE, param, outputPkgs, m, loader)
 		if err != nil {
 			return nil, err
 		}
@@ -230,7 +254,7 @@ func (c *Controller) getOutputtedPkg(ctx context.Context, logE *logrus.Entry, pa
 		outputPkg.Package.Registry = ""
 	}
 	if outputPkg.Package.Version == "" {
-		version := c.fuzzyGetter.Get(ctx, logE, pkg.PackageInfo, "", param.SelectVersion, param.Limit)
+		version := c.fuzzyGetter.Get(ctx, logE, pkg.PackageInfo, "", param.SelectVersion, param.Limit, nil)
 		if version == "" {
 			outputPkg.Package.Version = "[SET PACKAGE VERSION]"
 			return outputPkg
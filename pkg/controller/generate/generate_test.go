@@ -14,12 +14,14 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/github"
 	registry "github.com/aquaproj/aqua/v2/pkg/install-registry"
 	"github.com/aquaproj/aqua/v2/pkg/installpackage"
+	"github.com/aquaproj/aqua/v2/pkg/osexec"
 	"github.com/aquaproj/aqua/v2/pkg/ptr"
 	"github.com/aquaproj/aqua/v2/pkg/runtime"
 	"github.com/aquaproj/aqua/v2/pkg/slsa"
 	"github.com/aquaproj/aqua/v2/pkg/testutil"
 	"github.com/aquaproj/aqua/v2/pkg/versiongetter"
 	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
 )
 
 func Test_controller_Generate(t *testing.T) { //nolint:funlen,maintidx
@@ -374,6 +376,119 @@ packages:
 				},
 			},
 		},
+		{
+			name: "split registry",
+			rt: &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+			},
+			param: &config.Param{
+				PWD:            "/home/foo/workspace",
+				ConfigFilePath: "aqua.yaml",
+				RootDir:        "/home/foo/.local/share/aquaproj-aqua",
+				MaxParallelism: 5,
+			},
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+`,
+				"/home/foo/workspace/registry.yaml": `package_files:
+- name: aquaproj/aqua-installer
+  aliases:
+  - name: aquaproj/installer
+`,
+				"/home/foo/workspace/pkgs/aquaproj/aqua-installer/registry.yaml": `packages:
+- type: github_content
+  repo_owner: aquaproj
+  repo_name: aqua-installer
+  path: aqua-installer
+`,
+			},
+			args: []string{
+				"aquaproj/installer",
+			},
+			releases: []*github.RepositoryRelease{
+				{
+					TagName: ptr.String("v1.0.0"),
+				},
+			},
+		},
+		{
+			name: "split registry with fuzzy finder",
+			rt: &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+			},
+			param: &config.Param{
+				PWD:            "/home/foo/workspace",
+				ConfigFilePath: "aqua.yaml",
+				RootDir:        "/home/foo/.local/share/aquaproj-aqua",
+				MaxParallelism: 5,
+			},
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+`,
+				"/home/foo/workspace/registry.yaml": `package_files:
+- name: aquaproj/aqua-installer
+  aliases:
+  - name: aquaproj/installer
+`,
+				"/home/foo/workspace/pkgs/aquaproj/aqua-installer/registry.yaml": `packages:
+- type: github_content
+  repo_owner: aquaproj
+  repo_name: aqua-installer
+  path: aqua-installer
+`,
+			},
+			idxs: []int{0},
+			releases: []*github.RepositoryRelease{
+				{
+					TagName: ptr.String("v1.0.0"),
+				},
+			},
+		},
+		{
+			name: "package file of split registry isn't found",
+			rt: &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+			},
+			param: &config.Param{
+				PWD:            "/home/foo/workspace",
+				ConfigFilePath: "aqua.yaml",
+				RootDir:        "/home/foo/.local/share/aquaproj-aqua",
+				MaxParallelism: 5,
+			},
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+`,
+				"/home/foo/workspace/registry.yaml": `package_files:
+- name: aquaproj/aqua-installer
+  aliases:
+  - name: aquaproj/installer
+`,
+			},
+			args: []string{
+				"aquaproj/installer",
+			},
+			releases: []*github.RepositoryRelease{
+				{
+					TagName: ptr.String("v1.0.0"),
+				},
+			},
+			isErr: true,
+		},
 	}
 	logE := logrus.NewEntry(logrus.New())
 	for _, d := range data {
@@ -399,7 +514,7 @@ packages:
 			registryInstaller := registry.New(d.param, downloader, fs, d.rt, &cosign.MockVerifier{}, &slsa.MockVerifier{})
 			configReader := reader.New(fs, d.param)
 			fuzzyFinder := fuzzyfinder.NewMock(d.idxs, d.fuzzyFinderErr)
-			ctrl := generate.New(configFinder, configReader, registryInstaller, gh, fs, fuzzyFinder, versiongetter.NewMockFuzzyGetter(map[string]string{}))
+			ctrl := generate.New(configFinder, configReader, registryInstaller, gh, fs, fuzzyFinder, versiongetter.NewMockFuzzyGetter(map[string]string{}), osenv.NewMock(d.env), &osexec.Mock{})
 			if err := ctrl.Generate(ctx, logE, d.param, d.args...); err != nil {
 				if d.isErr {
 					return
@@ -0,0 +1,447 @@
+package generate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/fuzzyfinder"
+	"github.com/aquaproj/aqua/v2/pkg/versiongetter"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// importedFileNames are files searched by import-config if no file is specified.
+var importedFileNames = []string{ //nolint:gochecknoglobals
+	".tool-versions",
+	"mise.toml",
+	".mise.toml",
+	".go-version",
+	".terraform-version",
+	".nvmrc",
+	".node-version",
+}
+
+// singleVersionFiles maps files having only a version to the tool name.
+var singleVersionFiles = map[string]string{ //nolint:gochecknoglobals
+	".go-version":        "go",
+	".terraform-version": "terraform",
+	".nvmrc":             "node",
+	".node-version":      "node",
+}
+
+// toolNameAliases maps asdf plugin names to command names.
+var toolNameAliases = map[string]string{ //nolint:gochecknoglobals
+	"golang": "go",
+	"nodejs": "node",
+}
+
+var (
+	fullVersionPattern    = regexp.MustCompile(`^v?\d+\.\d+\.\d+`)
+	partialVersionPattern = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?$`)
+	miseTablePattern      = regexp.MustCompile(`^\[\s*([^\]]*?)\s*\]$`)
+	miseToolPattern       = regexp.MustCompile(`^("[^"]+"|'[^']+'|[A-Za-z0-9_.:/@-]+)\s*=\s*(.+)$`)
+	tomlStringPattern     = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"|'([^']*)'`)
+	miseInlineVersion     = regexp.MustCompile(`(?:^|[{,])\s*version\s*=\s*("(?:[^"\\]|\\.)*"|'[^']*')`)
+)
+
+// importedTool is a tool and its version read from a configuration file of another version manager.
+type importedTool struct {
+	file    string
+	name    string
+	version string
+}
+
+// ImportConfig converts configuration files of other version managers such as asdf and mise to aqua's packages.
+// If no file is specified, files in the current directory are imported.
+// Tools which can't be mapped to packages are reported.
+func (c *Controller) ImportConfig(ctx context.Context, logE *logrus.Entry, param *config.Param, args ...string) error {
+	tools, err := c.readImportedFiles(logE, param, args...)
+	if err != nil {
+		return err
+	}
+	if len(tools) == 0 {
+		logE.Info("no tool to import is found")
+		return nil
+	}
+	return c.generate(ctx, logE, param, func(_ *aqua.Config, registryContents map[string]*registry.Config, loader *packageFileLoader) ([]*config.Package, error) {
+		return c.listImportedPkgs(ctx, logE, param, registryContents, loader, tools), nil
+	})
+}
+
+func (c *Controller) readImportedFiles(logE *logrus.Entry, param *config.Param, args ...string) ([]*importedTool, error) {
+	files := args
+	if len(files) == 0 {
+		for _, name := range importedFileNames {
+			p := filepath.Join(param.PWD, name)
+			if f, err := afero.Exists(c.fs, p); err == nil && f {
+				files = append(files, p)
+			}
+		}
+	}
+	var tools []*importedTool
+	for _, file := range files {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(param.PWD, file)
+		}
+		b, err := afero.ReadFile(c.fs, file)
+		if err != nil {
+			return nil, fmt.Errorf("read a file: %w", logerr.WithFields(err, logrus.Fields{
+				"file": file,
+			}))
+		}
+		ts, err := parseImportedFile(file, b)
+		if err != nil {
+			return nil, fmt.Errorf("parse a file: %w", logerr.WithFields(err, logrus.Fields{
+				"file": file,
+			}))
+		}
+		logE.WithFields(logrus.Fields{
+			"file":         file,
+			"num_of_tools": len(ts),
+		}).Debug("read a file")
+		tools = append(tools, ts...)
+	}
+	return tools, nil
+}
+
+// parseImportedFile parses a file based on the file name.
+func parseImportedFile(file string, b []byte) ([]*importedTool, error) {
+	name := filepath.Base(file)
+	if tool, ok := singleVersionFiles[name]; ok {
+		version := parseSingleVersionFile(b)
+		if version == "" {
+			return nil, nil
+		}
+		return []*importedTool{
+			{
+				file:    file,
+				name:    tool,
+				version: version,
+			},
+		}, nil
+	}
+	switch {
+	case name == ".tool-versions":
+		return parseToolVersions(file, b), nil
+	case strings.HasSuffix(name, ".toml"):
+		return parseMiseToml(file, b), nil
+	default:
+		return nil, errUnsupportedImportedFile
+	}
+}
+
+// parseSingleVersionFile returns the first non-empty line which isn't a comment.
+func parseSingleVersionFile(b []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// parseToolVersions parses asdf's .tool-versions.
+// If multiple versions are specified, the first one is used like asdf does.
+func parseToolVersions(file string, b []byte) []*importedTool {
+	var tools []*importedTool
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		tool := &importedTool{
+			file: file,
+			name: fields[0],
+		}
+		if len(fields) > 1 {
+			tool.version = fields[1]
+		}
+		tools = append(tools, tool)
+	}
+	return tools
+}
+
+// parseMiseToml parses the table [tools] of mise.toml.
+// Only the subset of TOML used by mise's tools is supported.
+// A value is either a version string, an array of versions, or an inline table having the key version.
+func parseMiseToml(file string, b []byte) []*importedTool {
+	var tools []*importedTool
+	inTools := false
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if m := miseTablePattern.FindStringSubmatch(line); m != nil {
+			inTools = m[1] == "tools"
+			continue
+		}
+		if !inTools {
+			continue
+		}
+		m := miseToolPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		tools = append(tools, &importedTool{
+			file:    file,
+			name:    unquoteTOMLString(m[1]),
+			version: parseMiseToolVersion(m[2]),
+		})
+	}
+	return tools
+}
+
+func parseMiseToolVersion(value string) string {
+	if strings.HasPrefix(value, "{") {
+		m := miseInlineVersion.FindStringSubmatch(value)
+		if m == nil {
+			return ""
+		}
+		return unquoteTOMLString(m[1])
+	}
+	// A string or the first element of an array
+	m := tomlStringPattern.FindString(value)
+	if m == "" {
+		return ""
+	}
+	return unquoteTOMLString(m)
+}
+
+func unquoteTOMLString(s string) string {
+	if strings.HasPrefix(s, "'") {
+		return strings.Trim(s, "'")
+	}
+	if v, err := strconv.Unquote(s); err == nil {
+		return v
+	}
+	return s
+}
+
+// listImportedPkgs maps imported tools to packages.
+// Tools which can't be mapped are reported as warnings.
+func (c *Controller) listImportedPkgs(ctx context.Context, logE *logrus.Entry, param *config.Param, registryContents map[string]*registry.Config, loader *packageFileLoader, tools []*importedTool) []*config.Package {
+	pkgs := make([]*config.Package, 0, len(tools))
+	unmapped := 0
+	for _, tool := range tools {
+		logE := logE.WithFields(logrus.Fields{
+			"file":         tool.file,
+			"tool":         tool.name,
+			"tool_version": tool.version,
+		})
+		pkg, err := c.getImportedPkg(ctx, logE, param, registryContents, loader, tool)
+		if err != nil {
+			unmapped++
+			logerr.WithError(logE, err).Warn("a tool can't be imported")
+			continue
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	if unmapped > 0 {
+		logE.WithFields(logrus.Fields{
+			"num_of_imported_tools":   len(pkgs),
+			"num_of_unimported_tools": unmapped,
+		}).Warn("some tools can't be imported. Please add them to the configuration file manually")
+	}
+	return pkgs
+}
+
+func (c *Controller) getImportedPkg(ctx context.Context, logE *logrus.Entry, param *config.Param, registryContents map[string]*registry.Config, loader *packageFileLoader, tool *importedTool) (*config.Package, error) {
+	pkg, err := findImportedPkg(registryContents, tool.name)
+	if err != nil {
+		return nil, err
+	}
+	logE = logE.WithField("package_name", pkg.PackageInfo.GetName())
+	if err := loader.load(ctx, logE, pkg); err != nil {
+		return nil, err
+	}
+	version, err := c.getImportedVersion(ctx, logE, param, pkg.PackageInfo, tool.version)
+	if err != nil {
+		return nil, logerr.WithFields(err, logrus.Fields{ //nolint:wrapcheck
+			"package_name": pkg.PackageInfo.GetName(),
+		})
+	}
+	return c.getOutputtedPkg(ctx, logE, param, &fuzzyfinder.Package{
+		PackageInfo:  pkg.PackageInfo,
+		RegistryName: pkg.RegistryName,
+		Version:      version,
+	}), nil
+}
+
+// getImportedVersion converts the version of another version manager to aqua's version.
+// An empty string is returned for "latest" so that the latest version is used.
+// For a partial version such as "20" and "1.21", the latest version matching it is used.
+func (c *Controller) getImportedVersion(ctx context.Context, logE *logrus.Entry, param *config.Param, pkgInfo *registry.PackageInfo, version string) (string, error) {
+	if version == "" || version == "latest" {
+		return "", nil
+	}
+	if fullVersionPattern.MatchString(version) {
+		return c.completeVersionPrefix(ctx, logE, param, pkgInfo, version), nil
+	}
+	m := partialVersionPattern.FindStringSubmatch(version)
+	if m == nil {
+		return "", errUnsupportedImportedVersion
+	}
+	rule, err := partialVersionRule(m[1], m[2])
+	if err != nil {
+		return "", err
+	}
+	v := c.fuzzyGetter.Get(ctx, logE, pkgInfo, "", false, param.Limit, rule)
+	if v == "" {
+		return "", errImportedVersionNotFound
+	}
+	return v, nil
+}
+
+// partialVersionRule returns a rule allowing only versions matching a partial version.
+func partialVersionRule(major, minor string) (*versiongetter.UpdateRule, error) {
+	constraint := fmt.Sprintf(">= %s, < %s", major, increment(major))
+	if minor != "" {
+		constraint = fmt.Sprintf(">= %s.%s, < %s.%s", major, minor, major, increment(minor))
+	}
+	rule, err := versiongetter.NewUpdateRule("", nil, fmt.Sprintf("semver(%q)", constraint), "")
+	if err != nil {
+		return nil, fmt.Errorf("create a rule for a partial version: %w", err)
+	}
+	return rule, nil
+}
+
+func increment(s string) string {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return s
+	}
+	return strconv.Itoa(n + 1)
+}
+
+// Ranks of matches between a tool name and a package.
+// A smaller rank is preferred.
+const (
+	rankPackageName = iota
+	rankPackageBaseName
+	rankCommandName
+	rankSearchWord
+	rankUnmatched
+)
+
+// findImportedPkg finds a package by a tool name of other version managers.
+// mise's backends such as aqua:, ubi:, and github: are supported.
+// The tool name is compared with package names, aliases, command names, and search_words in this order.
+// If multiple packages match with the same priority, packages in the standard registry are preferred.
+func findImportedPkg(registryContents map[string]*registry.Config, toolName string) (*fuzzyfinder.Package, error) {
+	name, err := normalizeToolName(toolName)
+	if err != nil {
+		return nil, err
+	}
+	best := rankUnmatched
+	var cands []*fuzzyfinder.Package
+	for _, registryName := range slices.Sorted(maps.Keys(registryContents)) {
+		for _, pkgInfo := range registryContents[registryName].PackageInfos {
+			rank := matchToolName(pkgInfo, name)
+			if rank > best || rank == rankUnmatched {
+				continue
+			}
+			pkg := &fuzzyfinder.Package{
+				PackageInfo:  pkgInfo,
+				RegistryName: registryName,
+			}
+			if rank < best {
+				best = rank
+				cands = nil
+			}
+			cands = append(cands, pkg)
+		}
+	}
+	if len(cands) == 0 {
+		return nil, errNoPackageForTool
+	}
+	if len(cands) > 1 {
+		var standards []*fuzzyfinder.Package
+		for _, pkg := range cands {
+			if pkg.RegistryName == registryStandard {
+				standards = append(standards, pkg)
+			}
+		}
+		if len(standards) > 0 {
+			cands = standards
+		}
+	}
+	if len(cands) > 1 {
+		names := make([]string, len(cands))
+		for i, pkg := range cands {
+			names[i] = pkg.RegistryName + "," + pkg.PackageInfo.GetName()
+		}
+		return nil, logerr.WithFields(errMultiplePackagesForTool, logrus.Fields{ //nolint:wrapcheck
+			"packages": strings.Join(names, ", "),
+		})
+	}
+	return cands[0], nil
+}
+
+// normalizeToolName removes mise's backend and converts asdf plugin names.
+func normalizeToolName(toolName string) (string, error) {
+	name := toolName
+	if backend, s, ok := strings.Cut(toolName, ":"); ok {
+		switch backend {
+		case "aqua", "ubi", "github", "core", "asdf":
+			name = s
+		default:
+			return "", logerr.WithFields(errUnsupportedToolBackend, logrus.Fields{ //nolint:wrapcheck
+				"backend": backend,
+			})
+		}
+	}
+	if a, ok := toolNameAliases[name]; ok {
+		return a, nil
+	}
+	return name, nil
+}
+
+func matchToolName(pkgInfo *registry.PackageInfo, name string) int {
+	names := []string{pkgInfo.GetName()}
+	for _, alias := range pkgInfo.Aliases {
+		if alias.Name != "" {
+			names = append(names, alias.Name)
+		}
+	}
+	if slices.Contains(names, name) {
+		return rankPackageName
+	}
+	if strings.Contains(name, "/") {
+		return rankUnmatched
+	}
+	for _, n := range names {
+		if path.Base(n) == name {
+			return rankPackageBaseName
+		}
+	}
+	for _, file := range pkgInfo.GetFiles() {
+		if file.Name == name {
+			return rankCommandName
+		}
+	}
+	if slices.ContainsFunc(pkgInfo.SearchWords, func(w string) bool {
+		return strings.EqualFold(w, name)
+	}) {
+		return rankSearchWord
+	}
+	return rankUnmatched
+}
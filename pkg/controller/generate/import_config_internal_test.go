@@ -0,0 +1,217 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/testutil"
+	"github.com/aquaproj/aqua/v2/pkg/versiongetter"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+func Test_parseImportedFile(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name  string
+		file  string
+		input string
+		isErr bool
+		exp   []*importedTool
+	}{
+		{
+			name: ".tool-versions",
+			file: "/home/foo/.tool-versions",
+			input: `# comment
+golang 1.22.1
+nodejs 20.11.0 18.19.0 # the first version is used
+
+terraform ref:v1.7.0
+`,
+			exp: []*importedTool{
+				{file: "/home/foo/.tool-versions", name: "golang", version: "1.22.1"},
+				{file: "/home/foo/.tool-versions", name: "nodejs", version: "20.11.0"},
+				{file: "/home/foo/.tool-versions", name: "terraform", version: "ref:v1.7.0"},
+			},
+		},
+		{
+			name: "mise.toml",
+			file: "/home/foo/mise.toml",
+			input: `[env]
+NODE_ENV = "production"
+
+[tools]
+go = "1.22"
+node = ["20", "18"] # the first version is used
+"aqua:cli/cli" = 'v2.45.0'
+terraform = { version = "1.7.5", os = ["linux"] }
+
+[settings]
+experimental = true
+`,
+			exp: []*importedTool{
+				{file: "/home/foo/mise.toml", name: "go", version: "1.22"},
+				{file: "/home/foo/mise.toml", name: "node", version: "20"},
+				{file: "/home/foo/mise.toml", name: "aqua:cli/cli", version: "v2.45.0"},
+				{file: "/home/foo/mise.toml", name: "terraform", version: "1.7.5"},
+			},
+		},
+		{
+			name:  ".nvmrc",
+			file:  "/home/foo/.nvmrc",
+			input: "v20.11.0\n",
+			exp: []*importedTool{
+				{file: "/home/foo/.nvmrc", name: "node", version: "v20.11.0"},
+			},
+		},
+		{
+			name:  "unsupported file",
+			file:  "/home/foo/.python-version",
+			input: "3.12.0\n",
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			tools, err := parseImportedFile(d.file, []byte(d.input))
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(d.exp, tools, cmp.AllowUnexported(importedTool{})); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestController_ImportConfig(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	registries := map[string]*registry.Config{
+		"standard": {
+			PackageInfos: registry.PackageInfos{
+				{
+					Type:      "http",
+					RepoOwner: "golang",
+					RepoName:  "go",
+				},
+				{
+					Type:      "github_release",
+					RepoOwner: "hashicorp",
+					RepoName:  "terraform",
+				},
+				{
+					Type:      "github_release",
+					RepoOwner: "tfutils",
+					RepoName:  "tfenv",
+					Files: []*registry.File{
+						{
+							Name: "tfenv",
+						},
+						{
+							Name: "terraform",
+						},
+					},
+				},
+				{
+					Type:      "github_release",
+					RepoOwner: "cli",
+					RepoName:  "cli",
+					Files: []*registry.File{
+						{
+							Name: "gh",
+						},
+					},
+				},
+				{
+					Type:        "github_release",
+					RepoOwner:   "BurntSushi",
+					RepoName:    "ripgrep",
+					SearchWords: []string{"rg"},
+					Files: []*registry.File{
+						{
+							Name: "rg",
+						},
+					},
+				},
+				{
+					Name:        "nodejs/node",
+					Type:        "http",
+					SearchWords: []string{"nodejs"},
+				},
+			},
+		},
+	}
+	files := map[string]string{
+		"/home/foo/workspace/.tool-versions": `golang 1.22.1
+terraform 1.7
+github-cli 2.45.0
+nodejs lts/*
+ripgrep latest
+`,
+		"/home/foo/workspace/mise.toml": `[tools]
+"aqua:cli/cli" = "2.45.0"
+"npm:prettier" = "3.2.5"
+`,
+	}
+	versions := map[string]string{
+		"golang/go":           "go1.23.0",
+		"hashicorp/terraform": "v1.7.5",
+		"cli/cli":             "v2.60.0",
+		"BurntSushi/ripgrep":  "14.1.0",
+	}
+	exp := []*config.Package{
+		{
+			Package: &aqua.Package{
+				Name: "golang/go@go1.22.1",
+			},
+			PackageInfo: registries["standard"].PackageInfos[0],
+		},
+		{
+			Package: &aqua.Package{
+				Name: "hashicorp/terraform@v1.7.5",
+			},
+			PackageInfo: registries["standard"].PackageInfos[1],
+		},
+		{
+			Package: &aqua.Package{
+				Name: "BurntSushi/ripgrep@14.1.0",
+			},
+			PackageInfo: registries["standard"].PackageInfos[4],
+		},
+		{
+			Package: &aqua.Package{
+				Name: "cli/cli@v2.45.0",
+			},
+			PackageInfo: registries["standard"].PackageInfos[3],
+		},
+	}
+	fs, err := testutil.NewFs(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctrl := &Controller{
+		fs:          fs,
+		fuzzyGetter: versiongetter.NewMockFuzzyGetter(versions),
+	}
+	param := &config.Param{
+		PWD: "/home/foo/workspace",
+	}
+	logE := logrus.NewEntry(logrus.New())
+	tools, err := ctrl.readImportedFiles(logE, param)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkgs := ctrl.listImportedPkgs(t.Context(), logE, param, registries, nil, tools)
+	if diff := cmp.Diff(exp, pkgs); diff != "" {
+		t.Fatal(diff)
+	}
+}
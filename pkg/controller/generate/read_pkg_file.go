@@ -13,7 +13,7 @@ import (
 	"github.com/suzuki-shunsuke/logrus-error/logerr"
 )
 
-func (c *Controller) readGeneratedPkgsFromFile(ctx context.Context, logE *logrus.Entry, param *config.Param, outputPkgs []*config.Package, m map[string]*fuzzyfinder.Package) ([]*config.Package, error) {
+func (c *Controller) readGeneratedPkgsFromFile(ctx context.Context, logE *logrus.Entry, param *config.Param, outputPkgs []*config.Package, m map[string]*fuzzyfinder.Package, loader *packageFileLoader) ([]*config.Package, error) {
 	var file io.Reader
 	if param.File == "-" {
 		file = c.stdin
@@ -34,6 +34,9 @@ func (c *Controller) readGeneratedPkgsFromFile(ctx context.Context, logE *logrus
 			return nil, logerr.WithFields(errUnknownPkg, logrus.Fields{"package_name": txt}) //nolint:wrapcheck
 		}
 		findingPkg.Version = version
+		if err := loader.load(ctx, logE, findingPkg); err != nil {
+			return nil, err
+		}
 		outputPkg := c.getOutputtedPkg(ctx, logE, param, findingPkg)
 		outputPkgs = append(outputPkgs, outputPkg)
 	}
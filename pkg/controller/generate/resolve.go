@@ -0,0 +1,59 @@
+package generate
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/fuzzyfinder"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+var errVersionIsNotFound = errors.New("the latest version of the package isn't found. Please specify the version")
+
+// Resolve finds a package from registries of the configuration.
+// pkgName is formatted as `[<registry name>,]<package name or alias>[@<version>]`.
+// If the version isn't specified, the latest version is gotten.
+// Unlike Generate, Resolve outputs nothing and doesn't update configuration files.
+func (c *Controller) Resolve(ctx context.Context, logE *logrus.Entry, param *config.Param, cfg *aqua.Config, cfgFilePath string, checksums *checksum.Checksums, pkgName string) (*config.Package, error) {
+	registryContents, err := c.registryInstaller.InstallRegistries(ctx, logE, cfg, cfgFilePath, checksums)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	loader := c.newPackageFileLoader(registryContents, cfg, cfgFilePath, checksums)
+
+	m := map[string]*fuzzyfinder.Package{}
+	c.setPkgMap(logE, registryContents, m)
+
+	key, version, _ := strings.Cut(getGeneratePkg(pkgName), "@")
+	pkg, ok := m[key]
+	if !ok {
+		return nil, logerr.WithFields(errUnknownPkg, logrus.Fields{ //nolint:wrapcheck
+			"package_name": pkgName,
+		})
+	}
+	if err := loader.load(ctx, logE, pkg); err != nil {
+		return nil, err
+	}
+	if version == "" {
+		version = c.fuzzyGetter.Get(ctx, logE, pkg.PackageInfo, "", false, param.Limit, nil)
+		if version == "" {
+			return nil, logerr.WithFields(errVersionIsNotFound, logrus.Fields{ //nolint:wrapcheck
+				"package_name": pkgName,
+			})
+		}
+	}
+	return &config.Package{
+		Package: &aqua.Package{
+			Name:     pkg.PackageInfo.GetName(),
+			Registry: pkg.RegistryName,
+			Version:  version,
+		},
+		PackageInfo: pkg.PackageInfo,
+	}, nil
+}
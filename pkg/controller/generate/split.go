@@ -0,0 +1,91 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/fuzzyfinder"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// packageFileLoader installs package files of split registries lazily.
+// Split registries include only package files of packages in the configuration file,
+// so other packages are listed by names and aliases in the index file
+// and their package files are installed when they are chosen.
+type packageFileLoader struct {
+	installer   RegistryInstaller
+	cfg         *aqua.Config
+	cfgFilePath string
+	checksums   *checksum.Checksums
+	unloaded    map[*registry.PackageInfo]struct{}
+}
+
+// newPackageFileLoader adds packages whose package files aren't installed to registryContents.
+// Registry contents are copied so that contents returned by the registry installer aren't changed.
+// Packages of extending registries are patches, so they aren't added.
+func (c *Controller) newPackageFileLoader(registryContents map[string]*registry.Config, cfg *aqua.Config, cfgFilePath string, checksums *checksum.Checksums) *packageFileLoader {
+	loader := &packageFileLoader{
+		installer:   c.registryInstaller,
+		cfg:         cfg,
+		cfgFilePath: cfgFilePath,
+		checksums:   checksums,
+		unloaded:    map[*registry.PackageInfo]struct{}{},
+	}
+	for registryName, registryContent := range registryContents {
+		if !registryContent.IsSplit() || registryContent.Extends != "" {
+			continue
+		}
+		pkgInfos := registryContent.UnloadedPackageInfos()
+		if len(pkgInfos) == 0 {
+			continue
+		}
+		for _, pkgInfo := range pkgInfos {
+			loader.unloaded[pkgInfo] = struct{}{}
+		}
+		rc := *registryContent
+		rc.PackageInfos = append(slices.Clone(registryContent.PackageInfos), pkgInfos...)
+		registryContents[registryName] = &rc
+	}
+	return loader
+}
+
+// load installs the package file if the package file of the package isn't installed,
+// and replaces the package information with the one in the package file.
+func (l *packageFileLoader) load(ctx context.Context, logE *logrus.Entry, pkg *fuzzyfinder.Package) error {
+	if l == nil {
+		return nil
+	}
+	if _, ok := l.unloaded[pkg.PackageInfo]; !ok {
+		return nil
+	}
+	pkgName := pkg.PackageInfo.GetName()
+	rg, ok := l.cfg.Registries[pkg.RegistryName]
+	if !ok {
+		return logerr.WithFields(errUnknownPkg, logrus.Fields{ //nolint:wrapcheck
+			"registry_name": pkg.RegistryName,
+			"package_name":  pkgName,
+		})
+	}
+	logE.WithField("package_name", pkgName).Debug("install the package file of the split registry")
+	registryContent, err := l.installer.InstallRegistry(ctx, logE, rg, l.cfgFilePath, l.checksums, pkgName)
+	if err != nil {
+		return fmt.Errorf("install the package file of the split registry: %w", logerr.WithFields(err, logrus.Fields{
+			"registry_name": pkg.RegistryName,
+			"package_name":  pkgName,
+		}))
+	}
+	pkgInfo := registryContent.Package(logE, pkgName)
+	if pkgInfo == nil {
+		return logerr.WithFields(errUnknownPkg, logrus.Fields{ //nolint:wrapcheck
+			"registry_name": pkg.RegistryName,
+			"package_name":  pkgName,
+		})
+	}
+	pkg.PackageInfo = pkgInfo
+	return nil
+}
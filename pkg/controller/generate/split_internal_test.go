@@ -0,0 +1,97 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	rgst "github.com/aquaproj/aqua/v2/pkg/install-registry"
+	"github.com/aquaproj/aqua/v2/pkg/osexec"
+	"github.com/aquaproj/aqua/v2/pkg/testutil"
+	"github.com/aquaproj/aqua/v2/pkg/versiongetter"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/go-osenv/osenv"
+)
+
+func TestController_listPkgsFromPath_splitRegistry(t *testing.T) {
+	t.Parallel()
+	index := &registry.Config{
+		PackageFiles: []*registry.PackageFile{
+			{
+				Name: "suzuki-shunsuke/tfcmt",
+			},
+		},
+	}
+	pkgFile := &registry.Config{
+		PackageInfos: registry.PackageInfos{
+			{
+				Type:      "github_release",
+				RepoOwner: "suzuki-shunsuke",
+				RepoName:  "tfcmt",
+			},
+		},
+		PackageFiles: index.PackageFiles,
+	}
+	fs, err := testutil.NewFs(map[string]string{
+		"/usr/local/bin/tfcmt": "",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Chmod("/usr/local/bin/tfcmt", 0o755); err != nil { //nolint:mnd
+		t.Fatal(err)
+	}
+	ctrl := &Controller{
+		fs: fs,
+		registryInstaller: &rgst.MockInstaller{
+			M: map[string]*registry.Config{
+				"standard": pkgFile,
+			},
+		},
+		fuzzyGetter: versiongetter.NewMockFuzzyGetter(map[string]string{
+			"suzuki-shunsuke/tfcmt": "v4.14.0",
+		}),
+		osEnv: osenv.NewMock(map[string]string{
+			"PATH": "/usr/local/bin",
+		}),
+		executor: &osexec.Mock{},
+	}
+	cfg := &aqua.Config{
+		Registries: aqua.Registries{
+			"standard": {
+				Name: "standard",
+				Type: "local",
+				Path: "registry.yaml",
+			},
+		},
+	}
+	registryContents := map[string]*registry.Config{
+		"standard": index,
+	}
+	param := &config.Param{
+		RootDir: "/home/foo/.aqua",
+		All:     true,
+	}
+	logE := logrus.NewEntry(logrus.New())
+	loader := ctrl.newPackageFileLoader(registryContents, cfg, "/home/foo/workspace/aqua.yaml", nil)
+	if len(index.PackageInfos) != 0 {
+		t.Fatal("the registry content returned by the registry installer must not be changed")
+	}
+	pkgs, err := ctrl.listPkgsFromPath(t.Context(), logE, param, cfg, registryContents, loader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []*config.Package{
+		{
+			Package: &aqua.Package{
+				Name: "suzuki-shunsuke/tfcmt@v4.14.0",
+			},
+			PackageInfo: pkgFile.PackageInfos[0],
+		},
+	}
+	if diff := cmp.Diff(exp, pkgs); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -34,6 +34,7 @@ type Info struct {
 	CommitHash  string            `json:"commit_hash"`
 	OS          string            `json:"os"`
 	Arch        string            `json:"arch"`
+	Libc        string            `json:"libc,omitempty"`
 	AquaGOOS    string            `json:"aqua_goos,omitempty"`
 	AquaGOARCH  string            `json:"aqua_goarch,omitempty"`
 	PWD         string            `json:"pwd"`
@@ -87,6 +88,7 @@ func (c *Controller) Info(_ context.Context, _ *logrus.Entry, param *config.Para
 		PWD:         maskUser(param.PWD, userName),
 		OS:          runtime.GOOS,
 		Arch:        runtime.GOARCH,
+		Libc:        c.rt.Libc,
 		RootDir:     maskUser(param.RootDir, userName),
 		ConfigFiles: cfgs,
 		Env:         map[string]string{},
@@ -113,6 +115,7 @@ func (c *Controller) Info(_ context.Context, _ *logrus.Entry, param *config.Para
 		"AQUA_GOOS",
 		"AQUA_KEYRING_ENABLED",
 		"AQUA_GHTKN_ENABLED",
+		"AQUA_LIBC",
 		"AQUA_LOG_COLOR",
 		"AQUA_LOG_LEVEL",
 		"AQUA_MAX_PARALLELISM",
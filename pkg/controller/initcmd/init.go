@@ -79,23 +79,7 @@ func (c *Controller) Init(ctx context.Context, logE *logrus.Entry, cfgFilePath s
 		}
 	}
 
-	registryVersion := "v4.446.0" // renovate: depName=aquaproj/aqua-registry
-	release, _, err := c.github.GetLatestRelease(ctx, "aquaproj", "aqua-registry")
-	if err != nil {
-		logerr.WithError(logE, err).WithFields(logrus.Fields{
-			"repo_owner": "aquaproj",
-			"repo_name":  "aqua-registry",
-		}).Warn("get the latest release")
-	} else {
-		if release == nil {
-			logE.WithFields(logrus.Fields{
-				"repo_owner": "aquaproj",
-				"repo_name":  "aqua-registry",
-			}).Warn("failed to get the latest release")
-		} else {
-			registryVersion = release.GetTagName()
-		}
-	}
+	registryVersion := GetStandardRegistryVersion(ctx, logE, c.github)
 	var cfgStr string
 	if param.ImportDir == "" {
 		cfgStr = strings.Replace(configTemplate, "%%STANDARD_REGISTRY_VERSION%%", registryVersion, 1)
@@ -121,3 +105,25 @@ func (c *Controller) cfgFilePath(cfgFilePath string, param *Param) string {
 	}
 	return filepath.Join("aqua", "aqua.yaml")
 }
+
+// GetStandardRegistryVersion returns the latest version of the standard registry.
+// If it fails to get the latest version, a fixed version is returned.
+func GetStandardRegistryVersion(ctx context.Context, logE *logrus.Entry, gh RepositoriesService) string {
+	registryVersion := "v4.446.0" // renovate: depName=aquaproj/aqua-registry
+	release, _, err := gh.GetLatestRelease(ctx, "aquaproj", "aqua-registry")
+	if err != nil {
+		logerr.WithError(logE, err).WithFields(logrus.Fields{
+			"repo_owner": "aquaproj",
+			"repo_name":  "aqua-registry",
+		}).Warn("get the latest release")
+		return registryVersion
+	}
+	if release == nil {
+		logE.WithFields(logrus.Fields{
+			"repo_owner": "aquaproj",
+			"repo_name":  "aqua-registry",
+		}).Warn("failed to get the latest release")
+		return registryVersion
+	}
+	return release.GetTagName()
+}
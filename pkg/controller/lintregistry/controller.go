@@ -0,0 +1,20 @@
+package lintregistry
+
+import (
+	"io"
+	"os"
+
+	"github.com/spf13/afero"
+)
+
+type Controller struct {
+	stdout io.Writer
+	fs     afero.Fs
+}
+
+func New(fs afero.Fs) *Controller {
+	return &Controller{
+		stdout: os.Stdout,
+		fs:     fs,
+	}
+}
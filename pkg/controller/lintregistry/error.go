@@ -0,0 +1,8 @@
+package lintregistry
+
+import "errors"
+
+var (
+	errRegistryFileRequired = errors.New("a registry file is required")
+	errLintFailed           = errors.New("issues are found in the registry")
+)
@@ -0,0 +1,228 @@
+package lintregistry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/asset"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// issue is a problem found in a registry.
+// The same problem found in multiple environments is reported once with the environments.
+type issue struct {
+	pkgName string
+	message string
+	envs    []string
+}
+
+func (is *issue) String() string {
+	s := is.pkgName + ": " + is.message
+	if len(is.envs) == 0 {
+		return s
+	}
+	return s + " (" + strings.Join(is.envs, ", ") + ")"
+}
+
+// linter collects issues of a registry.
+type linter struct {
+	logE     *logrus.Entry
+	runtimes []*runtime.Runtime
+	issues   []*issue
+	index    map[string]*issue
+}
+
+func (l *linter) add(pkgName, env, format string, a ...any) {
+	msg := fmt.Sprintf(format, a...)
+	key := pkgName + "\n" + msg
+	is, ok := l.index[key]
+	if !ok {
+		is = &issue{
+			pkgName: pkgName,
+			message: msg,
+		}
+		l.index[key] = is
+		l.issues = append(l.issues, is)
+	}
+	if env != "" {
+		is.envs = append(is.envs, env)
+	}
+}
+
+// Lint validates registry files statically and outputs issues.
+// Templates are rendered for all supported environments and sample versions,
+// so mistakes are found without installing packages on every platform.
+// It returns an error if any issue is found.
+func (c *Controller) Lint(logE *logrus.Entry, param *config.Param) error {
+	if len(param.Args) == 0 {
+		return errRegistryFileRequired
+	}
+	rts, err := runtime.GetRuntimesFromEnvs(nil)
+	if err != nil {
+		return fmt.Errorf("get supported environments: %w", err)
+	}
+	failed := false
+	for _, p := range param.Args {
+		cfg := &registry.Config{}
+		if err := registry.ReadFile(c.fs, p, cfg); err != nil {
+			return logerr.WithFields(err, logrus.Fields{ //nolint:wrapcheck
+				"registry_file": p,
+			})
+		}
+		l := &linter{
+			logE:     logE,
+			runtimes: rts,
+			index:    map[string]*issue{},
+		}
+		l.lint(cfg)
+		for _, is := range l.issues {
+			fmt.Fprintf(c.stdout, "%s: %s\n", p, is)
+		}
+		if len(l.issues) != 0 {
+			failed = true
+		}
+	}
+	if failed {
+		return errLintFailed
+	}
+	return nil
+}
+
+func (l *linter) lint(cfg *registry.Config) {
+	names := l.checkDuplicates(cfg.PackageInfos)
+	for i, pkgInfo := range cfg.PackageInfos {
+		l.lintPackage(names[i], pkgInfo)
+	}
+}
+
+// checkDuplicates flags duplicate package names and aliases.
+// It returns labels of packages which are used in issues.
+func (l *linter) checkDuplicates(pkgInfos registry.PackageInfos) []string {
+	labels := make([]string, len(pkgInfos))
+	names := make(map[string]struct{}, len(pkgInfos))
+	for i, pkgInfo := range pkgInfos {
+		name := pkgInfo.GetName()
+		if name == "" {
+			labels[i] = fmt.Sprintf("packages[%d]", i)
+			l.add(labels[i], "", "the package name is empty")
+			continue
+		}
+		labels[i] = name
+		if _, ok := names[name]; ok {
+			l.add(name, "", "the package name is duplicated")
+			continue
+		}
+		names[name] = struct{}{}
+	}
+	aliases := map[string]struct{}{}
+	for i, pkgInfo := range pkgInfos {
+		for _, alias := range pkgInfo.Aliases {
+			if _, ok := names[alias.Name]; ok {
+				l.add(labels[i], "", "the alias %s is duplicated with a package name", alias.Name)
+				continue
+			}
+			if _, ok := aliases[alias.Name]; ok {
+				l.add(labels[i], "", "the alias %s is duplicated", alias.Name)
+				continue
+			}
+			aliases[alias.Name] = struct{}{}
+		}
+	}
+	return labels
+}
+
+func (l *linter) lintPackage(name string, pkgInfo *registry.PackageInfo) {
+	l.checkFormats(name, pkgInfo)
+	rts := l.runtimes
+	if pkgInfo.DependsOnLibc() {
+		rts = runtime.ExpandLibc(rts)
+	}
+	for _, vc := range l.checkVersionOverrides(name, pkgInfo) {
+		if vc.index == -1 || vc.vo.Overrides != nil {
+			l.checkOverrides(name, vc.label, vc.pkgInfo, rts)
+		}
+		for _, rt := range rts {
+			pi := vc.pkgInfo.Copy()
+			pi.OverrideByRuntime(rt)
+			supported, err := pi.CheckSupported(rt, rt.Env())
+			if err != nil {
+				l.add(name, rt.LibcEnv(), "%scheck if the package supports the environment: %v", vc.label, err)
+				continue
+			}
+			if !supported {
+				continue
+			}
+			l.checkTemplates(name, vc.label, vc.version, pi, rt)
+		}
+	}
+}
+
+// checkFormats flags unknown format values including ones in overrides.
+func (l *linter) checkFormats(name string, pkgInfo *registry.PackageInfo) {
+	check := func(field, format string) {
+		if !asset.IsKnownFormat(format) {
+			l.add(name, "", "%s: unknown format: %s", field, format)
+		}
+	}
+	check("format", pkgInfo.Format)
+	for i, fo := range pkgInfo.FormatOverrides {
+		check(fmt.Sprintf("format_overrides[%d].format", i), fo.Format)
+	}
+	for i, ov := range pkgInfo.Overrides {
+		check(fmt.Sprintf("overrides[%d].format", i), ov.Format)
+	}
+	for i, vo := range pkgInfo.VersionOverrides {
+		check(fmt.Sprintf("version_overrides[%d].format", i), vo.Format)
+		for j, fo := range vo.FormatOverrides {
+			check(fmt.Sprintf("version_overrides[%d].format_overrides[%d].format", i, j), fo.Format)
+		}
+		for j, ov := range vo.Overrides {
+			check(fmt.Sprintf("version_overrides[%d].overrides[%d].format", i, j), ov.Format)
+		}
+	}
+}
+
+// checkOverrides flags overrides which are never applied.
+// An override is never applied if it matches only environments unsupported by supported_envs
+// or if preceding overrides match all environments it matches.
+func (l *linter) checkOverrides(name, label string, pkgInfo *registry.PackageInfo, rts []*runtime.Runtime) {
+	matched := make([]bool, len(pkgInfo.Overrides))
+	matchedSupported := make([]bool, len(pkgInfo.Overrides))
+	selected := make([]bool, len(pkgInfo.Overrides))
+	for _, rt := range rts {
+		supported, err := pkgInfo.Copy().CheckSupported(rt, rt.Env())
+		if err != nil {
+			continue
+		}
+		first := true
+		for i, ov := range pkgInfo.Overrides {
+			if !ov.Match(rt) {
+				continue
+			}
+			matched[i] = true
+			if !supported {
+				continue
+			}
+			matchedSupported[i] = true
+			if first {
+				selected[i] = true
+				first = false
+			}
+		}
+	}
+	for i := range pkgInfo.Overrides {
+		switch {
+		case selected[i]:
+		case !matched[i]:
+			l.add(name, "", "%soverrides[%d] doesn't match any environment", label, i)
+		case !matchedSupported[i]:
+			l.add(name, "", "%soverrides[%d] never matches because of supported_envs", label, i)
+		default:
+			l.add(name, "", "%soverrides[%d] never matches because preceding overrides match the same environments", label, i)
+		}
+	}
+}
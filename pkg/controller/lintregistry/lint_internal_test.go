@@ -0,0 +1,203 @@
+package lintregistry
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/testutil"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+func TestController_Lint(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name   string
+		files  map[string]string
+		isErr  bool
+		expOut string
+	}{
+		{
+			name: "no issue",
+			files: map[string]string{
+				"registry.yaml": `packages:
+  - type: github_release
+    repo_owner: suzuki-shunsuke
+    repo_name: tfcmt
+    aliases:
+      - name: tfcmt
+    asset: tfcmt_{{.OS}}_{{.Arch}}.{{.Format}}
+    format: tar.gz
+    overrides:
+      - goos: windows
+        format: zip
+    files:
+      - name: tfcmt
+        src: "{{.AssetWithoutExt}}/tfcmt"
+    checksum:
+      type: github_release
+      asset: tfcmt_{{trimV .Version}}_checksums.txt
+      algorithm: sha256
+    version_constraint: semver(">= 4.0.0")
+    version_overrides:
+      - version_constraint: semver(">= 3.0.0")
+        asset: tfcmt_{{.Version}}_{{.OS}}_{{.Arch}}.tar.gz
+      - version_constraint: "true"
+        supported_envs:
+          - linux
+`,
+			},
+		},
+		{
+			name:  "duplicate names and aliases",
+			isErr: true,
+			files: map[string]string{
+				"registry.yaml": `packages:
+  - type: github_release
+    repo_owner: foo
+    repo_name: bar
+    asset: bar
+    aliases:
+      - name: foo/baz
+      - name: bar
+  - type: github_release
+    repo_owner: foo
+    repo_name: baz
+    asset: baz
+    aliases:
+      - name: bar
+  - type: github_release
+    repo_owner: foo
+    repo_name: bar
+    asset: bar
+`,
+			},
+			expOut: `registry.yaml: foo/bar: the package name is duplicated
+registry.yaml: foo/bar: the alias foo/baz is duplicated with a package name
+registry.yaml: foo/baz: the alias bar is duplicated
+`,
+		},
+		{
+			name:  "format and overrides",
+			isErr: true,
+			files: map[string]string{
+				"registry.yaml": `packages:
+  - type: github_release
+    repo_owner: foo
+    repo_name: bar
+    asset: bar_{{.OS}}_{{.Arch}}.{{.Format}}
+    format: targz
+    supported_envs:
+      - linux
+      - darwin
+    overrides:
+      - goos: windows
+        format: zip
+      - goos: linux
+        format: tar.gz
+      - goos: linux
+        goarch: amd64
+        format: raw
+      - goos: freebsd
+`,
+			},
+			expOut: `registry.yaml: foo/bar: format: unknown format: targz
+registry.yaml: foo/bar: overrides[0] never matches because of supported_envs
+registry.yaml: foo/bar: overrides[2] never matches because preceding overrides match the same environments
+registry.yaml: foo/bar: overrides[3] doesn't match any environment
+`,
+		},
+		{
+			name:  "version_overrides",
+			isErr: true,
+			files: map[string]string{
+				"registry.yaml": `packages:
+  - type: github_release
+    repo_owner: foo
+    repo_name: bar
+    asset: bar_{{.OS}}_{{.Arch}}.tar.gz
+    version_constraint: semver(">= 3.0.0")
+    version_overrides:
+      - version_constraint: semver(">= 2.0.0")
+        asset: bar_{{.Version}}_{{.OS}}_{{.Arch}}.tar.gz
+      - version_constraint: semver(">= 2.5.0")
+        asset: bar.tar.gz
+      - version_constraint: semver("> 3.1.0")
+        asset: bar.tar.gz
+      - version_constraint: Version == "v1.0.0"
+        asset: bar-{{.Foo}}.tar.gz
+  - type: github_release
+    repo_owner: foo
+    repo_name: baz
+    asset: baz
+    version_overrides:
+      - version_constraint: "true"
+`,
+			},
+			expOut: `registry.yaml: foo/bar: version_overrides[1] (semver(">= 2.5.0")) is unreachable because preceding version constraints match all versions matching it
+registry.yaml: foo/bar: version_overrides[2] (semver("> 3.1.0")) is unreachable because preceding version constraints match all versions matching it
+registry.yaml: foo/bar: version_overrides[3]: asset: render a template: template: _:1:6: executing "_" at <.Foo>: map has no entry for key "Foo" (darwin/amd64, darwin/arm64, linux/amd64, linux/arm64, windows/amd64, windows/arm64)
+registry.yaml: foo/baz: version_overrides are ignored because version_constraint isn't set
+`,
+		},
+		{
+			name:  "undefined variables",
+			isErr: true,
+			files: map[string]string{
+				"registry.yaml": `packages:
+  - type: http
+    repo_owner: foo
+    repo_name: bar
+    url: https://example.com/bar/{{.Version}}/bar_{{.OS}}_{{.Arch}}.tar.gz
+    supported_envs:
+      - linux/amd64
+    vars:
+      - name: dir
+        default: bin
+    files:
+      - name: bar
+        src: "{{.Vars.dir}}/{{.Vars.name}}"
+    checksum:
+      type: http
+      url: "{{.AssetURL}}.sha256"
+    slsa_provenance:
+      type: http
+      url: "https://example.com/bar/{{.Version}}/{{.Assets}}.intoto.jsonl"
+    cosign:
+      signature:
+        type: github_release
+`,
+			},
+			expOut: `registry.yaml: foo/bar: files[bar].src: render a template: template: _:1:21: executing "_" at <.Vars.name>: map has no entry for key "name" (linux/amd64)
+registry.yaml: foo/bar: cosign.signature: asset is required (linux/amd64)
+registry.yaml: foo/bar: slsa_provenance.url: render a template: template: _:1:39: executing "_" at <.Assets>: map has no entry for key "Assets" (linux/amd64)
+`,
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			fs, err := testutil.NewFs(d.files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stdout := &bytes.Buffer{}
+			ctrl := New(fs)
+			ctrl.stdout = stdout
+			if err := ctrl.Lint(logE, &config.Param{
+				Args: []string{"registry.yaml"},
+			}); err != nil {
+				if !d.isErr {
+					t.Fatal(err)
+				}
+			} else if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(d.expOut, stdout.String()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
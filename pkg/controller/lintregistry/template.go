@@ -0,0 +1,197 @@
+package lintregistry
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/aquaproj/aqua/v2/pkg/asset"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/aquaproj/aqua/v2/pkg/template"
+)
+
+// templateChecker renders templates of a package for an environment.
+type templateChecker struct {
+	linter *linter
+	name   string
+	label  string
+	env    string
+}
+
+func (tc *templateChecker) add(field, format string, a ...any) {
+	tc.linter.add(tc.name, tc.env, "%s%s: %s", tc.label, field, fmt.Sprintf(format, a...))
+}
+
+// check executes a template with only variables which aqua passes to it,
+// so references to undefined variables are flagged instead of being rendered as "<no value>".
+func (tc *templateChecker) check(field, s string, input map[string]any) bool {
+	tpl, err := template.Compile(s)
+	if err != nil {
+		tc.add(field, "parse a template: %v", err)
+		return false
+	}
+	if _, err := template.ExecuteTemplate(tpl.Option("missingkey=error"), input); err != nil {
+		tc.add(field, "%v", err)
+		return false
+	}
+	return true
+}
+
+func (tc *templateChecker) checkDownloadedFile(field string, file *registry.DownloadedFile, input map[string]any) {
+	if file == nil {
+		return
+	}
+	switch file.Type {
+	case registry.PkgInfoTypeGitHubRelease:
+		if file.Asset == nil {
+			tc.add(field, "asset is required")
+			return
+		}
+		tc.check(field+".asset", *file.Asset, input)
+	case registry.PkgInfoTypeHTTP:
+		if file.URL == nil {
+			tc.add(field, "url is required")
+			return
+		}
+		tc.check(field+".url", *file.URL, input)
+	default:
+		tc.add(field, "unknown type: %s", file.Type)
+	}
+}
+
+func (tc *templateChecker) checkCosign(field string, cos *registry.Cosign, input map[string]any) {
+	if !cos.GetEnabled() {
+		return
+	}
+	for i, opt := range cos.Opts {
+		tc.check(fmt.Sprintf("%s.opts[%d]", field, i), opt, input)
+	}
+	tc.checkDownloadedFile(field+".signature", cos.Signature, input)
+	tc.checkDownloadedFile(field+".certificate", cos.Certificate, input)
+	tc.checkDownloadedFile(field+".key", cos.Key, input)
+	tc.checkDownloadedFile(field+".bundle", cos.Bundle, input)
+}
+
+// checkTemplates renders templates in asset, url, path, files, checksum, cosign, slsa_provenance, and minisign
+// for the environment.
+func (l *linter) checkTemplates(name, label, version string, pkgInfo *registry.PackageInfo, rt *runtime.Runtime) { //nolint:cyclop
+	tc := &templateChecker{
+		linter: l,
+		name:   name,
+		label:  label,
+		env:    rt.LibcEnv(),
+	}
+	pkg := &config.Package{
+		Package: &aqua.Package{
+			Name:    name,
+			Version: version,
+			Vars:    sampleVars(pkgInfo.Vars),
+		},
+		PackageInfo: pkgInfo,
+	}
+	art := pkg.TemplateArtifact(rt, "")
+	input := map[string]any{
+		"Version": art.Version,
+		"SemVer":  art.SemVer,
+		"GOOS":    rt.GOOS,
+		"GOARCH":  rt.GOARCH,
+		"Libc":    rt.Libc,
+		"OS":      art.OS,
+		"Arch":    art.Arch,
+		"Format":  art.Format,
+		"Vars":    art.Vars,
+	}
+
+	switch pkgInfo.Type {
+	case registry.PkgInfoTypeGitHubRelease:
+		if !tc.check("asset", pkgInfo.Asset, input) {
+			return
+		}
+	case registry.PkgInfoTypeHTTP:
+		if !tc.check("url", pkgInfo.URL, input) {
+			return
+		}
+	case registry.PkgInfoTypeGitHubContent, registry.PkgInfoTypeGoInstall:
+		if !tc.check("path", pkgInfo.Path, input) {
+			return
+		}
+	}
+	assetName, err := pkg.RenderAsset(rt)
+	if err != nil {
+		tc.add("asset", "%v", err)
+		return
+	}
+	if pkgInfo.Type == registry.PkgInfoTypeGitHubRelease && assetName == "" {
+		tc.add("asset", "the rendered asset is empty")
+	}
+	assetWithoutExt, _ := asset.RemoveExtFromAsset(assetName)
+	artInput := with(input, map[string]any{
+		"Asset":           assetName,
+		"AssetWithoutExt": assetWithoutExt,
+	})
+
+	for _, file := range pkgInfo.GetFiles() {
+		if file.Src != "" {
+			tc.check(fmt.Sprintf("files[%s].src", file.Name), file.Src, with(artInput, map[string]any{
+				"FileName": file.Name,
+			}))
+		}
+		if file.Dir != "" {
+			tc.check(fmt.Sprintf("files[%s].dir", file.Name), file.Dir, with(input, map[string]any{
+				"FileName": file.Name,
+			}))
+		}
+	}
+
+	if pkgInfo.Checksum.GetEnabled() {
+		switch pkgInfo.Checksum.Type {
+		case registry.PkgInfoTypeGitHubRelease:
+			tc.check("checksum.asset", pkgInfo.Checksum.Asset, with(input, map[string]any{
+				"Asset": assetName,
+			}))
+		case registry.PkgInfoTypeHTTP:
+			checksumInput := input
+			if pkgInfo.Type == registry.PkgInfoTypeHTTP {
+				if u, err := pkg.RenderURL(rt); err == nil {
+					checksumInput = with(input, map[string]any{
+						"AssetURL": u,
+					})
+				}
+			}
+			tc.check("checksum.url", pkgInfo.Checksum.URL, checksumInput)
+		}
+		tc.checkCosign("checksum.cosign", pkgInfo.Checksum.GetCosign(), artInput)
+		if m := pkgInfo.Checksum.GetMinisign(); m.GetEnabled() {
+			tc.checkDownloadedFile("checksum.minisign", m.ToDownloadedFile(), artInput)
+		}
+	}
+	tc.checkCosign("cosign", pkgInfo.Cosign, artInput)
+	if sp := pkgInfo.SLSAProvenance; sp.GetEnabled() {
+		tc.checkDownloadedFile("slsa_provenance", sp.ToDownloadedFile(), artInput)
+	}
+	if m := pkgInfo.Minisign; m.GetEnabled() {
+		tc.checkDownloadedFile("minisign", m.ToDownloadedFile(), artInput)
+	}
+}
+
+// sampleVars returns values of vars.
+// Default values are used if they are set, otherwise empty strings are used.
+func sampleVars(vars []*registry.Var) map[string]any {
+	m := make(map[string]any, len(vars))
+	for _, v := range vars {
+		if v.Default != nil {
+			m[v.Name] = v.Default
+			continue
+		}
+		m[v.Name] = ""
+	}
+	return m
+}
+
+func with(base, m map[string]any) map[string]any {
+	ret := maps.Clone(base)
+	maps.Copy(ret, m)
+	return ret
+}
@@ -0,0 +1,249 @@
+package lintregistry
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/expr"
+)
+
+const defaultVersion = "v1.0.0"
+
+var (
+	quotedPattern  = regexp.MustCompile(`"[^"]*"`)                                          //nolint:gochecknoglobals
+	versionPattern = regexp.MustCompile(`v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(-[0-9A-Za-z.]+)?`) //nolint:gochecknoglobals
+)
+
+// versionCase is a pair of a sample version and the package information selected by the version.
+type versionCase struct {
+	// index is the index of version_overrides. -1 means the top level configuration.
+	index   int
+	label   string
+	version string
+	vo      *registry.VersionOverride
+	pkgInfo *registry.PackageInfo
+}
+
+// checkVersionOverrides flags version_overrides which are never selected and constraints which can't be evaluated.
+// version_overrides are evaluated in order and the first matching one is selected,
+// so a version_override is unreachable if all sample versions matching it are matched by preceding constraints.
+// It returns sample versions for the top level configuration and each reachable version_override.
+func (l *linter) checkVersionOverrides(name string, pkgInfo *registry.PackageInfo) []*versionCase { //nolint:cyclop,funlen
+	top := &versionCase{
+		index:   -1,
+		version: pkgInfo.VersionPrefix + defaultVersion,
+		pkgInfo: pkgInfo,
+	}
+	if pkgInfo.VersionConstraints == "" {
+		if len(pkgInfo.VersionOverrides) != 0 {
+			l.add(name, "", "version_overrides are ignored because version_constraint isn't set")
+		}
+		return []*versionCase{top}
+	}
+
+	samples := sampleVersions(pkgInfo)
+	constraints := make([]*constraint, 0, len(pkgInfo.VersionOverrides)+1)
+	constraints = append(constraints, &constraint{
+		label:   "version_constraint",
+		expr:    pkgInfo.VersionConstraints,
+		prefix:  pkgInfo.VersionPrefix,
+		matched: make([]bool, len(samples)),
+	})
+	for i, vo := range pkgInfo.VersionOverrides {
+		prefix := pkgInfo.VersionPrefix
+		if vo.VersionPrefix != nil {
+			prefix = *vo.VersionPrefix
+		}
+		constraints = append(constraints, &constraint{
+			label:   fmt.Sprintf("version_overrides[%d].version_constraint", i),
+			expr:    vo.VersionConstraints,
+			prefix:  prefix,
+			matched: make([]bool, len(samples)),
+		})
+	}
+	for _, c := range constraints {
+		c.evaluate(samples)
+		if !c.evaluated && c.firstError != nil {
+			l.add(name, "", "%s: evaluate %q: %v", c.label, c.expr, c.firstError)
+		}
+	}
+
+	// reached[i] is the index of a sample version which selects constraints[i].
+	reached := make([]int, len(constraints))
+	for i := range reached {
+		reached[i] = -1
+	}
+	fallback := -1
+	for j := range samples {
+		idx := slices.IndexFunc(constraints, func(c *constraint) bool {
+			return c.matched[j]
+		})
+		if idx == -1 {
+			if fallback == -1 {
+				fallback = j
+			}
+			continue
+		}
+		if reached[idx] == -1 {
+			reached[idx] = j
+		}
+	}
+
+	if reached[0] != -1 {
+		top.version = samples[reached[0]]
+	} else if fallback != -1 {
+		top.version = samples[fallback]
+	}
+	cases := []*versionCase{top}
+	for i, vo := range pkgInfo.VersionOverrides {
+		c := constraints[i+1]
+		if reached[i+1] == -1 {
+			if slices.Contains(c.matched, true) {
+				l.add(name, "", "version_overrides[%d] (%s) is unreachable because preceding version constraints match all versions matching it", i, vo.VersionConstraints)
+			}
+			continue
+		}
+		version := samples[reached[i+1]]
+		pi, err := pkgInfo.SetVersion(l.logE, version)
+		if err != nil {
+			l.add(name, "", "version_overrides[%d]: apply version_overrides: %v", i, err)
+			continue
+		}
+		cases = append(cases, &versionCase{
+			index:   i,
+			label:   fmt.Sprintf("version_overrides[%d]: ", i),
+			version: version,
+			vo:      vo,
+			pkgInfo: pi,
+		})
+	}
+	return cases
+}
+
+// constraint is the result of evaluating a version constraint with sample versions.
+type constraint struct {
+	label   string
+	expr    string
+	prefix  string
+	matched []bool
+	// evaluated is true if the constraint is evaluated successfully with at least one version.
+	evaluated  bool
+	firstError error
+}
+
+func (c *constraint) evaluate(samples []string) {
+	for i, v := range samples {
+		sv := v
+		if c.prefix != "" {
+			s, ok := strings.CutPrefix(v, c.prefix)
+			if !ok {
+				continue
+			}
+			sv = s
+		}
+		a, err := expr.EvaluateVersionConstraints(c.expr, v, sv)
+		if err != nil {
+			if c.firstError == nil {
+				c.firstError = err
+			}
+			continue
+		}
+		c.evaluated = true
+		c.matched[i] = a
+	}
+}
+
+// sampleVersions returns versions to evaluate version constraints.
+// Versions are extracted from string literals in constraints,
+// and versions around them are added so that both sides of boundaries such as `semver("< 1.0.0")` are evaluated.
+func sampleVersions(pkgInfo *registry.PackageInfo) []string {
+	exprs := []string{pkgInfo.VersionConstraints}
+	prefixes := []string{pkgInfo.VersionPrefix}
+	for _, vo := range pkgInfo.VersionOverrides {
+		exprs = append(exprs, vo.VersionConstraints)
+		if vo.VersionPrefix != nil {
+			prefixes = append(prefixes, *vo.VersionPrefix)
+		}
+	}
+	raws := map[string]struct{}{}
+	cores := map[string]struct{}{
+		strings.TrimPrefix(defaultVersion, "v"): {},
+	}
+	for _, e := range exprs {
+		for _, q := range quotedPattern.FindAllString(e, -1) {
+			q = strings.Trim(q, `"`)
+			if q != "" && !strings.ContainsAny(q, " <>=~^,|!*") {
+				raws[q] = struct{}{}
+			}
+			for _, m := range versionPattern.FindAllStringSubmatch(q, -1) {
+				for _, core := range neighborVersions(m[1:]) {
+					cores[core] = struct{}{}
+				}
+			}
+		}
+	}
+	versions := map[string]struct{}{}
+	for v := range raws {
+		versions[v] = struct{}{}
+		for _, prefix := range prefixes {
+			if !strings.HasPrefix(v, prefix) {
+				versions[prefix+v] = struct{}{}
+			}
+		}
+	}
+	for core := range cores {
+		for _, prefix := range prefixes {
+			versions[prefix+"v"+core] = struct{}{}
+			versions[prefix+core] = struct{}{}
+		}
+	}
+	ret := make([]string, 0, len(versions))
+	for v := range versions {
+		ret = append(ret, v)
+	}
+	slices.Sort(ret)
+	return ret
+}
+
+// neighborVersions returns the version and versions around it.
+// parts are the major, minor, patch, and prerelease parts of the version.
+func neighborVersions(parts []string) []string {
+	nums := make([]int, 3) //nolint:mnd
+	for i := range nums {
+		if parts[i] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return nil
+		}
+		nums[i] = n
+	}
+	major, minor, patch := nums[0], nums[1], nums[2]
+	f := func(x, y, z int) string {
+		return fmt.Sprintf("%d.%d.%d", x, y, z)
+	}
+	ret := []string{
+		f(major, minor, patch),
+		f(major, minor, patch+1),
+		f(major, minor+1, 0),
+		f(major+1, 0, 0),
+	}
+	if parts[3] != "" {
+		ret = append(ret, f(major, minor, patch)+parts[3])
+	}
+	if patch > 0 {
+		ret = append(ret, f(major, minor, patch-1))
+	}
+	if minor > 0 {
+		ret = append(ret, f(major, minor-1, 0))
+	}
+	if major > 0 {
+		ret = append(ret, f(major-1, 0, 0))
+	}
+	return ret
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/checksum"
 	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
 	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 )
@@ -18,15 +19,17 @@ type Controller struct {
 	configReader      ConfigReader
 	registryInstaller RegistryInstaller
 	fs                afero.Fs
+	runtime           *runtime.Runtime
 }
 
-func NewController(configFinder ConfigFinder, configReader ConfigReader, registryInstaller RegistryInstaller, fs afero.Fs) *Controller {
+func NewController(configFinder ConfigFinder, configReader ConfigReader, registryInstaller RegistryInstaller, fs afero.Fs, rt *runtime.Runtime) *Controller {
 	return &Controller{
 		stdout:            os.Stdout,
 		configFinder:      configFinder,
 		configReader:      configReader,
 		registryInstaller: registryInstaller,
 		fs:                fs,
+		runtime:           rt,
 	}
 }
 
@@ -1,18 +1,36 @@
 package list
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/aquaproj/aqua/v2/pkg/checksum"
 	"github.com/aquaproj/aqua/v2/pkg/config"
 	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
 	"github.com/sirupsen/logrus"
 )
 
+// Package is a package in registries outputted by `aqua list --format`.
+type Package struct {
+	Registry      string   `json:"registry" yaml:"registry"`
+	Name          string   `json:"name" yaml:"name"`
+	Type          string   `json:"type" yaml:"type"`
+	Description   string   `json:"description,omitempty" yaml:"description,omitempty"`
+	SearchWords   []string `json:"search_words,omitempty" yaml:"search_words,omitempty"`
+	Aliases       []string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	SupportedEnvs []string `json:"supported_envs,omitempty" yaml:"supported_envs,omitempty"`
+}
+
 func (c *Controller) List(ctx context.Context, logE *logrus.Entry, param *config.Param) error {
+	if err := validateFormat(param.OutputFormat); err != nil {
+		return err
+	}
 	if param.Installed {
-		return c.listInstalled(logE, param)
+		return c.listInstalled(ctx, logE, param)
 	}
 	cfg := &aqua.Config{}
 	cfgFilePath, err := c.configFinder.Find(param.PWD, param.ConfigFilePath, param.GlobalConfigFilePaths...)
@@ -36,8 +54,12 @@ func (c *Controller) List(ctx context.Context, logE *logrus.Entry, param *config
 	if err != nil {
 		return err //nolint:wrapcheck
 	}
+	if param.OutputFormat != "" {
+		return c.outputPackages(param.OutputFormat, listPackages(registryContents))
+	}
 	for registryName, registryContent := range registryContents {
-		for pkgName := range registryContent.PackageInfos.ToMap(logE) {
+		pkgInfos := slices.Concat(registryContent.PackageInfos, registryContent.UnloadedPackageInfos())
+		for pkgName := range pkgInfos.ToMap(logE) {
 			if pkgName == "" {
 				logE.Debug("ignore a package because the package name is empty")
 				continue
@@ -48,3 +70,53 @@ func (c *Controller) List(ctx context.Context, logE *logrus.Entry, param *config
 
 	return nil
 }
+
+// listPackages returns packages in registries sorted by the registry name and the package name.
+// Aliases are included in each package rather than listed separately.
+// Packages of split registries whose package files aren't installed have only names and aliases.
+func listPackages(registryContents map[string]*registry.Config) []*Package {
+	pkgs := []*Package{}
+	for registryName, registryContent := range registryContents {
+		names := map[string]struct{}{}
+		for _, pkgInfo := range slices.Concat(registryContent.PackageInfos, registryContent.UnloadedPackageInfos()) {
+			if pkgInfo == nil {
+				continue
+			}
+			name := pkgInfo.GetName()
+			if name == "" {
+				continue
+			}
+			if _, ok := names[name]; ok {
+				continue
+			}
+			names[name] = struct{}{}
+			pkg := &Package{
+				Registry:      registryName,
+				Name:          name,
+				Type:          pkgInfo.Type,
+				Description:   pkgInfo.Description,
+				SearchWords:   pkgInfo.SearchWords,
+				SupportedEnvs: pkgInfo.SupportedEnvs,
+			}
+			for _, alias := range pkgInfo.Aliases {
+				if alias.Name == "" {
+					continue
+				}
+				pkg.Aliases = append(pkg.Aliases, alias.Name)
+			}
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	slices.SortFunc(pkgs, func(a, b *Package) int {
+		return cmp.Or(cmp.Compare(a.Registry, b.Registry), cmp.Compare(a.Name, b.Name))
+	})
+	return pkgs
+}
+
+func (c *Controller) outputPackages(format string, pkgs []*Package) error {
+	rows := make([][]string, len(pkgs))
+	for i, pkg := range pkgs {
+		rows[i] = []string{pkg.Registry, pkg.Name, pkg.Type, strings.Join(pkg.Aliases, ","), strings.Join(strings.Fields(pkg.Description), " ")}
+	}
+	return c.output(format, pkgs, []string{"REGISTRY", "NAME", "TYPE", "ALIASES", "DESCRIPTION"}, rows)
+}
@@ -1,61 +1,203 @@
 package list
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
 
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
 	"github.com/aquaproj/aqua/v2/pkg/config"
 	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
 	"github.com/suzuki-shunsuke/logrus-error/logerr"
 )
 
-func (c *Controller) listInstalled(logE *logrus.Entry, param *config.Param) error {
+// InstalledPackage is a package in configuration files outputted by `aqua list --installed --format`.
+type InstalledPackage struct {
+	Name           string `json:"name" yaml:"name"`
+	Version        string `json:"version" yaml:"version"`
+	Registry       string `json:"registry" yaml:"registry"`
+	ConfigFilePath string `json:"config_file_path" yaml:"config_file_path"`
+	// Supported is false if the package isn't supported on the current environment.
+	Supported bool `json:"supported" yaml:"supported"`
+	// Installed is true if the package is installed for the current environment.
+	Installed   bool   `json:"installed" yaml:"installed"`
+	InstallPath string `json:"install_path,omitempty" yaml:"install_path,omitempty"`
+	// Size is the total size of files in InstallPath in bytes.
+	Size int64 `json:"size,omitempty" yaml:"size,omitempty"`
+}
+
+func (c *Controller) listInstalled(ctx context.Context, logE *logrus.Entry, param *config.Param) error {
 	cfgFilePaths := c.configFinder.Finds(param.PWD, param.ConfigFilePath)
 	cfgFileMap := map[string]struct{}{}
+	pkgs := []*InstalledPackage{}
 	for _, cfgFilePath := range cfgFilePaths {
 		if _, ok := cfgFileMap[cfgFilePath]; ok {
 			continue
 		}
 		cfgFileMap[cfgFilePath] = struct{}{}
 
-		if err := c.listInstalledByConfig(logE, cfgFilePath); err != nil {
+		arr, err := c.listInstalledByConfig(ctx, logE, param, cfgFilePath)
+		if err != nil {
 			return logerr.WithFields(err, logrus.Fields{ //nolint:wrapcheck
 				"config_file_path": cfgFilePath,
 			})
 		}
+		pkgs = append(pkgs, arr...)
+	}
+
+	if param.All {
+		for _, cfgFilePath := range param.GlobalConfigFilePaths {
+			logE := logE.WithField("config_file_path", cfgFilePath)
+			if _, ok := cfgFileMap[cfgFilePath]; ok {
+				continue
+			}
+			cfgFileMap[cfgFilePath] = struct{}{}
+
+			logE.Debug("checking a global configuration file")
+			if _, err := c.fs.Stat(cfgFilePath); err != nil {
+				continue
+			}
+			arr, err := c.listInstalledByConfig(ctx, logE, param, cfgFilePath)
+			if err != nil {
+				return logerr.WithFields(err, logrus.Fields{ //nolint:wrapcheck
+					"config_file_path": cfgFilePath,
+				})
+			}
+			pkgs = append(pkgs, arr...)
+		}
 	}
 
-	if !param.All {
+	if param.OutputFormat == "" {
 		return nil
 	}
+	return c.outputInstalledPackages(param.OutputFormat, pkgs)
+}
 
-	for _, cfgFilePath := range param.GlobalConfigFilePaths {
-		logE := logE.WithField("config_file_path", cfgFilePath)
-		if _, ok := cfgFileMap[cfgFilePath]; ok {
-			continue
+// listInstalledByConfig lists packages in the configuration file.
+// If the output format isn't specified, packages are outputted immediately in the legacy format.
+func (c *Controller) listInstalledByConfig(ctx context.Context, logE *logrus.Entry, param *config.Param, cfgFilePath string) ([]*InstalledPackage, error) {
+	cfg := &aqua.Config{}
+	if err := c.configReader.Read(logE, cfgFilePath, cfg); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	if param.OutputFormat == "" {
+		for _, pkg := range cfg.Packages {
+			fmt.Fprintln(c.stdout, pkg.Name+"\t"+pkg.Version+"\t"+pkg.Registry)
 		}
-		cfgFileMap[cfgFilePath] = struct{}{}
+		return nil, nil
+	}
 
-		logE.Debug("checking a global configuration file")
-		if _, err := c.fs.Stat(cfgFilePath); err != nil {
-			continue
+	checksums, updateChecksum, err := checksum.Open(
+		logE, c.fs, cfgFilePath,
+		param.ChecksumEnabled(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("read a checksum JSON: %w", err)
+	}
+	defer updateChecksum()
+
+	registryContents, err := c.registryInstaller.InstallRegistries(ctx, logE, cfg, cfgFilePath, checksums)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	pkgs := make([]*InstalledPackage, 0, len(cfg.Packages))
+	for _, pkg := range cfg.Packages {
+		logE := logE.WithFields(logrus.Fields{
+			"package_name":    pkg.Name,
+			"package_version": pkg.Version,
+			"registry_name":   pkg.Registry,
+		})
+		installedPkg := &InstalledPackage{
+			Name:           pkg.Name,
+			Version:        pkg.Version,
+			Registry:       pkg.Registry,
+			ConfigFilePath: cfgFilePath,
 		}
-		if err := c.listInstalledByConfig(logE, cfgFilePath); err != nil {
-			return logerr.WithFields(err, logrus.Fields{ //nolint:wrapcheck
-				"config_file_path": cfgFilePath,
-			})
+		pkgs = append(pkgs, installedPkg)
+		if err := c.setInstallStatus(logE, param, registryContents, pkg, installedPkg); err != nil {
+			logerr.WithError(logE, err).Warn("check if the package is installed")
 		}
 	}
-	return nil
+	return pkgs, nil
 }
 
-func (c *Controller) listInstalledByConfig(logE *logrus.Entry, cfgFilePath string) error {
-	cfg := &aqua.Config{}
-	if err := c.configReader.Read(logE, cfgFilePath, cfg); err != nil {
-		return err //nolint:wrapcheck
+// setInstallStatus sets whether the package is supported and installed on the current environment.
+func (c *Controller) setInstallStatus(logE *logrus.Entry, param *config.Param, registryContents map[string]*registry.Config, pkg *aqua.Package, installedPkg *InstalledPackage) error {
+	registryContent, ok := registryContents[pkg.Registry]
+	if !ok {
+		return errors.New("the registry isn't found")
 	}
-	for _, pkg := range cfg.Packages {
-		fmt.Fprintln(c.stdout, pkg.Name+"\t"+pkg.Version+"\t"+pkg.Registry)
+	pkgInfo := registryContent.Package(logE, pkg.Name)
+	if pkgInfo == nil {
+		return errors.New("the package isn't found in the registry")
+	}
+	pkgInfo, err := pkgInfo.Override(logE, pkg.Version, c.runtime)
+	if err != nil {
+		return fmt.Errorf("evaluate version constraints: %w", err)
+	}
+	supported, err := pkgInfo.CheckSupported(c.runtime, c.runtime.Env())
+	if err != nil {
+		return fmt.Errorf("check if the package is supported: %w", err)
 	}
+	installedPkg.Supported = supported
+	if !supported {
+		return nil
+	}
+	p := &config.Package{
+		Package:     pkg,
+		PackageInfo: pkgInfo,
+	}
+	if err := p.ApplyVars(); err != nil {
+		return fmt.Errorf("apply package variables: %w", err)
+	}
+	pkgPath, err := p.AbsPkgPath(param.RootDir, c.runtime)
+	if err != nil {
+		return fmt.Errorf("get the package path: %w", err)
+	}
+	installedPkg.InstallPath = pkgPath
+	size, err := c.getSize(pkgPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("get the size of the package: %w", err)
+	}
+	installedPkg.Installed = true
+	installedPkg.Size = size
 	return nil
 }
+
+// getSize returns the total size of files in the path.
+func (c *Controller) getSize(p string) (int64, error) {
+	var size int64
+	if err := afero.Walk(c.fs, p, func(_ string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	}); err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+	return size, nil
+}
+
+func (c *Controller) outputInstalledPackages(format string, pkgs []*InstalledPackage) error {
+	rows := make([][]string, len(pkgs))
+	for i, pkg := range pkgs {
+		size := ""
+		if pkg.Installed {
+			size = formatSize(pkg.Size)
+		}
+		rows[i] = []string{pkg.Name, pkg.Version, pkg.Registry, strconv.FormatBool(pkg.Installed), size, pkg.InstallPath}
+	}
+	return c.output(format, pkgs, []string{"NAME", "VERSION", "REGISTRY", "INSTALLED", "SIZE", "INSTALL_PATH"}, rows)
+}
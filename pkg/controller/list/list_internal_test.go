@@ -0,0 +1,107 @@
+package list
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_listPackages(t *testing.T) {
+	t.Parallel()
+	registryContents := map[string]*registry.Config{
+		"standard": {
+			PackageInfos: registry.PackageInfos{
+				{
+					Type:        "github_release",
+					RepoOwner:   "cli",
+					RepoName:    "cli",
+					Description: "GitHub’s official command line tool",
+					SearchWords: []string{"github"},
+					Aliases: []*registry.Alias{
+						{Name: "github/cli"},
+					},
+				},
+				{
+					Type:          "github_content",
+					RepoOwner:     "aquaproj",
+					RepoName:      "aqua-installer",
+					Path:          "aqua-installer",
+					SupportedEnvs: registry.SupportedEnvs{"linux", "darwin"},
+				},
+				{
+					Type:      "github_release",
+					RepoOwner: "cli",
+					RepoName:  "cli",
+				},
+				nil,
+			},
+		},
+		"local": {
+			PackageInfos: registry.PackageInfos{
+				{
+					Type:      "github_release",
+					RepoOwner: "suzuki-shunsuke",
+					RepoName:  "tfcmt",
+				},
+			},
+			PackageFiles: []*registry.PackageFile{
+				{Name: "suzuki-shunsuke/tfcmt"},
+				{
+					Name:    "suzuki-shunsuke/pinact",
+					Aliases: []*registry.Alias{{Name: "pinact"}},
+				},
+			},
+		},
+	}
+	exp := []*Package{
+		{
+			Registry: "local",
+			Name:     "suzuki-shunsuke/pinact",
+			Aliases:  []string{"pinact"},
+		},
+		{
+			Registry: "local",
+			Name:     "suzuki-shunsuke/tfcmt",
+			Type:     "github_release",
+		},
+		{
+			Registry:      "standard",
+			Name:          "aquaproj/aqua-installer",
+			Type:          "github_content",
+			SupportedEnvs: []string{"linux", "darwin"},
+		},
+		{
+			Registry:    "standard",
+			Name:        "cli/cli",
+			Type:        "github_release",
+			Description: "GitHub’s official command line tool",
+			SearchWords: []string{"github"},
+			Aliases:     []string{"github/cli"},
+		},
+	}
+	if diff := cmp.Diff(exp, listPackages(registryContents)); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func Test_formatSize(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		size int64
+		exp  string
+	}{
+		{size: 0, exp: "0 B"},
+		{size: 1023, exp: "1023 B"},
+		{size: 1536, exp: "1.5 KiB"},
+		{size: 10 * 1024 * 1024, exp: "10.0 MiB"},
+	}
+	for _, d := range data {
+		t.Run(d.exp, func(t *testing.T) {
+			t.Parallel()
+			if s := formatSize(d.size); s != d.exp {
+				t.Fatalf("wanted %s, got %s", d.exp, s)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package list
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/goccy/go-yaml"
+)
+
+const (
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+	formatTable = "table"
+)
+
+var errInvalidFormat = errors.New("the output format must be either json, yaml, or table")
+
+func validateFormat(format string) error {
+	switch format {
+	case "", formatJSON, formatYAML, formatTable:
+		return nil
+	default:
+		return errInvalidFormat
+	}
+}
+
+// output outputs records in the given format.
+// header and rows are used only if the format is table.
+func (c *Controller) output(format string, records any, header []string, rows [][]string) error {
+	switch format {
+	case formatJSON:
+		encoder := json.NewEncoder(c.stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(records); err != nil {
+			return fmt.Errorf("encode the list as JSON: %w", err)
+		}
+		return nil
+	case formatYAML:
+		if err := yaml.NewEncoder(c.stdout).Encode(records); err != nil {
+			return fmt.Errorf("encode the list as YAML: %w", err)
+		}
+		return nil
+	case formatTable:
+		w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0) //nolint:mnd
+		fmt.Fprintln(w, strings.Join(header, "\t"))
+		for _, row := range rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		if err := w.Flush(); err != nil {
+			return fmt.Errorf("output the list as a table: %w", err)
+		}
+		return nil
+	default:
+		return errInvalidFormat
+	}
+}
+
+// formatSize formats the byte size in the human-readable format such as "1.5 MiB".
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
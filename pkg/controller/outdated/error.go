@@ -0,0 +1,8 @@
+package outdated
+
+import "errors"
+
+var (
+	errUpdatesExist  = errors.New("some packages or registries are outdated")
+	errInvalidFormat = errors.New("the output format must be either json or table")
+)
@@ -0,0 +1,277 @@
+package outdated
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/versiongetter"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/go-error-with-exit-code/ecerror"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+var commitHashPattern = regexp.MustCompile(`\b[0-9a-f]{40}\b`)
+
+const (
+	formatJSON  = "json"
+	formatTable = "table"
+)
+
+// Result is the result of `aqua outdated`.
+type Result struct {
+	Registries []*Registry `json:"registries"`
+	Packages   []*Package  `json:"packages"`
+}
+
+// Registry is a registry in the configuration file and its latest version.
+type Registry struct {
+	Name           string `json:"name"`
+	CurrentVersion string `json:"current_version"`
+	LatestVersion  string `json:"latest_version,omitempty"`
+	// UpdateType is one of major, minor, patch, and other.
+	// It's empty if the registry is up to date.
+	UpdateType string `json:"update_type,omitempty"`
+	// SkipReason is the reason why the latest version isn't checked.
+	SkipReason string `json:"skip_reason,omitempty"`
+}
+
+// Package is a package in the configuration file and its latest version.
+type Package struct {
+	Name           string `json:"name"`
+	Registry       string `json:"registry"`
+	ConfigFilePath string `json:"config_file_path"`
+	CurrentVersion string `json:"current_version"`
+	LatestVersion  string `json:"latest_version,omitempty"`
+	// UpdateType is one of major, minor, patch, and other.
+	// It's empty if the package is up to date.
+	UpdateType string `json:"update_type,omitempty"`
+	// SkipReason is the reason why the latest version isn't checked.
+	SkipReason string `json:"skip_reason,omitempty"`
+}
+
+func (r *Result) hasUpdates() bool {
+	for _, rgst := range r.Registries {
+		if rgst.UpdateType != "" {
+			return true
+		}
+	}
+	for _, pkg := range r.Packages {
+		if pkg.UpdateType != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Outdated outputs current and latest versions of packages and registries without updating the configuration file.
+// The result is outputted in the format param.OutputFormat, which is either json or table (default).
+// It returns an error with the exit code 1 if some packages or registries are outdated.
+func (c *Controller) Outdated(ctx context.Context, logE *logrus.Entry, param *config.Param) error {
+	switch param.OutputFormat {
+	case "", formatJSON, formatTable:
+	default:
+		return errInvalidFormat
+	}
+	result, err := c.outdated(ctx, logE, param)
+	if err != nil {
+		return err
+	}
+	if err := c.output(result, param.OutputFormat); err != nil {
+		return err
+	}
+	if result.hasUpdates() {
+		return ecerror.Wrap(errUpdatesExist, 1)
+	}
+	return nil
+}
+
+func (c *Controller) outdated(ctx context.Context, logE *logrus.Entry, param *config.Param) (*Result, error) {
+	cfgFilePath, err := c.configFinder.Find(param.PWD, param.ConfigFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("find a configuration file: %w", err)
+	}
+	cfg := &aqua.Config{}
+	if err := c.configReader.Read(logE, cfgFilePath, cfg); err != nil {
+		return nil, fmt.Errorf("read a configuration file: %w", err)
+	}
+
+	result := &Result{
+		Registries: []*Registry{},
+		Packages:   []*Package{},
+	}
+
+	if !param.OnlyRegistry {
+		checksums, updateChecksum, err := checksum.Open(
+			logE, c.fs, cfgFilePath,
+			param.ChecksumEnabled(cfg))
+		if err != nil {
+			return nil, fmt.Errorf("read a checksum JSON: %w", err)
+		}
+		defer updateChecksum()
+
+		registryConfigs, err := c.registryInstaller.InstallRegistries(ctx, logE, cfg, cfgFilePath, checksums)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		result.Packages = c.checkPackages(ctx, logE, param, cfgFilePath, cfg, registryConfigs)
+	}
+
+	if !param.OnlyPackage {
+		result.Registries = c.checkRegistries(ctx, logE, cfg)
+	}
+	return result, nil
+}
+
+func (c *Controller) checkRegistries(ctx context.Context, logE *logrus.Entry, cfg *aqua.Config) []*Registry {
+	rgsts := make([]*Registry, 0, len(cfg.Registries))
+	for _, rgst := range cfg.Registries {
+		if rgst.Type == aqua.RegistryTypeLocal {
+			continue
+		}
+		logE := logE.WithField("registry_name", rgst.Name)
+		r := &Registry{
+			Name:           rgst.Name,
+			CurrentVersion: rgst.Ref,
+		}
+		rgsts = append(rgsts, r)
+		if commitHashPattern.MatchString(rgst.Ref) {
+			r.SkipReason = "the version is a commit hash"
+			continue
+		}
+		logE.Debug("getting the latest release of a registry")
+		release, _, err := c.gh.GetLatestRelease(ctx, rgst.RepoOwner, rgst.RepoName)
+		if err != nil {
+			logerr.WithError(logE, err).Warn("get the latest release of the registry by GitHub API")
+			r.SkipReason = "failed to get the latest version"
+			continue
+		}
+		r.LatestVersion = release.GetTagName()
+		r.UpdateType = versiongetter.GetUpdateType(r.CurrentVersion, r.LatestVersion)
+	}
+	slices.SortFunc(rgsts, func(a, b *Registry) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return rgsts
+}
+
+func (c *Controller) checkPackages(ctx context.Context, logE *logrus.Entry, param *config.Param, cfgFilePath string, cfg *aqua.Config, rgstCfgs map[string]*registry.Config) []*Package {
+	pkgs, _ := config.ListPackagesNotOverride(logE, cfg, rgstCfgs)
+	results := make([]*Package, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		logE := logE.WithFields(logrus.Fields{
+			"package_name":    pkg.Package.Name,
+			"package_version": pkg.Package.Version,
+			"registry":        pkg.Package.Registry,
+		})
+		if !aqua.FilterPackageByTag(pkg.Package, param.Tags, param.ExcludedTags) {
+			logE.Debug("skip checking the package because package tags are unmatched")
+			continue
+		}
+		filePath := cfgFilePath
+		if pkg.Package.FilePath != "" {
+			filePath = pkg.Package.FilePath
+		}
+		p := &Package{
+			Name:           pkg.Package.Name,
+			Registry:       pkg.Package.Registry,
+			ConfigFilePath: filePath,
+			CurrentVersion: pkg.Package.Version,
+		}
+		results = append(results, p)
+		c.checkPackage(ctx, logE, pkg, p)
+	}
+	return results
+}
+
+func (c *Controller) checkPackage(ctx context.Context, logE *logrus.Entry, pkg *config.Package, p *Package) {
+	if !pkg.Package.Update.GetEnabled() {
+		p.SkipReason = "update is disabled"
+		return
+	}
+	if pkg.Package.Pin {
+		p.SkipReason = "the version is fixed by the field version"
+		return
+	}
+	if commitHashPattern.MatchString(pkg.Package.Version) {
+		p.SkipReason = "the version is a commit hash"
+		return
+	}
+	var rule *versiongetter.UpdateRule
+	if u := pkg.Package.Update; u != nil {
+		r, err := versiongetter.NewUpdateRule(pkg.Package.Version, u.Types, u.AllowedVersion, u.MinAge)
+		if err != nil {
+			logerr.WithError(logE, err).Warn("the field update is invalid")
+			p.SkipReason = "the field update is invalid"
+			return
+		}
+		rule = r
+	}
+	filters, err := versiongetter.CreateFilters(pkg.PackageInfo)
+	if err != nil {
+		logerr.WithError(logE, err).Warn("create filters")
+		p.SkipReason = "failed to get the latest version"
+		return
+	}
+	versiongetter.SetUpdateRule(filters, rule)
+	latest, err := c.versionGetter.Get(ctx, logE, pkg.PackageInfo, filters)
+	if err != nil {
+		logerr.WithError(logE, err).Warn("get the latest version of the package")
+		p.SkipReason = "failed to get the latest version"
+		return
+	}
+	if latest == "" {
+		if rule == nil {
+			p.SkipReason = "the latest version isn't found"
+		}
+		// No version is allowed by the field update, so the package is up to date.
+		return
+	}
+	p.LatestVersion = latest
+	// Some version getters don't support filters, so the update rule is checked again.
+	if !rule.Match(latest) {
+		p.SkipReason = "the latest version isn't allowed by the field update"
+		return
+	}
+	p.UpdateType = versiongetter.GetUpdateType(p.CurrentVersion, latest)
+}
+
+func (c *Controller) output(result *Result, format string) error {
+	if format == formatJSON {
+		encoder := json.NewEncoder(c.stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			return fmt.Errorf("encode the result as JSON: %w", err)
+		}
+		return nil
+	}
+	w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0) //nolint:mnd
+	if len(result.Registries) != 0 {
+		fmt.Fprintln(w, "REGISTRY\tCURRENT\tLATEST\tTYPE\tNOTE")
+		for _, r := range result.Registries {
+			fmt.Fprintln(w, strings.Join([]string{r.Name, r.CurrentVersion, r.LatestVersion, r.UpdateType, r.SkipReason}, "\t"))
+		}
+	}
+	if len(result.Packages) != 0 {
+		if len(result.Registries) != 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, "PACKAGE\tREGISTRY\tCURRENT\tLATEST\tTYPE\tNOTE")
+		for _, p := range result.Packages {
+			fmt.Fprintln(w, strings.Join([]string{p.Name, p.Registry, p.CurrentVersion, p.LatestVersion, p.UpdateType, p.SkipReason}, "\t"))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("output the result: %w", err)
+	}
+	return nil
+}
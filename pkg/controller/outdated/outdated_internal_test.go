@@ -0,0 +1,189 @@
+package outdated
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	finder "github.com/aquaproj/aqua/v2/pkg/config-finder"
+	reader "github.com/aquaproj/aqua/v2/pkg/config-reader"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/github"
+	rgst "github.com/aquaproj/aqua/v2/pkg/install-registry"
+	"github.com/aquaproj/aqua/v2/pkg/ptr"
+	"github.com/aquaproj/aqua/v2/pkg/testutil"
+	"github.com/aquaproj/aqua/v2/pkg/versiongetter"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+func TestController_outdated(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	registries := map[string]*registry.Config{
+		"standard": {
+			PackageInfos: registry.PackageInfos{
+				{
+					Type:      "github_release",
+					RepoOwner: "suzuki-shunsuke",
+					RepoName:  "tfcmt",
+					Asset:     "tfcmt_{{.OS}}_{{.Arch}}.tar.gz",
+				},
+				{
+					Type:      "github_release",
+					RepoOwner: "cli",
+					RepoName:  "cli",
+					Asset:     "gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.zip",
+				},
+				{
+					Type:      "github_release",
+					RepoOwner: "gohugoio",
+					RepoName:  "hugo",
+					Asset:     "hugo_{{trimV .Version}}_{{.OS}}_{{.Arch}}.tar.gz",
+				},
+			},
+		},
+	}
+	releases := []*github.RepositoryRelease{
+		{TagName: ptr.String("v4.60.0")},
+		{TagName: ptr.String("v3.2.0")},
+		{TagName: ptr.String("v3.0.1")},
+		{TagName: ptr.String("v2.0.0")},
+	}
+	data := []struct {
+		name  string
+		files map[string]string
+		param *config.Param
+		exp   *Result
+		isErr bool
+	}{
+		{
+			name: "normal",
+			param: &config.Param{
+				PWD: "/workspace",
+			},
+			files: map[string]string{
+				"/workspace/aqua.yaml": `registries:
+- type: standard
+  ref: v4.0.0
+packages:
+- name: suzuki-shunsuke/tfcmt@v3.0.0
+  update:
+    types: [minor, patch]
+- name: cli/cli@v4.60.0
+- name: gohugoio/hugo
+  version: v0.118.0
+`,
+			},
+			exp: &Result{
+				Registries: []*Registry{
+					{
+						Name:           "standard",
+						CurrentVersion: "v4.0.0",
+						LatestVersion:  "v4.60.0",
+						UpdateType:     "minor",
+					},
+				},
+				Packages: []*Package{
+					{
+						Name:           "suzuki-shunsuke/tfcmt",
+						Registry:       "standard",
+						ConfigFilePath: "/workspace/aqua.yaml",
+						CurrentVersion: "v3.0.0",
+						LatestVersion:  "v3.2.0",
+						UpdateType:     "minor",
+					},
+					{
+						Name:           "cli/cli",
+						Registry:       "standard",
+						ConfigFilePath: "/workspace/aqua.yaml",
+						CurrentVersion: "v4.60.0",
+						LatestVersion:  "v4.60.0",
+					},
+					{
+						Name:           "gohugoio/hugo",
+						Registry:       "standard",
+						ConfigFilePath: "/workspace/aqua.yaml",
+						CurrentVersion: "v0.118.0",
+						SkipReason:     "the version is fixed by the field version",
+					},
+				},
+			},
+		},
+		{
+			name: "only package and allowed_version",
+			param: &config.Param{
+				PWD:         "/workspace",
+				OnlyPackage: true,
+			},
+			files: map[string]string{
+				"/workspace/aqua.yaml": `registries:
+- type: standard
+  ref: v4.0.0
+packages:
+- name: suzuki-shunsuke/tfcmt@v2.0.0
+  update:
+    allowed_version: semver("< 3.1.0")
+`,
+			},
+			exp: &Result{
+				Registries: []*Registry{},
+				Packages: []*Package{
+					{
+						Name:           "suzuki-shunsuke/tfcmt",
+						Registry:       "standard",
+						ConfigFilePath: "/workspace/aqua.yaml",
+						CurrentVersion: "v2.0.0",
+						LatestVersion:  "v3.0.1",
+						UpdateType:     "major",
+					},
+				},
+			},
+		},
+		{
+			name: "config file isn't found",
+			param: &config.Param{
+				PWD: "/workspace",
+			},
+			isErr: true,
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			fs, err := testutil.NewFs(d.files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gh := &github.MockRepositoriesService{
+				Releases: releases,
+			}
+			versionGetter := versiongetter.NewGeneralVersionGetter(nil, versiongetter.NewGitHubTag(gh), versiongetter.NewGitHubRelease(gh), nil)
+			ctrl := New(gh, finder.NewConfigFinder(fs), reader.New(fs, d.param), &rgst.MockInstaller{M: registries}, fs, versionGetter)
+			result, err := ctrl.outdated(t.Context(), logE, d.param)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(d.exp, result); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestController_Outdated_invalidFormat(t *testing.T) {
+	t.Parallel()
+	ctrl := &Controller{}
+	err := ctrl.Outdated(t.Context(), logrus.NewEntry(logrus.New()), &config.Param{
+		OutputFormat: "yaml",
+	})
+	if !errors.Is(err, errInvalidFormat) {
+		t.Fatalf("wanted errInvalidFormat, got %v", err)
+	}
+}
@@ -63,5 +63,5 @@ type RegistryInstaller interface {
 }
 
 type Vacuum interface {
-	Remove(pkgPath string) error
+	RemoveMatched(pattern string) error
 }
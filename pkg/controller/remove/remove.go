@@ -209,8 +209,8 @@ func (c *Controller) removePackage(logE *logrus.Entry, rootDir string, pkg *regi
 		if err := c.removePath(logE, rootDir, path); err != nil {
 			return err
 		}
-		if err := c.removeMetadataPath(logE, rootDir, path); err != nil {
-			return err
+		if err := c.vacuum.RemoveMatched(filepath.Join("pkgs", path)); err != nil {
+			return fmt.Errorf("remove timestamps of the package: %w", err)
 		}
 	}
 	return gErr
@@ -231,21 +231,6 @@ func (c *Controller) removePath(logE *logrus.Entry, rootDir string, path string)
 	return nil
 }
 
-func (c *Controller) removeMetadataPath(logE *logrus.Entry, rootDir string, path string) error {
-	pkgPath := filepath.Join(rootDir, "metadata", "pkgs", path)
-	arr, err := afero.Glob(c.fs, pkgPath)
-	if err != nil {
-		return fmt.Errorf("find directories: %w", err)
-	}
-	for _, p := range arr {
-		logE.WithField("removed_path", p).Debug("removing a directory")
-		if err := c.fs.RemoveAll(p); err != nil {
-			return fmt.Errorf("remove directories: %w", err)
-		}
-	}
-	return nil
-}
-
 func parsePkgName(pkgName string) (string, string) {
 	registryName, pkgName, ok := strings.Cut(pkgName, ",")
 	if ok {
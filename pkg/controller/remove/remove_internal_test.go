@@ -1,6 +1,17 @@
 package remove
 
-import "testing"
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/vacuum"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
 
 func Test_parsePkgName(t *testing.T) {
 	t.Parallel()
@@ -37,3 +48,49 @@ func Test_parsePkgName(t *testing.T) {
 		})
 	}
 }
+
+func TestController_removePackage(t *testing.T) {
+	t.Parallel()
+	const rootDir = "/home/foo/.local/share/aquaproj-aqua"
+	fs := afero.NewMemMapFs()
+	files := map[string]string{
+		rootDir + "/pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip/gh": "",
+		rootDir + "/metadata/timestamps.json": `{"packages": {
+  "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip": "2025-01-01T00:15:00+09:00",
+  "pkgs/github_release/github.com/cli/cli/v2.60.0/gh_2.60.0_macOS_arm64.zip": "2025-01-20T00:15:00+09:00",
+  "pkgs/github_release/github.com/cli/cli-extra/v1.0.0/cli-extra.tar.gz": "2025-01-20T00:15:00+09:00"
+}}`,
+	}
+	for name, body := range files {
+		if err := afero.WriteFile(fs, name, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	vc := vacuum.New(fs, &config.Param{RootDir: rootDir})
+	ctrl := &Controller{
+		fs:     fs,
+		mode:   &config.RemoveMode{Package: true},
+		vacuum: vc,
+	}
+	logE := logrus.NewEntry(logrus.New())
+	if err := ctrl.removePackage(logE, rootDir, &registry.PackageInfo{
+		Type:      "github_release",
+		RepoOwner: "cli",
+		RepoName:  "cli",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if f, err := afero.Exists(fs, rootDir+"/pkgs/github_release/github.com/cli/cli"); err != nil {
+		t.Fatal(err)
+	} else if f {
+		t.Fatal("the package directory must be removed")
+	}
+	timestamps, err := vc.FindAll(logE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkgPaths := slices.Sorted(maps.Keys(timestamps))
+	if diff := cmp.Diff([]string{"pkgs/github_release/github.com/cli/cli-extra/v1.0.0/cli-extra.tar.gz"}, pkgPaths); diff != "" {
+		t.Fatal(diff)
+	}
+}
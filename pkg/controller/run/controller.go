@@ -0,0 +1,56 @@
+package run
+
+import (
+	"context"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/controller/which"
+	"github.com/aquaproj/aqua/v2/pkg/github"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+type Controller struct {
+	configFinder ConfigFinder
+	configReader ConfigReader
+	resolver     PackageResolver
+	executor     PackageExecutor
+	github       RepositoriesService
+	runtime      *runtime.Runtime
+	fs           afero.Fs
+}
+
+func New(configFinder ConfigFinder, configReader ConfigReader, resolver PackageResolver, executor PackageExecutor, gh RepositoriesService, rt *runtime.Runtime, fs afero.Fs) *Controller {
+	return &Controller{
+		fs:           fs,
+		configFinder: configFinder,
+		configReader: configReader,
+		resolver:     resolver,
+		executor:     executor,
+		github:       gh,
+		runtime:      rt,
+	}
+}
+
+type ConfigFinder interface {
+	Find(wd, configFilePath string, globalConfigFilePaths ...string) (string, error)
+}
+
+type ConfigReader interface {
+	Read(logE *logrus.Entry, configFilePath string, cfg *aqua.Config) error
+}
+
+type PackageResolver interface {
+	Resolve(ctx context.Context, logE *logrus.Entry, param *config.Param, cfg *aqua.Config, cfgFilePath string, checksums *checksum.Checksums, pkgName string) (*config.Package, error)
+}
+
+type PackageExecutor interface {
+	ExecPackage(ctx context.Context, logE *logrus.Entry, param *config.Param, findResult *which.FindResult, exeName string, args ...string) error
+}
+
+type RepositoriesService interface {
+	GetLatestRelease(ctx context.Context, repoOwner, repoName string) (*github.RepositoryRelease, *github.Response, error)
+}
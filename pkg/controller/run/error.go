@@ -0,0 +1,11 @@
+package run
+
+import "errors"
+
+var (
+	errUnsupportedPkg   = errors.New("the package isn't supported on this environment")
+	errCommandNotFound  = errors.New("the package doesn't have the command")
+	errPkgHasNoCommands = errors.New("the package has no command")
+	// errChecksumFileNotFound is returned if checksums are required but there is no configuration file to find aqua-checksums.json.
+	errChecksumFileNotFound = errors.New("checksum is required but no configuration file is found, so checksums can't be verified")
+)
@@ -0,0 +1,150 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	finder "github.com/aquaproj/aqua/v2/pkg/config-finder"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/controller/initcmd"
+	"github.com/aquaproj/aqua/v2/pkg/controller/which"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// Run installs a package and executes the command without adding the package to configuration files.
+// The package is searched from registries of the configuration file.
+// If no configuration file is found, the latest standard registry is used.
+// Checksums are verified with aqua-checksums.json, but aqua-checksums.json isn't updated.
+func (c *Controller) Run(ctx context.Context, logE *logrus.Entry, param *config.Param, pkgName, exeName string, args ...string) error {
+	cfg, cfgFilePath, err := c.readConfig(ctx, logE, param)
+	if err != nil {
+		return err
+	}
+
+	checksums, err := c.readChecksums(logE, param, cfg, cfgFilePath)
+	if err != nil {
+		return err
+	}
+
+	pkg, err := c.resolver.Resolve(ctx, logE, param, cfg, cfgFilePath, checksums, pkgName)
+	if err != nil {
+		return fmt.Errorf("find the package: %w", err)
+	}
+	logE = logE.WithFields(logrus.Fields{
+		"registry_name":   pkg.Package.Registry,
+		"package_name":    pkg.Package.Name,
+		"package_version": pkg.Package.Version,
+	})
+
+	pkgInfo, err := pkg.PackageInfo.Override(logE, pkg.Package.Version, c.runtime)
+	if err != nil {
+		return fmt.Errorf("evaluate version constraints: %w", logerr.WithFields(err, logE.Data))
+	}
+	supported, err := pkgInfo.CheckSupported(c.runtime, c.runtime.GOOS+"/"+c.runtime.GOARCH)
+	if err != nil {
+		return fmt.Errorf("check if the package is supported: %w", logerr.WithFields(err, logE.Data))
+	}
+	if !supported {
+		return logerr.WithFields(errUnsupportedPkg, logE.Data) //nolint:wrapcheck
+	}
+	pkg.PackageInfo = pkgInfo
+	pkg.Registry = cfg.Registries[pkg.Package.Registry]
+	if err := pkg.ApplyVars(); err != nil {
+		return fmt.Errorf("apply package variables: %w", logerr.WithFields(err, logE.Data))
+	}
+
+	file, err := getFile(pkgInfo, exeName)
+	if err != nil {
+		return logerr.WithFields(err, logE.Data) //nolint:wrapcheck
+	}
+	exePath, err := which.GetExePath(param.RootDir, c.runtime, pkg, file)
+	if err != nil {
+		return fmt.Errorf("get the execution file path: %w", logerr.WithFields(err, logE.Data))
+	}
+
+	return c.executor.ExecPackage(ctx, logE, param, &which.FindResult{ //nolint:wrapcheck
+		Package:        pkg,
+		File:           file,
+		Config:         cfg,
+		ExePath:        exePath,
+		ConfigFilePath: cfgFilePath,
+		Checksums:      checksums,
+	}, file.Name, args...)
+}
+
+func (c *Controller) readConfig(ctx context.Context, logE *logrus.Entry, param *config.Param) (*aqua.Config, string, error) {
+	cfgFilePath, err := c.configFinder.Find(param.PWD, param.ConfigFilePath, param.GlobalConfigFilePaths...)
+	if err != nil {
+		if !errors.Is(err, finder.ErrConfigFileNotFound) {
+			return nil, "", err //nolint:wrapcheck
+		}
+		logE.Debug("no configuration file is found, so the standard registry is used")
+		return &aqua.Config{
+			Registries: aqua.Registries{
+				aqua.RegistryTypeStandard: {
+					Name:      aqua.RegistryTypeStandard,
+					Type:      aqua.RegistryTypeGitHubContent,
+					RepoOwner: "aquaproj",
+					RepoName:  "aqua-registry",
+					Ref:       initcmd.GetStandardRegistryVersion(ctx, logE, c.github),
+					Path:      "registry.yaml",
+				},
+			},
+		}, "", nil
+	}
+	cfg := &aqua.Config{}
+	if err := c.configReader.Read(logE, cfgFilePath, cfg); err != nil {
+		return nil, "", err //nolint:wrapcheck
+	}
+	return cfg, cfgFilePath, nil
+}
+
+// readChecksums reads the checksum file of the configuration file.
+// Checksums of the executed package are added only in memory, so the update function of checksum.Open isn't called.
+// If no configuration file is found, checksums can't be pinned, so an error is returned when checksums are required.
+func (c *Controller) readChecksums(logE *logrus.Entry, param *config.Param, cfg *aqua.Config, cfgFilePath string) (*checksum.Checksums, error) {
+	if !param.ChecksumEnabled(cfg) {
+		return nil, nil //nolint:nilnil
+	}
+	if cfgFilePath == "" {
+		if cfg.RequireChecksum(param.EnforceRequireChecksum, param.RequireChecksum) {
+			return nil, errChecksumFileNotFound
+		}
+		return checksum.New(), nil
+	}
+	checksums, _, err := checksum.Open(logE, c.fs, cfgFilePath, true)
+	if err != nil {
+		return nil, fmt.Errorf("read a checksum JSON: %w", err)
+	}
+	return checksums, nil
+}
+
+// getFile returns the file of the executed command.
+// If exeName is empty, the first file of the package is returned.
+func getFile(pkgInfo *registry.PackageInfo, exeName string) (*registry.File, error) {
+	files := pkgInfo.GetFiles()
+	if len(files) == 0 {
+		return nil, errPkgHasNoCommands
+	}
+	if exeName == "" {
+		return files[0], nil
+	}
+	for _, file := range files {
+		if file.Name == exeName {
+			return file, nil
+		}
+	}
+	cmds := make([]string, len(files))
+	for i, file := range files {
+		cmds[i] = file.Name
+	}
+	return nil, logerr.WithFields(errCommandNotFound, logrus.Fields{ //nolint:wrapcheck
+		"exe_name": exeName,
+		"commands": cmds,
+	})
+}
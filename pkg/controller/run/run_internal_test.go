@@ -0,0 +1,182 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/testutil"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+func Test_getFile(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name    string
+		pkgInfo *registry.PackageInfo
+		exeName string
+		exp     *registry.File
+		isErr   bool
+	}{
+		{
+			name: "default file",
+			pkgInfo: &registry.PackageInfo{
+				Type:      "github_release",
+				RepoOwner: "suzuki-shunsuke",
+				RepoName:  "tfcmt",
+			},
+			exp: &registry.File{
+				Name: "tfcmt",
+			},
+		},
+		{
+			name: "first file",
+			pkgInfo: &registry.PackageInfo{
+				Type:      "github_release",
+				RepoOwner: "kubernetes-sigs",
+				RepoName:  "kustomize",
+				Files: []*registry.File{
+					{Name: "kustomize"},
+					{Name: "kustomize-plugin"},
+				},
+			},
+			exp: &registry.File{
+				Name: "kustomize",
+			},
+		},
+		{
+			name: "command is specified",
+			pkgInfo: &registry.PackageInfo{
+				Type:      "github_release",
+				RepoOwner: "kubernetes-sigs",
+				RepoName:  "kustomize",
+				Files: []*registry.File{
+					{Name: "kustomize"},
+					{Name: "kustomize-plugin"},
+				},
+			},
+			exeName: "kustomize-plugin",
+			exp: &registry.File{
+				Name: "kustomize-plugin",
+			},
+		},
+		{
+			name: "command isn't found",
+			pkgInfo: &registry.PackageInfo{
+				Type:      "github_release",
+				RepoOwner: "suzuki-shunsuke",
+				RepoName:  "tfcmt",
+			},
+			exeName: "gh",
+			isErr:   true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			file, err := getFile(d.pkgInfo, d.exeName)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(d.exp, file); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestController_readChecksums(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	const checksumID = "github_release/github.com/suzuki-shunsuke/tfcmt/v4.14.0/tfcmt_linux_amd64.tar.gz"
+	data := []struct {
+		name        string
+		files       map[string]string
+		cfg         *aqua.Config
+		cfgFilePath string
+		param       *config.Param
+		exp         *checksum.Checksum
+		isNil       bool
+		isErr       bool
+	}{
+		{
+			name:  "disabled",
+			cfg:   &aqua.Config{},
+			param: &config.Param{},
+			isNil: true,
+		},
+		{
+			name: "pinned checksums are read",
+			files: map[string]string{
+				"/home/foo/workspace/aqua-checksums.json": `{"checksums": [{"id": "` + checksumID + `", "checksum": "ABC", "algorithm": "sha256"}]}`,
+			},
+			cfg:         &aqua.Config{},
+			cfgFilePath: "/home/foo/workspace/aqua.yaml",
+			param: &config.Param{
+				EnforceChecksum: true,
+			},
+			exp: &checksum.Checksum{
+				ID:        checksumID,
+				Checksum:  "ABC",
+				Algorithm: "sha256",
+			},
+		},
+		{
+			name: "no configuration file",
+			cfg:  &aqua.Config{},
+			param: &config.Param{
+				EnforceChecksum: true,
+			},
+		},
+		{
+			name: "checksum is required but no configuration file",
+			cfg:  &aqua.Config{},
+			param: &config.Param{
+				EnforceChecksum:        true,
+				EnforceRequireChecksum: true,
+			},
+			isErr: true,
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			fs, err := testutil.NewFs(d.files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctrl := &Controller{fs: fs}
+			checksums, err := ctrl.readChecksums(logE, d.param, d.cfg, d.cfgFilePath)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if d.isNil {
+				if checksums != nil {
+					t.Fatal("checksums must be nil")
+				}
+				return
+			}
+			if checksums == nil {
+				t.Fatal("checksums must not be nil")
+			}
+			if diff := cmp.Diff(d.exp, checksums.Get(checksumID)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
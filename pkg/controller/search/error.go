@@ -0,0 +1,9 @@
+package search
+
+import "errors"
+
+var (
+	errQueryIsRequired  = errors.New("query is required")
+	errRegistryNotFound = errors.New("the registry isn't found")
+	errInvalidFormat    = errors.New("the output format must be either json or table")
+)
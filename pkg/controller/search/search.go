@@ -0,0 +1,219 @@
+package search
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+type Param struct {
+	Query string
+	// Registry limits searched registries. If it's empty, all registries are searched.
+	Registry string
+	// Limit is the maximum number of results. Non-positive number refers to no limit.
+	Limit int
+}
+
+const (
+	formatJSON  = "json"
+	formatTable = "table"
+)
+
+// Result is a package matching the query.
+type Result struct {
+	Registry    string   `json:"registry"`
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Aliases     []string `json:"aliases,omitempty"`
+	SearchWords []string `json:"search_words,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Link        string   `json:"link,omitempty"`
+	Score       int      `json:"score"`
+}
+
+// Search searches packages in registries of the configuration file without the interactive fuzzy finder.
+// Packages are ranked by the package name, aliases, search_words, and description.
+// The result is outputted in the format param.OutputFormat, which is either json or table (default).
+func (c *Controller) Search(ctx context.Context, logE *logrus.Entry, param *config.Param, searchParam *Param) error {
+	switch param.OutputFormat {
+	case "", formatJSON, formatTable:
+	default:
+		return errInvalidFormat
+	}
+	terms := strings.Fields(strings.ToLower(searchParam.Query))
+	if len(terms) == 0 {
+		return errQueryIsRequired
+	}
+
+	cfgFilePath, err := c.configFinder.Find(param.PWD, param.ConfigFilePath, param.GlobalConfigFilePaths...)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	cfg := &aqua.Config{}
+	if err := c.configReader.Read(logE, cfgFilePath, cfg); err != nil {
+		return err //nolint:wrapcheck
+	}
+	if searchParam.Registry != "" {
+		rgst, ok := cfg.Registries[searchParam.Registry]
+		if !ok {
+			return logerr.WithFields(errRegistryNotFound, logrus.Fields{ //nolint:wrapcheck
+				"registry_name": searchParam.Registry,
+			})
+		}
+		cfg.Registries = aqua.Registries{
+			searchParam.Registry: rgst,
+		}
+	}
+
+	checksums, updateChecksum, err := checksum.Open(
+		logE, c.fs, cfgFilePath,
+		param.ChecksumEnabled(cfg))
+	if err != nil {
+		return fmt.Errorf("read a checksum JSON: %w", err)
+	}
+	defer updateChecksum()
+
+	registryContents, err := c.registryInstaller.InstallRegistries(ctx, logE, cfg, cfgFilePath, checksums)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	results := search(registryContents, terms)
+	if searchParam.Limit > 0 && len(results) > searchParam.Limit {
+		results = results[:searchParam.Limit]
+	}
+	return c.output(results, param.OutputFormat)
+}
+
+// search returns packages matching all terms sorted by the score.
+// Packages of split registries whose package files aren't installed are searched by names and aliases.
+func search(registryContents map[string]*registry.Config, terms []string) []*Result {
+	results := []*Result{}
+	for registryName, registryContent := range registryContents {
+		names := map[string]struct{}{}
+		for _, pkgInfo := range slices.Concat(registryContent.PackageInfos, registryContent.UnloadedPackageInfos()) {
+			if pkgInfo == nil {
+				continue
+			}
+			name := pkgInfo.GetName()
+			if name == "" {
+				continue
+			}
+			if _, ok := names[name]; ok {
+				continue
+			}
+			names[name] = struct{}{}
+			result := newResult(registryName, name, pkgInfo)
+			score := result.score(terms)
+			if score == 0 {
+				continue
+			}
+			result.Score = score
+			results = append(results, result)
+		}
+	}
+	slices.SortFunc(results, func(a, b *Result) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Registry, b.Registry), cmp.Compare(a.Name, b.Name))
+	})
+	return results
+}
+
+func newResult(registryName, name string, pkgInfo *registry.PackageInfo) *Result {
+	result := &Result{
+		Registry:    registryName,
+		Name:        name,
+		Type:        pkgInfo.Type,
+		SearchWords: pkgInfo.SearchWords,
+		Description: pkgInfo.Description,
+		Link:        pkgInfo.GetLink(),
+	}
+	for _, alias := range pkgInfo.Aliases {
+		if alias.Name == "" {
+			continue
+		}
+		result.Aliases = append(result.Aliases, alias.Name)
+	}
+	return result
+}
+
+// Scores of each field.
+// A package name is weighted most, and the description is weighted least.
+const (
+	scoreNameExact         = 100
+	scoreAliasExact        = 90
+	scoreNamePrefix        = 60
+	scoreNameContain       = 50
+	scoreAliasContain      = 40
+	scoreSearchWordExact   = 30
+	scoreSearchWordContain = 20
+	scoreDescription       = 10
+)
+
+// score returns the sum of the highest score of each term.
+// If any term doesn't match the package, score returns 0.
+func (r *Result) score(terms []string) int {
+	total := 0
+	for _, term := range terms {
+		s := r.scoreTerm(term)
+		if s == 0 {
+			return 0
+		}
+		total += s
+	}
+	return total
+}
+
+func (r *Result) scoreTerm(term string) int {
+	name := strings.ToLower(r.Name)
+	baseName := name[strings.LastIndex(name, "/")+1:]
+	switch {
+	case name == term || baseName == term:
+		return scoreNameExact
+	case slices.ContainsFunc(r.Aliases, func(alias string) bool { return strings.EqualFold(alias, term) }):
+		return scoreAliasExact
+	case strings.HasPrefix(baseName, term):
+		return scoreNamePrefix
+	case strings.Contains(name, term):
+		return scoreNameContain
+	case slices.ContainsFunc(r.Aliases, func(alias string) bool { return strings.Contains(strings.ToLower(alias), term) }):
+		return scoreAliasContain
+	case slices.ContainsFunc(r.SearchWords, func(word string) bool { return strings.EqualFold(word, term) }):
+		return scoreSearchWordExact
+	case slices.ContainsFunc(r.SearchWords, func(word string) bool { return strings.Contains(strings.ToLower(word), term) }):
+		return scoreSearchWordContain
+	case strings.Contains(strings.ToLower(r.Description), term):
+		return scoreDescription
+	default:
+		return 0
+	}
+}
+
+func (c *Controller) output(results []*Result, format string) error {
+	if format == formatJSON {
+		encoder := json.NewEncoder(c.stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(results); err != nil {
+			return fmt.Errorf("encode the search result as JSON: %w", err)
+		}
+		return nil
+	}
+	w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0) //nolint:mnd
+	for _, result := range results {
+		fmt.Fprintln(w, result.Registry+","+result.Name+"\t"+strings.Join(strings.Fields(result.Description), " "))
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("output the search result: %w", err)
+	}
+	return nil
+}
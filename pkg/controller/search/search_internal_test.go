@@ -0,0 +1,134 @@
+package search
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+func Test_search(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	registryContents := map[string]*registry.Config{
+		"standard": {
+			PackageInfos: registry.PackageInfos{
+				{
+					Type:        "github_release",
+					RepoOwner:   "cli",
+					RepoName:    "cli",
+					Description: "GitHub’s official command line tool",
+					Aliases: []*registry.Alias{
+						{Name: "github/cli"},
+					},
+				},
+				{
+					Type:        "github_release",
+					RepoOwner:   "suzuki-shunsuke",
+					RepoName:    "github-comment",
+					Description: "CLI to create and hide GitHub comments",
+				},
+				{
+					Type:        "github_release",
+					RepoOwner:   "int128",
+					RepoName:    "ghcp",
+					SearchWords: []string{"github", "commit"},
+				},
+				{
+					Type:        "github_release",
+					RepoOwner:   "suzuki-shunsuke",
+					RepoName:    "tfcmt",
+					Description: "Fork of mercari/tfnotify",
+				},
+			},
+		},
+		"split": {
+			PackageInfos: registry.PackageInfos{
+				{
+					Type:        "github_release",
+					RepoOwner:   "suzuki-shunsuke",
+					RepoName:    "ghalint",
+					Description: "Linter of workflows",
+				},
+			},
+			PackageFiles: []*registry.PackageFile{
+				{Name: "suzuki-shunsuke/ghalint"},
+				{
+					Name:    "suzuki-shunsuke/pinact",
+					Aliases: []*registry.Alias{{Name: "suzuki-shunsuke/pinner"}},
+				},
+			},
+		},
+	}
+	data := []struct {
+		name  string
+		terms []string
+		exp   []string
+	}{
+		{
+			name:  "rank by fields",
+			terms: []string{"github"},
+			exp: []string{
+				"standard,suzuki-shunsuke/github-comment:60",
+				"standard,cli/cli:40",
+				"standard,int128/ghcp:30",
+			},
+		},
+		{
+			name:  "all terms must match",
+			terms: []string{"github", "comment"},
+			exp: []string{
+				"standard,suzuki-shunsuke/github-comment:110",
+			},
+		},
+		{
+			name:  "exact name",
+			terms: []string{"cli"},
+			exp: []string{
+				"standard,cli/cli:100",
+				"standard,suzuki-shunsuke/github-comment:10",
+			},
+		},
+		{
+			name:  "package files of a split registry",
+			terms: []string{"pin"},
+			exp: []string{
+				"split,suzuki-shunsuke/pinact:60",
+			},
+		},
+		{
+			name:  "no match",
+			terms: []string{"terraform"},
+			exp:   []string{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			results := search(registryContents, d.terms)
+			arr := make([]string, len(results))
+			for i, result := range results {
+				arr[i] = result.Registry + "," + result.Name + ":" + strconv.Itoa(result.Score)
+			}
+			if diff := cmp.Diff(d.exp, arr); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestController_Search_invalidFormat(t *testing.T) {
+	t.Parallel()
+	ctrl := &Controller{}
+	err := ctrl.Search(t.Context(), logrus.NewEntry(logrus.New()), &config.Param{
+		OutputFormat: "yaml",
+	}, &Param{
+		Query: "terraform",
+	})
+	if !errors.Is(err, errInvalidFormat) {
+		t.Fatalf("wanted errInvalidFormat, got %v", err)
+	}
+}
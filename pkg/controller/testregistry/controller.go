@@ -0,0 +1,37 @@
+package testregistry
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/aquaproj/aqua/v2/pkg/installpackage"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+type Controller struct {
+	stdout              io.Writer
+	fs                  afero.Fs
+	newPackageInstaller NewPackageInstaller
+}
+
+func New(fs afero.Fs) *Controller {
+	return &Controller{
+		stdout: os.Stdout,
+		fs:     fs,
+	}
+}
+
+type PackageInstaller interface {
+	InstallPackage(ctx context.Context, logE *logrus.Entry, param *installpackage.ParamInstallPackage) error
+}
+
+// NewPackageInstaller returns a PackageInstaller to install packages for the runtime into rootDir.
+type NewPackageInstaller func(rt *runtime.Runtime, rootDir string) (PackageInstaller, error)
+
+// SetNewPackageInstaller sets the function to create package installers used by --fixture-dir.
+func (c *Controller) SetNewPackageInstaller(f NewPackageInstaller) {
+	c.newPackageInstaller = f
+}
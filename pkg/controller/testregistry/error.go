@@ -0,0 +1,13 @@
+package testregistry
+
+import "errors"
+
+var (
+	errTestdataFileRequired  = errors.New("a testdata file is required")
+	errPackageNotFound       = errors.New("the package isn't found in the registry")
+	errVersionRequired       = errors.New("the package version is required")
+	errPlanChanged           = errors.New("the download plan is different from the golden file")
+	errInstallFailed         = errors.New("the package can't be installed in some environments")
+	errPackageInstallerIsNil = errors.New("the package installer isn't set")
+	errUnknownChecksumType   = errors.New("unknown checksum type")
+)
@@ -0,0 +1,171 @@
+package testregistry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/osfile"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v2"
+)
+
+// checkGolden compares the plan with the golden file and outputs differences.
+// If the golden file isn't given, the plan is output.
+// If --update-golden is set, the golden file is updated.
+func (c *Controller) checkGolden(logE *logrus.Entry, param *config.Param, plan *Plan) error {
+	b, err := yaml.Marshal(plan)
+	if err != nil {
+		return fmt.Errorf("encode the download plan as YAML: %w", err)
+	}
+	if param.GoldenFilePath == "" {
+		if _, err := c.stdout.Write(b); err != nil {
+			return fmt.Errorf("output the download plan: %w", err)
+		}
+		return nil
+	}
+	if param.UpdateGolden {
+		if err := afero.WriteFile(c.fs, param.GoldenFilePath, b, osfile.FilePermission); err != nil {
+			return fmt.Errorf("update the golden file: %w", err)
+		}
+		logE.WithField("golden_file", param.GoldenFilePath).Info("the golden file was updated")
+		return nil
+	}
+	gb, err := afero.ReadFile(c.fs, param.GoldenFilePath)
+	if err != nil {
+		return fmt.Errorf("read the golden file: %w", err)
+	}
+	golden := &Plan{}
+	if err := yaml.Unmarshal(gb, golden); err != nil {
+		return fmt.Errorf("parse the golden file as YAML: %w", err)
+	}
+	diffs, err := diffPlans(golden, plan)
+	if err != nil {
+		return err
+	}
+	if len(diffs) == 0 {
+		return nil
+	}
+	for _, d := range diffs {
+		fmt.Fprint(c.stdout, d)
+	}
+	return errPlanChanged
+}
+
+type envPlanKey struct {
+	name    string
+	version string
+	env     string
+}
+
+func (k envPlanKey) String() string {
+	return fmt.Sprintf("%s@%s (%s)", k.name, k.version, k.env)
+}
+
+type envPlanEntry struct {
+	key  envPlanKey
+	yaml string
+}
+
+func flattenPlan(plan *Plan) ([]*envPlanEntry, error) {
+	var entries []*envPlanEntry
+	for _, pp := range plan.Packages {
+		for _, ep := range pp.Envs {
+			b, err := yaml.Marshal(ep)
+			if err != nil {
+				return nil, fmt.Errorf("encode the download plan as YAML: %w", err)
+			}
+			entries = append(entries, &envPlanEntry{
+				key: envPlanKey{
+					name:    pp.Name,
+					version: pp.Version,
+					env:     ep.Env,
+				},
+				yaml: string(b),
+			})
+		}
+	}
+	return entries, nil
+}
+
+// diffPlans returns differences between plans per package version and environment.
+func diffPlans(golden, actual *Plan) ([]string, error) {
+	goldenEntries, err := flattenPlan(golden)
+	if err != nil {
+		return nil, err
+	}
+	actualEntries, err := flattenPlan(actual)
+	if err != nil {
+		return nil, err
+	}
+	goldenMap := make(map[envPlanKey]string, len(goldenEntries))
+	for _, e := range goldenEntries {
+		goldenMap[e.key] = e.yaml
+	}
+	actualKeys := make(map[envPlanKey]struct{}, len(actualEntries))
+	var diffs []string
+	for _, e := range actualEntries {
+		actualKeys[e.key] = struct{}{}
+		g, ok := goldenMap[e.key]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("%s: not found in the golden file\n%s", e.key, diffLines("", e.yaml)))
+			continue
+		}
+		if g != e.yaml {
+			diffs = append(diffs, fmt.Sprintf("%s:\n%s", e.key, diffLines(g, e.yaml)))
+		}
+	}
+	for _, e := range goldenEntries {
+		if _, ok := actualKeys[e.key]; !ok {
+			diffs = append(diffs, fmt.Sprintf("%s: not found in testdata\n%s", e.key, diffLines(e.yaml, "")))
+		}
+	}
+	return diffs, nil
+}
+
+// diffLines returns a line based diff of a and b.
+// Lines only in a are prefixed with "-" and lines only in b are prefixed with "+".
+func diffLines(a, b string) string {
+	x := splitLines(a)
+	y := splitLines(b)
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+				continue
+			}
+			lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+		}
+	}
+	buf := &strings.Builder{}
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			fmt.Fprintf(buf, "  %s\n", x[i])
+			i++
+			j++
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintf(buf, "- %s\n", x[i])
+			i++
+		default:
+			fmt.Fprintf(buf, "+ %s\n", y[j])
+			j++
+		}
+	}
+	return buf.String()
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
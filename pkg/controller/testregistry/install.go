@@ -0,0 +1,149 @@
+package testregistry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/installpackage"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// install installs packages with recorded fixtures which a local HTTP server serves instead of real servers.
+// The fixture of a URL is located at <fixture dir>/<host>/<path>.
+// e.g. https://github.com/foo/bar/releases/download/v1.0.0/bar.tar.gz => <fixture dir>/github.com/foo/bar/releases/download/v1.0.0/bar.tar.gz
+// Package versions whose assets aren't recorded are skipped.
+// Checksums are verified if checksum files are recorded,
+// but signatures, provenances, and attestations aren't verified because they can't be reproduced with fixtures.
+func (c *Controller) install(ctx context.Context, logE *logrus.Entry, fixtureDir string, targets []*target) error {
+	if c.newPackageInstaller == nil {
+		return errPackageInstallerIsNil
+	}
+	server := httptest.NewServer(http.FileServer(afero.NewHttpFs(c.fs).Dir(fixtureDir)))
+	defer server.Close()
+
+	rootDir, err := afero.TempDir(c.fs, "", "aqua-registry-test-")
+	if err != nil {
+		return fmt.Errorf("create a temporary root directory: %w", err)
+	}
+	defer func() {
+		if err := c.fs.RemoveAll(rootDir); err != nil {
+			logE.WithError(err).Warn("remove a temporary root directory")
+		}
+	}()
+
+	var failed []string
+	for i, t := range targets {
+		logE := logE.WithFields(logrus.Fields{
+			"package_name":    t.pkg.Package.Name,
+			"package_version": t.pkg.Package.Version,
+			"tested_env":      t.rt.Env(),
+		})
+		pkg, err := c.standIn(fixtureDir, server.URL, t)
+		if err != nil {
+			logerr.WithError(logE, err).Error("the package can't be installed")
+			failed = append(failed, fmt.Sprintf("%s@%s (%s)", t.pkg.Package.Name, t.pkg.Package.Version, t.rt.Env()))
+			continue
+		}
+		if pkg == nil {
+			logE.Debug("skip installing the package because the asset isn't recorded")
+			continue
+		}
+		// Each package is installed into its own root directory so that packages don't affect each other.
+		installer, err := c.newPackageInstaller(t.rt, filepath.Join(rootDir, strconv.Itoa(i)))
+		if err == nil {
+			err = installer.InstallPackage(ctx, logE, &installpackage.ParamInstallPackage{
+				Pkg:           pkg,
+				Checksums:     checksum.New(),
+				DisablePolicy: true,
+			})
+		}
+		if err != nil {
+			logerr.WithError(logE, err).Error("the package can't be installed")
+			failed = append(failed, fmt.Sprintf("%s@%s (%s)", t.pkg.Package.Name, t.pkg.Package.Version, t.rt.Env()))
+			continue
+		}
+		logE.Info("the package was installed successfully")
+	}
+	if len(failed) != 0 {
+		return logerr.WithFields(errInstallFailed, logrus.Fields{ //nolint:wrapcheck
+			"failed_packages": strings.Join(failed, ", "),
+		})
+	}
+	return nil
+}
+
+// standIn converts the package to a http package downloaded from the local HTTP server.
+// It returns nil if the asset isn't recorded.
+func (c *Controller) standIn(fixtureDir, serverURL string, t *target) (*config.Package, error) {
+	u, ok, err := c.fixtureURL(fixtureDir, serverURL, t.plan.URL)
+	if err != nil || !ok {
+		return nil, err
+	}
+	pi := t.pkg.PackageInfo.Copy()
+	// The package is already resolved for the environment.
+	pi.Type = registry.PkgInfoTypeHTTP
+	pi.URL = u
+	pi.Overrides = nil
+	pi.FormatOverrides = nil
+	pi.SupportedEnvs = nil
+	pi.VersionConstraints = ""
+	pi.VersionOverrides = nil
+	pi.Cosign = nil
+	pi.SLSAProvenance = nil
+	pi.Minisign = nil
+	pi.GitHubArtifactAttestations = nil
+	pi.GitHubImmutableRelease = false
+	pi.Checksum = nil
+	if t.plan.Checksum != nil {
+		cu, ok, err := c.fixtureURL(fixtureDir, serverURL, t.plan.Checksum.URL)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			chk := *t.pkg.PackageInfo.Checksum
+			chk.Type = registry.PkgInfoTypeHTTP
+			chk.URL = cu
+			chk.Cosign = nil
+			chk.Minisign = nil
+			chk.GitHubArtifactAttestations = nil
+			pi.Checksum = &chk
+		}
+	}
+	return &config.Package{
+		Package:     t.pkg.Package,
+		PackageInfo: pi,
+	}, nil
+}
+
+// fixtureURL returns the URL of the local HTTP server serving the fixture of the URL.
+// It returns false if the fixture isn't found.
+func (c *Controller) fixtureURL(fixtureDir, serverURL, rawURL string) (string, bool, error) {
+	if rawURL == "" {
+		return "", false, nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", false, fmt.Errorf("parse a URL: %w", err)
+	}
+	p := path.Join(u.Host, u.Path)
+	found, err := afero.Exists(c.fs, filepath.Join(fixtureDir, filepath.FromSlash(p)))
+	if err != nil {
+		return "", false, fmt.Errorf("check if the fixture exists: %w", err)
+	}
+	if !found {
+		return "", false, nil
+	}
+	return serverURL + "/" + p, true, nil
+}
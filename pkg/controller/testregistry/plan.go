@@ -0,0 +1,255 @@
+package testregistry
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/download"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/aquaproj/aqua/v2/pkg/template"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// Plan is the download plan of packages.
+// It's compared with the golden file.
+type Plan struct {
+	Packages []*PackagePlan `json:"packages"`
+}
+
+type PackagePlan struct {
+	Name    string     `json:"name"`
+	Version string     `json:"version"`
+	Envs    []*EnvPlan `json:"envs"`
+}
+
+// EnvPlan is what aqua downloads and verifies to install a package version in an environment.
+type EnvPlan struct {
+	Env                        string        `json:"env"`
+	Unsupported                bool          `yaml:",omitempty" json:"unsupported,omitempty"`
+	Type                       string        `yaml:",omitempty" json:"type,omitempty"`
+	Asset                      string        `yaml:",omitempty" json:"asset,omitempty"`
+	URL                        string        `yaml:",omitempty" json:"url,omitempty"`
+	Files                      []*FilePlan   `yaml:",omitempty" json:"files,omitempty"`
+	Checksum                   *ChecksumPlan `yaml:",omitempty" json:"checksum,omitempty"`
+	Cosign                     *CosignPlan   `yaml:",omitempty" json:"cosign,omitempty"`
+	SLSAProvenance             string        `yaml:"slsa_provenance,omitempty" json:"slsa_provenance,omitempty"`
+	Minisign                   string        `yaml:",omitempty" json:"minisign,omitempty"`
+	GitHubArtifactAttestations string        `yaml:"github_artifact_attestations,omitempty" json:"github_artifact_attestations,omitempty"`
+}
+
+type FilePlan struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+type ChecksumPlan struct {
+	URL                        string      `json:"url"`
+	Algorithm                  string      `yaml:",omitempty" json:"algorithm,omitempty"`
+	Cosign                     *CosignPlan `yaml:",omitempty" json:"cosign,omitempty"`
+	Minisign                   string      `yaml:",omitempty" json:"minisign,omitempty"`
+	GitHubArtifactAttestations string      `yaml:"github_artifact_attestations,omitempty" json:"github_artifact_attestations,omitempty"`
+}
+
+// CosignPlan has URLs of files to verify a file with Cosign.
+type CosignPlan struct {
+	Signature   string `yaml:",omitempty" json:"signature,omitempty"`
+	Certificate string `yaml:",omitempty" json:"certificate,omitempty"`
+	Key         string `yaml:",omitempty" json:"key,omitempty"`
+	Bundle      string `yaml:",omitempty" json:"bundle,omitempty"`
+}
+
+// planEnv resolves the download plan of the package version in the environment.
+// It returns the package information for the environment too, which is used to install the package.
+func planEnv(logE *logrus.Entry, pkgInfo *registry.PackageInfo, version string, rt *runtime.Runtime) (*EnvPlan, *config.Package, error) { //nolint:cyclop,funlen
+	ep := &EnvPlan{
+		Env: rt.Env(),
+	}
+	pi, err := pkgInfo.Copy().Override(logE, version, rt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("evaluate version constraints: %w", err)
+	}
+	supported, err := pi.CheckSupported(rt, rt.Env())
+	if err != nil {
+		return nil, nil, fmt.Errorf("check if the package is supported in the environment: %w", err)
+	}
+	if !supported {
+		ep.Unsupported = true
+		return ep, nil, nil
+	}
+	pkg := &config.Package{
+		Package: &aqua.Package{
+			Name:    pi.GetName(),
+			Version: version,
+		},
+		PackageInfo: pi,
+	}
+	if err := pkg.ApplyVars(); err != nil {
+		return nil, nil, fmt.Errorf("apply vars: %w", err)
+	}
+	ep.Type = pi.Type
+	assetName, err := pkg.RenderAsset(rt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("render the asset: %w", err)
+	}
+	ep.Asset = assetName
+	switch pi.Type {
+	case registry.PkgInfoTypeGoInstall, registry.PkgInfoTypeCargo:
+		// These packages are built from source code, so nothing is downloaded.
+	default:
+		file, err := download.ConvertPackageToFile(pkg, assetName, rt)
+		if err != nil {
+			return nil, nil, fmt.Errorf("get the download source of the package: %w", err)
+		}
+		ep.URL = fileURL(file)
+	}
+
+	for _, f := range pi.GetFiles() {
+		p, err := pkg.ExePath("", f, rt)
+		if err != nil {
+			return nil, nil, fmt.Errorf("get the path of the file %s: %w", f.Name, err)
+		}
+		ep.Files = append(ep.Files, &FilePlan{
+			Name: f.Name,
+			Path: filepath.ToSlash(p),
+		})
+	}
+
+	art := pkg.TemplateArtifact(rt, assetName)
+	pkgFile := &download.File{
+		RepoOwner: pi.RepoOwner,
+		RepoName:  pi.RepoName,
+		Version:   version,
+	}
+
+	if pi.Checksum.GetEnabled() {
+		u, err := checksumURL(pkg, rt)
+		if err != nil {
+			return nil, nil, err
+		}
+		cp := &ChecksumPlan{
+			URL:       u,
+			Algorithm: pi.Checksum.GetAlgorithm(),
+		}
+		cos, err := planCosign(pi.Checksum.GetCosign(), pkgFile, rt, art)
+		if err != nil {
+			return nil, nil, fmt.Errorf("checksum.cosign: %w", err)
+		}
+		cp.Cosign = cos
+		if m := pi.Checksum.GetMinisign(); m.GetEnabled() {
+			u, err := downloadedFileURL(m.ToDownloadedFile(), pkgFile, rt, art)
+			if err != nil {
+				return nil, nil, fmt.Errorf("checksum.minisign: %w", err)
+			}
+			cp.Minisign = u
+		}
+		if gaa := pi.Checksum.GetGitHubArtifactAttestations(); gaa.GetEnabled() {
+			cp.GitHubArtifactAttestations = gaa.SignerWorkflow()
+		}
+		ep.Checksum = cp
+	}
+
+	cos, err := planCosign(pi.Cosign, pkgFile, rt, art)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cosign: %w", err)
+	}
+	ep.Cosign = cos
+	if sp := pi.SLSAProvenance; sp.GetEnabled() {
+		u, err := downloadedFileURL(sp.ToDownloadedFile(), pkgFile, rt, art)
+		if err != nil {
+			return nil, nil, fmt.Errorf("slsa_provenance: %w", err)
+		}
+		ep.SLSAProvenance = u
+	}
+	if m := pi.Minisign; m.GetEnabled() {
+		u, err := downloadedFileURL(m.ToDownloadedFile(), pkgFile, rt, art)
+		if err != nil {
+			return nil, nil, fmt.Errorf("minisign: %w", err)
+		}
+		ep.Minisign = u
+	}
+	if gaa := pi.GitHubArtifactAttestations; gaa.GetEnabled() {
+		ep.GitHubArtifactAttestations = gaa.SignerWorkflow()
+	}
+	return ep, pkg, nil
+}
+
+func checksumURL(pkg *config.Package, rt *runtime.Runtime) (string, error) {
+	pi := pkg.PackageInfo
+	switch pi.Checksum.Type {
+	case registry.PkgInfoTypeGitHubRelease:
+		asset, err := pkg.RenderChecksumFileName(rt)
+		if err != nil {
+			return "", fmt.Errorf("render a checksum file name: %w", err)
+		}
+		return fileURL(&download.File{
+			Type:      registry.PkgInfoTypeGitHubRelease,
+			RepoOwner: pi.RepoOwner,
+			RepoName:  pi.RepoName,
+			Version:   pkg.Package.Version,
+			Asset:     asset,
+		}), nil
+	case registry.PkgInfoTypeHTTP:
+		u, err := pkg.RenderChecksumURL(rt)
+		if err != nil {
+			return "", fmt.Errorf("render a checksum file URL: %w", err)
+		}
+		return u, nil
+	}
+	return "", logerr.WithFields(errUnknownChecksumType, logrus.Fields{ //nolint:wrapcheck
+		"checksum_type": pi.Checksum.Type,
+	})
+}
+
+func planCosign(cos *registry.Cosign, pkgFile *download.File, rt *runtime.Runtime, art *template.Artifact) (*CosignPlan, error) {
+	if !cos.GetEnabled() {
+		return nil, nil //nolint:nilnil
+	}
+	cp := &CosignPlan{}
+	for _, f := range []struct {
+		name string
+		file *registry.DownloadedFile
+		dest *string
+	}{
+		{name: "signature", file: cos.Signature, dest: &cp.Signature},
+		{name: "certificate", file: cos.Certificate, dest: &cp.Certificate},
+		{name: "key", file: cos.Key, dest: &cp.Key},
+		{name: "bundle", file: cos.Bundle, dest: &cp.Bundle},
+	} {
+		if f.file == nil {
+			continue
+		}
+		u, err := downloadedFileURL(f.file, pkgFile, rt, art)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.name, err)
+		}
+		*f.dest = u
+	}
+	return cp, nil
+}
+
+func downloadedFileURL(file *registry.DownloadedFile, pkgFile *download.File, rt *runtime.Runtime, art *template.Artifact) (string, error) {
+	f, err := download.ConvertDownloadedFileToFile(file, pkgFile, rt, art)
+	if err != nil {
+		return "", fmt.Errorf("render the file: %w", err)
+	}
+	return fileURL(f), nil
+}
+
+// fileURL returns the URL which aqua downloads the file from.
+func fileURL(file *download.File) string {
+	switch file.Type {
+	case registry.PkgInfoTypeGitHubRelease:
+		return fmt.Sprintf("https://github.com/%s/%s/releases/download/%s/%s", file.RepoOwner, file.RepoName, file.Version, file.Asset)
+	case registry.PkgInfoTypeGitHubContent:
+		return fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s", file.RepoOwner, file.RepoName, file.Version, file.Path)
+	case registry.PkgInfoTypeGitHubArchive:
+		return fmt.Sprintf("https://github.com/%s/%s/archive/refs/tags/%s.tar.gz", file.RepoOwner, file.RepoName, file.Version)
+	case registry.PkgInfoTypeHTTP:
+		return file.URL
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+package testregistry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+	"go.yaml.in/yaml/v2"
+)
+
+const defaultRegistryFilePath = "registry.yaml"
+
+// target is a package version resolved for an environment.
+type target struct {
+	plan *EnvPlan
+	pkg  *config.Package
+	rt   *runtime.Runtime
+}
+
+// Test resolves download plans of package versions listed in testdata files for all environments.
+// Testdata files have the same format as files generated by `aqua gr --out-testdata`.
+// If the golden file is given, plans are compared with it.
+// Otherwise, plans are output to the standard output.
+// If the fixture directory is given, packages are installed with recorded fixtures.
+func (c *Controller) Test(ctx context.Context, logE *logrus.Entry, param *config.Param) error {
+	if len(param.Args) == 0 {
+		return errTestdataFileRequired
+	}
+	registryFilePath := param.RegistryFilePath
+	if registryFilePath == "" {
+		registryFilePath = defaultRegistryFilePath
+	}
+	rgst := &registry.Config{}
+	if err := registry.ReadFile(c.fs, registryFilePath, rgst); err != nil {
+		return logerr.WithFields(err, logrus.Fields{ //nolint:wrapcheck
+			"registry_file": registryFilePath,
+		})
+	}
+	pkgInfos := rgst.PackageInfos.ToMap(logE)
+	rts, err := runtime.GetRuntimesFromEnvs(nil)
+	if err != nil {
+		return fmt.Errorf("get supported environments: %w", err)
+	}
+
+	plan := &Plan{}
+	var targets []*target
+	for _, p := range param.Args {
+		cfg := &aqua.Config{}
+		if err := c.readTestdata(p, cfg); err != nil {
+			return logerr.WithFields(err, logrus.Fields{ //nolint:wrapcheck
+				"testdata_file": p,
+			})
+		}
+		for _, pkg := range cfg.Packages {
+			pp, ts, err := planPackage(logE, pkgInfos, pkg, rts)
+			if err != nil {
+				return logerr.WithFields(err, logrus.Fields{ //nolint:wrapcheck
+					"testdata_file":   p,
+					"package_name":    pkg.Name,
+					"package_version": pkg.Version,
+				})
+			}
+			plan.Packages = append(plan.Packages, pp)
+			targets = append(targets, ts...)
+		}
+	}
+
+	if err := c.checkGolden(logE, param, plan); err != nil {
+		return err
+	}
+	if param.FixtureDir == "" {
+		return nil
+	}
+	return c.install(ctx, logE, param.FixtureDir, targets)
+}
+
+func planPackage(logE *logrus.Entry, pkgInfos map[string]*registry.PackageInfo, pkg *aqua.Package, rts []*runtime.Runtime) (*PackagePlan, []*target, error) {
+	pkgInfo, ok := pkgInfos[pkg.Name]
+	if !ok {
+		return nil, nil, errPackageNotFound
+	}
+	if pkg.Version == "" {
+		return nil, nil, errVersionRequired
+	}
+	pp := &PackagePlan{
+		Name:    pkg.Name,
+		Version: pkg.Version,
+		Envs:    make([]*EnvPlan, 0, len(rts)),
+	}
+	var targets []*target
+	for _, rt := range rts {
+		ep, cpkg, err := planEnv(logE, pkgInfo, pkg.Version, rt)
+		if err != nil {
+			return nil, nil, fmt.Errorf("resolve the download plan: %w", logerr.WithFields(err, logrus.Fields{
+				"tested_env": rt.Env(),
+			}))
+		}
+		pp.Envs = append(pp.Envs, ep)
+		if cpkg != nil {
+			targets = append(targets, &target{
+				plan: ep,
+				pkg:  cpkg,
+				rt:   rt,
+			})
+		}
+	}
+	return pp, targets, nil
+}
+
+func (c *Controller) readTestdata(p string, cfg *aqua.Config) error {
+	f, err := c.fs.Open(p)
+	if err != nil {
+		return fmt.Errorf("open a testdata file: %w", err)
+	}
+	defer f.Close()
+	if err := yaml.NewDecoder(f).Decode(cfg); err != nil {
+		return fmt.Errorf("parse a testdata file as YAML: %w", err)
+	}
+	return nil
+}
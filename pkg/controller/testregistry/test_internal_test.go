@@ -0,0 +1,350 @@
+package testregistry
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/installpackage"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/aquaproj/aqua/v2/pkg/testutil"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+var localServerPattern = regexp.MustCompile(`http://127\.0\.0\.1:\d+`)
+
+const testRegistry = `packages:
+  - type: github_release
+    repo_owner: foo
+    repo_name: bar
+    asset: bar_{{trimV .Version}}_{{.OS}}_{{.Arch}}.tar.gz
+    supported_envs:
+      - linux/amd64
+      - darwin/arm64
+    files:
+      - name: bar
+        src: bar_{{.OS}}/bar
+    checksum:
+      type: github_release
+      asset: bar_{{trimV .Version}}_checksums.txt
+      algorithm: sha256
+      cosign:
+        bundle:
+          type: github_release
+          asset: bar_{{trimV .Version}}_checksums.txt.bundle
+    version_constraint: semver(">= 2.0.0")
+    version_overrides:
+      - version_constraint: "true"
+        asset: bar_{{.OS}}_{{.Arch}}.zip
+        checksum:
+          enabled: false
+`
+
+const testPlan = `packages:
+- name: foo/bar
+  version: v2.0.0
+  envs:
+  - env: darwin/amd64
+    unsupported: true
+  - env: darwin/arm64
+    type: github_release
+    asset: bar_2.0.0_darwin_arm64.tar.gz
+    url: https://github.com/foo/bar/releases/download/v2.0.0/bar_2.0.0_darwin_arm64.tar.gz
+    files:
+    - name: bar
+      path: pkgs/github_release/github.com/foo/bar/v2.0.0/bar_2.0.0_darwin_arm64.tar.gz/bar_darwin/bar
+    checksum:
+      url: https://github.com/foo/bar/releases/download/v2.0.0/bar_2.0.0_checksums.txt
+      algorithm: sha256
+      cosign:
+        bundle: https://github.com/foo/bar/releases/download/v2.0.0/bar_2.0.0_checksums.txt.bundle
+  - env: linux/amd64
+    type: github_release
+    asset: bar_2.0.0_linux_amd64.tar.gz
+    url: https://github.com/foo/bar/releases/download/v2.0.0/bar_2.0.0_linux_amd64.tar.gz
+    files:
+    - name: bar
+      path: pkgs/github_release/github.com/foo/bar/v2.0.0/bar_2.0.0_linux_amd64.tar.gz/bar_linux/bar
+    checksum:
+      url: https://github.com/foo/bar/releases/download/v2.0.0/bar_2.0.0_checksums.txt
+      algorithm: sha256
+      cosign:
+        bundle: https://github.com/foo/bar/releases/download/v2.0.0/bar_2.0.0_checksums.txt.bundle
+  - env: linux/arm64
+    unsupported: true
+  - env: windows/amd64
+    unsupported: true
+  - env: windows/arm64
+    unsupported: true
+- name: foo/bar
+  version: v1.0.0
+  envs:
+  - env: darwin/amd64
+    unsupported: true
+  - env: darwin/arm64
+    type: github_release
+    asset: bar_darwin_arm64.zip
+    url: https://github.com/foo/bar/releases/download/v1.0.0/bar_darwin_arm64.zip
+    files:
+    - name: bar
+      path: pkgs/github_release/github.com/foo/bar/v1.0.0/bar_darwin_arm64.zip/bar_darwin/bar
+  - env: linux/amd64
+    type: github_release
+    asset: bar_linux_amd64.zip
+    url: https://github.com/foo/bar/releases/download/v1.0.0/bar_linux_amd64.zip
+    files:
+    - name: bar
+      path: pkgs/github_release/github.com/foo/bar/v1.0.0/bar_linux_amd64.zip/bar_linux/bar
+  - env: linux/arm64
+    unsupported: true
+  - env: windows/amd64
+    unsupported: true
+  - env: windows/arm64
+    unsupported: true
+`
+
+const testTestdata = `packages:
+  - name: foo/bar@v2.0.0
+  - name: foo/bar
+    version: v1.0.0
+`
+
+func TestController_Test(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name   string
+		files  map[string]string
+		param  *config.Param
+		isErr  bool
+		expOut string
+	}{
+		{
+			name: "output the plan",
+			files: map[string]string{
+				"registry.yaml": testRegistry,
+				"pkg.yaml":      testTestdata,
+			},
+			param: &config.Param{
+				Args: []string{"pkg.yaml"},
+			},
+			expOut: testPlan,
+		},
+		{
+			name: "same as the golden file",
+			files: map[string]string{
+				"registry.yaml": testRegistry,
+				"pkg.yaml":      testTestdata,
+				"plan.yaml":     testPlan,
+			},
+			param: &config.Param{
+				Args:           []string{"pkg.yaml"},
+				GoldenFilePath: "plan.yaml",
+			},
+		},
+		{
+			name: "different from the golden file",
+			files: map[string]string{
+				"registry.yaml": strings.Replace(testRegistry, "algorithm: sha256", "algorithm: sha512", 1),
+				"pkg.yaml":      testTestdata,
+				"plan.yaml":     testPlan,
+			},
+			param: &config.Param{
+				Args:           []string{"pkg.yaml"},
+				GoldenFilePath: "plan.yaml",
+			},
+			isErr: true,
+			expOut: `foo/bar@v2.0.0 (darwin/arm64):
+  env: darwin/arm64
+  type: github_release
+  asset: bar_2.0.0_darwin_arm64.tar.gz
+  url: https://github.com/foo/bar/releases/download/v2.0.0/bar_2.0.0_darwin_arm64.tar.gz
+  files:
+  - name: bar
+    path: pkgs/github_release/github.com/foo/bar/v2.0.0/bar_2.0.0_darwin_arm64.tar.gz/bar_darwin/bar
+  checksum:
+    url: https://github.com/foo/bar/releases/download/v2.0.0/bar_2.0.0_checksums.txt
+-   algorithm: sha256
++   algorithm: sha512
+    cosign:
+      bundle: https://github.com/foo/bar/releases/download/v2.0.0/bar_2.0.0_checksums.txt.bundle
+foo/bar@v2.0.0 (linux/amd64):
+  env: linux/amd64
+  type: github_release
+  asset: bar_2.0.0_linux_amd64.tar.gz
+  url: https://github.com/foo/bar/releases/download/v2.0.0/bar_2.0.0_linux_amd64.tar.gz
+  files:
+  - name: bar
+    path: pkgs/github_release/github.com/foo/bar/v2.0.0/bar_2.0.0_linux_amd64.tar.gz/bar_linux/bar
+  checksum:
+    url: https://github.com/foo/bar/releases/download/v2.0.0/bar_2.0.0_checksums.txt
+-   algorithm: sha256
++   algorithm: sha512
+    cosign:
+      bundle: https://github.com/foo/bar/releases/download/v2.0.0/bar_2.0.0_checksums.txt.bundle
+`,
+		},
+		{
+			name: "a package version isn't in the golden file",
+			files: map[string]string{
+				"registry.yaml": testRegistry,
+				"pkg.yaml": `packages:
+  - name: foo/bar@v1.0.0
+`,
+				"plan.yaml": `packages:
+- name: foo/bar
+  version: v1.0.0
+  envs:
+  - env: darwin/amd64
+    unsupported: true
+  - env: darwin/arm64
+    unsupported: true
+  - env: linux/amd64
+    type: github_release
+    asset: bar_linux_amd64.zip
+    url: https://github.com/foo/bar/releases/download/v1.0.0/bar_linux_amd64.zip
+    files:
+    - name: bar
+      path: pkgs/github_release/github.com/foo/bar/v1.0.0/bar_linux_amd64.zip/bar_linux/bar
+  - env: linux/arm64
+    unsupported: true
+  - env: windows/amd64
+    unsupported: true
+`,
+			},
+			param: &config.Param{
+				Args:           []string{"pkg.yaml"},
+				GoldenFilePath: "plan.yaml",
+			},
+			isErr: true,
+			expOut: `foo/bar@v1.0.0 (darwin/arm64):
+  env: darwin/arm64
+- unsupported: true
++ type: github_release
++ asset: bar_darwin_arm64.zip
++ url: https://github.com/foo/bar/releases/download/v1.0.0/bar_darwin_arm64.zip
++ files:
++ - name: bar
++   path: pkgs/github_release/github.com/foo/bar/v1.0.0/bar_darwin_arm64.zip/bar_darwin/bar
+foo/bar@v1.0.0 (windows/arm64): not found in the golden file
++ env: windows/arm64
++ unsupported: true
+`,
+		},
+		{
+			name: "unknown package type",
+			files: map[string]string{
+				"registry.yaml": `packages:
+  - type: foo
+    repo_owner: foo
+    repo_name: bar
+`,
+				"pkg.yaml": `packages:
+  - name: foo/bar@v1.0.0
+`,
+			},
+			param: &config.Param{
+				Args: []string{"pkg.yaml"},
+			},
+			isErr: true,
+		},
+		{
+			name: "unknown package",
+			files: map[string]string{
+				"registry.yaml": testRegistry,
+				"pkg.yaml": `packages:
+  - name: foo/baz@v1.0.0
+`,
+			},
+			param: &config.Param{
+				Args: []string{"pkg.yaml"},
+			},
+			isErr: true,
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			fs, err := testutil.NewFs(d.files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stdout := &bytes.Buffer{}
+			ctrl := New(fs)
+			ctrl.stdout = stdout
+			if err := ctrl.Test(t.Context(), logE, d.param); err != nil {
+				if !d.isErr {
+					t.Fatal(err)
+				}
+			} else if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if d.expOut == "" {
+				return
+			}
+			if diff := cmp.Diff(d.expOut, stdout.String()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+type mockPackageInstaller struct {
+	installed *[]string
+}
+
+func (m *mockPackageInstaller) InstallPackage(_ context.Context, _ *logrus.Entry, param *installpackage.ParamInstallPackage) error {
+	pi := param.Pkg.PackageInfo
+	s := pi.Type + " " + pi.URL
+	if pi.Checksum != nil {
+		s += " " + pi.Checksum.Type + " " + pi.Checksum.URL
+	}
+	*m.installed = append(*m.installed, s)
+	return nil
+}
+
+func TestController_Test_install(t *testing.T) {
+	t.Parallel()
+	fs, err := testutil.NewFs(map[string]string{
+		"registry.yaml": testRegistry,
+		"pkg.yaml":      testTestdata,
+		"fixtures/github.com/foo/bar/releases/download/v2.0.0/bar_2.0.0_linux_amd64.tar.gz": "",
+		"fixtures/github.com/foo/bar/releases/download/v2.0.0/bar_2.0.0_checksums.txt":      "",
+		"fixtures/github.com/foo/bar/releases/download/v1.0.0/bar_darwin_arm64.zip":         "",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctrl := New(fs)
+	ctrl.stdout = &bytes.Buffer{}
+	var installed []string
+	var envs []string
+	ctrl.SetNewPackageInstaller(func(rt *runtime.Runtime, _ string) (PackageInstaller, error) {
+		envs = append(envs, rt.Env())
+		return &mockPackageInstaller{installed: &installed}, nil
+	})
+	logE := logrus.NewEntry(logrus.New())
+	if err := ctrl.Test(t.Context(), logE, &config.Param{
+		Args:       []string{"pkg.yaml"},
+		FixtureDir: "fixtures",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"linux/amd64", "darwin/arm64"}, envs); diff != "" {
+		t.Fatal(diff)
+	}
+	for i, s := range installed {
+		// The port of the local HTTP server is random.
+		installed[i] = localServerPattern.ReplaceAllString(s, "http://localhost")
+	}
+	if diff := cmp.Diff([]string{
+		"http http://localhost/github.com/foo/bar/releases/download/v2.0.0/bar_2.0.0_linux_amd64.tar.gz http http://localhost/github.com/foo/bar/releases/download/v2.0.0/bar_2.0.0_checksums.txt",
+		"http http://localhost/github.com/foo/bar/releases/download/v1.0.0/bar_darwin_arm64.zip",
+	}, installed); diff != "" {
+		t.Fatal(diff)
+	}
+}
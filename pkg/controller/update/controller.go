@@ -2,6 +2,8 @@ package update
 
 import (
 	"context"
+	"io"
+	"os"
 
 	"github.com/aquaproj/aqua/v2/pkg/checksum"
 	"github.com/aquaproj/aqua/v2/pkg/config"
@@ -10,12 +12,15 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/controller/which"
 	"github.com/aquaproj/aqua/v2/pkg/fuzzyfinder"
 	"github.com/aquaproj/aqua/v2/pkg/github"
+	"github.com/aquaproj/aqua/v2/pkg/osexec"
 	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/aquaproj/aqua/v2/pkg/versiongetter"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 )
 
 type Controller struct {
+	stdout            io.Writer
 	gh                RepositoriesService
 	rootDir           string
 	configFinder      ConfigFinder
@@ -26,6 +31,13 @@ type Controller struct {
 	fuzzyGetter       FuzzyGetter
 	fuzzyFinder       FuzzyFinder
 	which             WhichController
+	versionCache      *versionCache
+	report            *updateReport
+	executor          CommandExecutor
+}
+
+type CommandExecutor interface {
+	ExecAndGetOutput(cmd *osexec.Cmd) (string, int, error)
 }
 
 type WhichController interface {
@@ -43,7 +55,7 @@ type ConfigReader interface {
 }
 
 type FuzzyGetter interface {
-	Get(ctx context.Context, logE *logrus.Entry, pkg *registry.PackageInfo, currentVersion string, useFinder bool, limit int) string
+	Get(ctx context.Context, logE *logrus.Entry, pkg *registry.PackageInfo, currentVersion string, useFinder bool, limit int, rule *versiongetter.UpdateRule) string
 }
 
 type RepositoriesService interface {
@@ -52,8 +64,9 @@ type RepositoriesService interface {
 	ListTags(ctx context.Context, owner string, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error)
 }
 
-func New(param *config.Param, gh RepositoriesService, configFinder ConfigFinder, configReader ConfigReader, registryInstaller RegistryInstaller, fs afero.Fs, rt *runtime.Runtime, fuzzyGetter FuzzyGetter, fuzzyFinder FuzzyFinder, whichController WhichController) *Controller {
+func New(param *config.Param, gh RepositoriesService, configFinder ConfigFinder, configReader ConfigReader, registryInstaller RegistryInstaller, fs afero.Fs, rt *runtime.Runtime, fuzzyGetter FuzzyGetter, fuzzyFinder FuzzyFinder, whichController WhichController, executor CommandExecutor) *Controller {
 	return &Controller{
+		stdout:            os.Stdout,
 		gh:                gh,
 		rootDir:           param.RootDir,
 		configFinder:      configFinder,
@@ -64,6 +77,7 @@ func New(param *config.Param, gh RepositoriesService, configFinder ConfigFinder,
 		fuzzyGetter:       fuzzyGetter,
 		fuzzyFinder:       fuzzyFinder,
 		which:             whichController,
+		executor:          executor,
 	}
 }
 
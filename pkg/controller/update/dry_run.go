@@ -0,0 +1,153 @@
+package update
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+var errInvalidFormat = errors.New("the output format of --dry-run must be json")
+
+// DryRunResult is the output of `aqua update --dry-run --format json`.
+type DryRunResult struct {
+	// Changed is true if some files would be updated.
+	Changed bool        `json:"changed"`
+	Files   []*FileDiff `json:"files"`
+}
+
+// FileDiff is a unified diff of a file updated by `aqua update`.
+type FileDiff struct {
+	Path string `json:"path"`
+	Diff string `json:"diff"`
+}
+
+// updateDryRun runs the update against an in-memory copy of files and outputs unified diffs instead of updating files.
+func (c *Controller) updateDryRun(ctx context.Context, logE *logrus.Entry, param *config.Param, notes *releaseNotes) error {
+	if param.OutputFormat != "" && param.OutputFormat != "json" {
+		return errInvalidFormat
+	}
+	layer := afero.NewMemMapFs()
+	ctrl := c.withFs(afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(c.fs), layer))
+	if err := ctrl.updateAll(ctx, logE, param, notes); err != nil {
+		return err
+	}
+	result, err := c.diffLayer(layer)
+	if err != nil {
+		return err
+	}
+	if err := c.outputDryRunResult(param.OutputFormat, result); err != nil {
+		return err
+	}
+	return c.writeReleaseNotes(ctx, logE, param, notes)
+}
+
+// withFs returns a copy of the controller using the given file system.
+func (c *Controller) withFs(fs afero.Fs) *Controller {
+	ctrl := *c
+	ctrl.fs = fs
+	return &ctrl
+}
+
+// diffLayer compares files written to the layer with the original files.
+func (c *Controller) diffLayer(layer afero.Fs) (*DryRunResult, error) {
+	result := &DryRunResult{
+		Files: []*FileDiff{},
+	}
+	if err := afero.Walk(layer, string(filepath.Separator), func(p string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		// Checksum files are updated as a side effect of installing registries, so they're excluded.
+		if base := filepath.Base(p); base == "aqua-checksums.json" || base == ".aqua-checksums.json" {
+			return nil
+		}
+		fileDiff, err := c.diffFile(layer, p)
+		if err != nil {
+			return err
+		}
+		if fileDiff != nil {
+			result.Files = append(result.Files, fileDiff)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("compare updated files with original files: %w", err)
+	}
+	slices.SortFunc(result.Files, func(a, b *FileDiff) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+	result.Changed = len(result.Files) != 0
+	return result, nil
+}
+
+func (c *Controller) diffFile(layer afero.Fs, p string) (*FileDiff, error) {
+	newContent, err := afero.ReadFile(layer, p)
+	if err != nil {
+		return nil, fmt.Errorf("read an updated file: %w", err)
+	}
+	oldContent, err := afero.ReadFile(c.fs, p)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read an original file: %w", err)
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(string(oldContent)),
+		B:        splitLines(string(newContent)),
+		FromFile: p,
+		ToFile:   p,
+		Context:  3, //nolint:mnd
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get a unified diff: %w", err)
+	}
+	if diff == "" {
+		return nil, nil //nolint:nilnil
+	}
+	return &FileDiff{
+		Path: p,
+		Diff: diff,
+	}, nil
+}
+
+// splitLines splits the content into lines including line breaks.
+// Unlike difflib.SplitLines, it doesn't add an empty line to the end.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func (c *Controller) outputDryRunResult(format string, result *DryRunResult) error {
+	if format == "json" {
+		encoder := json.NewEncoder(c.stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			return fmt.Errorf("encode the result as JSON: %w", err)
+		}
+		return nil
+	}
+	for _, file := range result.Files {
+		if _, err := fmt.Fprint(c.stdout, file.Diff); err != nil {
+			return fmt.Errorf("output a diff: %w", err)
+		}
+	}
+	return nil
+}
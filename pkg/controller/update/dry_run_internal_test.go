@@ -0,0 +1,101 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/testutil"
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+)
+
+func TestController_diffLayer(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name  string
+		files map[string]string
+		layer map[string]string
+		exp   *DryRunResult
+		isErr bool
+	}{
+		{
+			name: "no change",
+			files: map[string]string{
+				"/workspace/aqua.yaml": "packages:\n- name: cli/cli@v2.0.0\n",
+			},
+			exp: &DryRunResult{
+				Files: []*FileDiff{},
+			},
+		},
+		{
+			name: "changed",
+			files: map[string]string{
+				"/workspace/aqua.yaml":           "registries:\n- type: standard\n  ref: v4.0.0\nimport_dir: imports\n",
+				"/workspace/imports/gh.yaml":     "packages:\n- name: cli/cli@v2.0.0\n",
+				"/workspace/imports/jq.yaml":     "packages:\n- name: jqlang/jq@jq-1.7\n",
+				"/workspace/aqua-checksums.json": "{}\n",
+			},
+			layer: map[string]string{
+				"/workspace/aqua.yaml":           "registries:\n- type: standard\n  ref: v4.60.0\nimport_dir: imports\n",
+				"/workspace/imports/gh.yaml":     "packages:\n- name: cli/cli@v2.30.0\n",
+				"/workspace/imports/jq.yaml":     "packages:\n- name: jqlang/jq@jq-1.7\n",
+				"/workspace/aqua-checksums.json": "{\"checksums\": []}\n",
+			},
+			exp: &DryRunResult{
+				Changed: true,
+				Files: []*FileDiff{
+					{
+						Path: "/workspace/aqua.yaml",
+						Diff: `--- /workspace/aqua.yaml
++++ /workspace/aqua.yaml
+@@ -1,4 +1,4 @@
+ registries:
+ - type: standard
+-  ref: v4.0.0
++  ref: v4.60.0
+ import_dir: imports
+`,
+					},
+					{
+						Path: "/workspace/imports/gh.yaml",
+						Diff: `--- /workspace/imports/gh.yaml
++++ /workspace/imports/gh.yaml
+@@ -1,2 +1,2 @@
+ packages:
+-- name: cli/cli@v2.0.0
++- name: cli/cli@v2.30.0
+`,
+					},
+				},
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			fs, err := testutil.NewFs(d.files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			layer := afero.NewMemMapFs()
+			for p, content := range d.layer {
+				if err := afero.WriteFile(layer, p, []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			ctrl := &Controller{fs: fs}
+			result, err := ctrl.diffLayer(layer)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(d.exp, result); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package update
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/osexec"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// exitCodeNotIgnored is the exit code of `git check-ignore` when none of given paths are ignored.
+const exitCodeNotIgnored = 1
+
+// filterIgnoredPaths removes paths ignored by git from paths.
+// Ignored paths are checked by `git check-ignore`, so .gitignore, .git/info/exclude, and the global excludes file
+// are respected in the same way as git. Tracked files aren't treated as ignored.
+// If git isn't available or the directory isn't in a git repository, paths are returned as they are.
+func (c *Controller) filterIgnoredPaths(ctx context.Context, logE *logrus.Entry, dir string, paths []string) []string {
+	if len(paths) == 0 {
+		return paths
+	}
+	rels := make(map[string]string, len(paths))
+	args := make([]string, 0, len(paths)+1)
+	args = append(args, "check-ignore")
+	for _, p := range paths {
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return paths
+		}
+		rels[filepath.ToSlash(rel)] = p
+		args = append(args, rel)
+	}
+	cmd := osexec.Command(ctx, "git", args...)
+	cmd.Dir = dir
+	out, code, err := c.executor.ExecAndGetOutput(cmd)
+	if err != nil {
+		if code == exitCodeNotIgnored {
+			return paths
+		}
+		logerr.WithError(logE, err).WithField("output", out).Debug("check paths ignored by git")
+		return paths
+	}
+	ignored := make(map[string]struct{}, len(paths))
+	for line := range strings.SplitSeq(out, "\n") {
+		if p, ok := rels[filepath.ToSlash(strings.TrimSpace(line))]; ok {
+			ignored[p] = struct{}{}
+		}
+	}
+	filtered := make([]string, 0, len(paths))
+	for _, p := range paths {
+		if _, ok := ignored[p]; !ok {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
@@ -10,12 +10,14 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/config/registry"
 	"github.com/aquaproj/aqua/v2/pkg/controller/update/ast"
 	"github.com/aquaproj/aqua/v2/pkg/fuzzyfinder"
+	"github.com/aquaproj/aqua/v2/pkg/versiongetter"
 	"github.com/goccy/go-yaml/parser"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
 )
 
-func (c *Controller) updatePackages(ctx context.Context, logE *logrus.Entry, param *config.Param, cfgFilePath string, rgstCfgs map[string]*registry.Config) error {
+func (c *Controller) updatePackages(ctx context.Context, logE *logrus.Entry, param *config.Param, cfgFilePath string, rgstCfgs map[string]*registry.Config, notes *releaseNotes) error {
 	newVersions := map[string]string{}
 	updatedPkgs := map[string]struct{}{}
 	if param.Insert {
@@ -35,14 +37,17 @@ func (c *Controller) updatePackages(ctx context.Context, logE *logrus.Entry, par
 	}
 	cfgs[cfgFilePath] = cfg
 	for cfgPath, cfg := range cfgs {
-		if err := c.updatePackagesInFile(ctx, logE, param, cfgPath, cfg, rgstCfgs, updatedPkgs, newVersions); err != nil {
+		if err := c.updatePackagesInFile(ctx, logE, param, cfgPath, cfg, rgstCfgs, updatedPkgs, newVersions, notes); err != nil {
 			return err
 		}
 	}
+	if param.UpdateVersionSource {
+		return c.updateVersionSources(ctx, logE, param, cfgFilePath, rgstCfgs, updatedPkgs, notes)
+	}
 	return nil
 }
 
-func (c *Controller) updatePackagesInFile(ctx context.Context, logE *logrus.Entry, param *config.Param, cfgFilePath string, cfg *aqua.Config, rgstCfgs map[string]*registry.Config, updatedPkgs map[string]struct{}, newVersions map[string]string) error { //nolint:cyclop
+func (c *Controller) updatePackagesInFile(ctx context.Context, logE *logrus.Entry, param *config.Param, cfgFilePath string, cfg *aqua.Config, rgstCfgs map[string]*registry.Config, updatedPkgs map[string]struct{}, newVersions map[string]string, notes *releaseNotes) error { //nolint:cyclop
 	pkgs, failed := config.ListPackages(logE, cfg, c.runtime, rgstCfgs)
 	if len(pkgs) == 0 {
 		if failed {
@@ -69,6 +74,8 @@ func (c *Controller) updatePackagesInFile(ctx context.Context, logE *logrus.Entr
 		if newVersion := c.getPackageNewVersion(ctx, logE, param, updatedPkgs, pkg); newVersion != "" {
 			newVersions[fmt.Sprintf("%s,%s", pkg.Package.Registry, pkg.PackageInfo.GetName())] = newVersion
 			newVersions[fmt.Sprintf("%s,%s", pkg.Package.Registry, pkg.Package.Name)] = newVersion
+			notes.add(pkg, newVersion)
+			c.report.addPackage(cfgFilePath, pkg, newVersion)
 		}
 	}
 	if len(newVersions) == 0 {
@@ -92,7 +99,25 @@ func (c *Controller) getPackageNewVersion(ctx context.Context, logE *logrus.Entr
 			return ""
 		}
 	}
-	return c.fuzzyGetter.Get(ctx, logE, pkg.PackageInfo, pkg.Package.Version, param.SelectVersion, param.Limit)
+	var rule *versiongetter.UpdateRule
+	if u := pkg.Package.Update; u != nil {
+		r, err := versiongetter.NewUpdateRule(pkg.Package.Version, u.Types, u.AllowedVersion, u.MinAge)
+		if err != nil {
+			logerr.WithError(logE, err).Warn("skip updating the package because the field update is invalid")
+			return ""
+		}
+		rule = r
+	}
+	if param.SelectVersion {
+		return c.fuzzyGetter.Get(ctx, logE, pkg.PackageInfo, pkg.Package.Version, true, param.Limit, rule)
+	}
+	key := packageCacheKey(pkg)
+	if version, ok := c.versionCache.getPackage(key); ok {
+		return version
+	}
+	version := c.fuzzyGetter.Get(ctx, logE, pkg.PackageInfo, pkg.Package.Version, false, param.Limit, rule)
+	c.versionCache.setPackage(key, version)
+	return version
 }
 
 func (c *Controller) selectPackages(logE *logrus.Entry, cfgFilePath string) (map[string]struct{}, error) {
@@ -0,0 +1,265 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	finder "github.com/aquaproj/aqua/v2/pkg/config-finder"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+var (
+	errRecursiveWithArgs = errors.New("--recursive can't be used with arguments and the -i option")
+	errUpdateConfigFiles = errors.New("failed to update some configuration files")
+)
+
+// skippedDirs are directory names skipped when configuration files are searched recursively.
+var skippedDirs = map[string]struct{}{
+	".git":         {},
+	"node_modules": {},
+	"vendor":       {},
+}
+
+// updateRecursively updates all configuration files under the current directory.
+// Even if it fails to update a file, it continues to update other files.
+func (c *Controller) updateRecursively(ctx context.Context, logE *logrus.Entry, param *config.Param, notes *releaseNotes) error {
+	if len(param.Args) != 0 || param.Insert {
+		return errRecursiveWithArgs
+	}
+	cfgFilePaths, err := c.findConfigFiles(param.PWD, param.ExcludedPaths)
+	if err != nil {
+		return err
+	}
+	cfgFilePaths = c.filterIgnoredPaths(ctx, logE, param.PWD, cfgFilePaths)
+	if len(cfgFilePaths) == 0 {
+		return finder.ErrConfigFileNotFound
+	}
+	failed := false
+	for _, cfgFilePath := range cfgFilePaths {
+		logE := logE.WithField("config_file_path", cfgFilePath)
+		if err := c.update(ctx, logE, param, cfgFilePath, notes); err != nil {
+			logerr.WithError(logE, err).Error("update a configuration file")
+			failed = true
+		}
+	}
+	if failed {
+		return errUpdateConfigFiles
+	}
+	return nil
+}
+
+// findConfigFiles returns configuration files under the directory.
+// Directories in skippedDirs and paths matching excluded patterns are skipped.
+// Paths ignored by git are removed by filterIgnoredPaths.
+func (c *Controller) findConfigFiles(dir string, excludedPatterns []string) ([]string, error) {
+	fileNames := map[string]struct{}{}
+	for _, name := range finder.ConfigFileNames() {
+		fileNames[filepath.Base(name)] = struct{}{}
+	}
+	var cfgFilePaths []string
+	if err := afero.Walk(c.fs, dir, func(p string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != dir && isExcludedPath(dir, p, excludedPatterns) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			if _, ok := skippedDirs[info.Name()]; ok {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if _, ok := fileNames[info.Name()]; ok {
+			cfgFilePaths = append(cfgFilePaths, p)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("search configuration files: %w", logerr.WithFields(err, logrus.Fields{
+			"dir": dir,
+		}))
+	}
+	slices.Sort(cfgFilePaths)
+	return cfgFilePaths, nil
+}
+
+// isExcludedPath returns true if the relative path or the base name of the path matches one of patterns.
+func isExcludedPath(dir, p string, patterns []string) bool {
+	rel, err := filepath.Rel(dir, p)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	base := path.Base(rel)
+	for _, pattern := range patterns {
+		if f, _ := path.Match(pattern, rel); f {
+			return true
+		}
+		if f, _ := path.Match(pattern, base); f {
+			return true
+		}
+	}
+	return false
+}
+
+// versionCache shares the latest versions of packages and registries among configuration files,
+// so the same API isn't called repeatedly when many configuration files are updated.
+type versionCache struct {
+	packages   map[string]string
+	registries map[string]string
+}
+
+func newVersionCache() *versionCache {
+	return &versionCache{
+		packages:   map[string]string{},
+		registries: map[string]string{},
+	}
+}
+
+// packageCacheKey returns a key of the cache of the package.
+// The latest version doesn't depend on the current version, so configuration files pinning different versions share the cache.
+// The field update is included because it changes the latest version,
+// and the current version is included only if update.types is set because update types depend on it.
+func packageCacheKey(pkg *config.Package) string {
+	key := fmt.Sprintf("%s,%s", pkg.Package.Registry, pkg.PackageInfo.GetName())
+	if u := pkg.Package.Update; u != nil {
+		key += fmt.Sprintf(",%v,%s,%s", u.Types, u.AllowedVersion, u.MinAge)
+		if len(u.Types) != 0 {
+			key += "@" + pkg.Package.Version
+		}
+	}
+	return key
+}
+
+func (v *versionCache) getPackage(key string) (string, bool) {
+	if v == nil {
+		return "", false
+	}
+	version, ok := v.packages[key]
+	return version, ok
+}
+
+func (v *versionCache) setPackage(key, version string) {
+	if v == nil {
+		return
+	}
+	v.packages[key] = version
+}
+
+func (v *versionCache) getRegistry(key string) (string, bool) {
+	if v == nil {
+		return "", false
+	}
+	version, ok := v.registries[key]
+	return version, ok
+}
+
+func (v *versionCache) setRegistry(key, version string) {
+	if v == nil {
+		return
+	}
+	v.registries[key] = version
+}
+
+// withVersionCache returns a copy of the controller sharing the cache.
+func (c *Controller) withVersionCache(cache *versionCache) *Controller {
+	ctrl := *c
+	ctrl.versionCache = cache
+	return &ctrl
+}
+
+// updateReport collects updated registries and packages to output them after `aqua update --recursive`.
+// If the option --recursive isn't set, updateReport is nil and methods do nothing.
+type updateReport struct {
+	rows []*reportRow
+}
+
+type reportRow struct {
+	filePath   string
+	name       string
+	oldVersion string
+	newVersion string
+}
+
+func newUpdateReport(param *config.Param) *updateReport {
+	if !param.Recursive || param.DryRun {
+		return nil
+	}
+	return &updateReport{}
+}
+
+// withUpdateReport returns a copy of the controller adding updates to the report.
+func (c *Controller) withUpdateReport(report *updateReport) *Controller {
+	ctrl := *c
+	ctrl.report = report
+	return &ctrl
+}
+
+// addPackage adds a package to the report if the package is updated.
+func (r *updateReport) addPackage(cfgFilePath string, pkg *config.Package, newVersion string) {
+	if r == nil || newVersion == "" || newVersion == pkg.Package.Version {
+		return
+	}
+	// Packages whose version is set by the field version and commit hashes aren't updated.
+	if pkg.Package.Pin || commitHashPattern.MatchString(pkg.Package.Version) {
+		return
+	}
+	r.add(cfgFilePath, pkg.Package.Name, pkg.Package.Version, newVersion)
+}
+
+// add adds an update to the report.
+// The same update is added only once because a file can be imported from multiple configuration files.
+func (r *updateReport) add(cfgFilePath, name, oldVersion, newVersion string) {
+	if r == nil || newVersion == "" || newVersion == oldVersion {
+		return
+	}
+	for _, row := range r.rows {
+		if row.filePath == cfgFilePath && row.name == name && row.oldVersion == oldVersion {
+			return
+		}
+	}
+	r.rows = append(r.rows, &reportRow{
+		filePath:   cfgFilePath,
+		name:       name,
+		oldVersion: oldVersion,
+		newVersion: newVersion,
+	})
+}
+
+// writeUpdateReport outputs the list of updated registries and packages as a table.
+func (c *Controller) writeUpdateReport(pwd string, report *updateReport) error {
+	if report == nil {
+		return nil
+	}
+	if len(report.rows) == 0 {
+		if _, err := fmt.Fprintln(c.stdout, "All registries and packages are up to date"); err != nil {
+			return fmt.Errorf("output the result: %w", err)
+		}
+		return nil
+	}
+	w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0) //nolint:mnd
+	fmt.Fprintln(w, "FILE\tNAME\tCURRENT\tNEW")
+	for _, row := range report.rows {
+		p := row.filePath
+		if rel, err := filepath.Rel(pwd, p); err == nil {
+			p = rel
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", p, row.name, row.oldVersion, row.newVersion)
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("output the result: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package update
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/osexec"
+	"github.com/aquaproj/aqua/v2/pkg/testutil"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+func Test_packageCacheKey(t *testing.T) {
+	t.Parallel()
+	newPkg := func(version string, update *aqua.Update) *config.Package {
+		return &config.Package{
+			Package: &aqua.Package{
+				Name:     "suzuki-shunsuke/tfcmt",
+				Registry: "standard",
+				Version:  version,
+				Update:   update,
+			},
+			PackageInfo: &registry.PackageInfo{
+				Type:      "github_release",
+				RepoOwner: "suzuki-shunsuke",
+				RepoName:  "tfcmt",
+			},
+		}
+	}
+	if packageCacheKey(newPkg("v3.0.0", nil)) != packageCacheKey(newPkg("v4.0.0", nil)) {
+		t.Fatal("packages of different versions should share the cache")
+	}
+	minAge := &aqua.Update{MinAge: "72h"}
+	if packageCacheKey(newPkg("v3.0.0", minAge)) != packageCacheKey(newPkg("v4.0.0", minAge)) {
+		t.Fatal("min_age doesn't depend on the current version")
+	}
+	if packageCacheKey(newPkg("v3.0.0", nil)) == packageCacheKey(newPkg("v3.0.0", minAge)) {
+		t.Fatal("the field update should change the key")
+	}
+	types := &aqua.Update{Types: []string{"patch"}}
+	if packageCacheKey(newPkg("v3.0.0", types)) == packageCacheKey(newPkg("v4.0.0", types)) {
+		t.Fatal("update types depend on the current version")
+	}
+}
+
+func TestController_findConfigFiles(t *testing.T) {
+	t.Parallel()
+	fs, err := testutil.NewFs(map[string]string{
+		"/workspace/aqua.yaml":                  "",
+		"/workspace/foo/build/aqua.yaml":        "",
+		"/workspace/bar/.aqua/aqua.yaml":        "",
+		"/workspace/bar/aqua.yaml.bak":          "",
+		"/workspace/node_modules/foo/aqua.yaml": "",
+		"/workspace/vendor/aqua.yaml":           "",
+		"/workspace/excluded/aqua.yaml":         "",
+		"/workspace/foo/excluded/aqua.yaml":     "",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctrl := &Controller{fs: fs}
+	cfgFilePaths, err := ctrl.findConfigFiles("/workspace", []string{"excluded"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []string{
+		"/workspace/aqua.yaml",
+		"/workspace/bar/.aqua/aqua.yaml",
+		"/workspace/foo/build/aqua.yaml",
+	}
+	if diff := cmp.Diff(exp, cfgFilePaths); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestController_filterIgnoredPaths(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name     string
+		executor *osexec.Mock
+		exp      []string
+	}{
+		{
+			name: "ignored",
+			executor: &osexec.Mock{
+				Output: "build/aqua.yaml\nfoo/tmp/aqua.yaml\n",
+			},
+			exp: []string{
+				"/workspace/aqua.yaml",
+			},
+		},
+		{
+			name: "nothing is ignored",
+			executor: &osexec.Mock{
+				ExitCode: 1,
+				Err:      errors.New("exit status 1"),
+			},
+			exp: []string{
+				"/workspace/aqua.yaml",
+				"/workspace/build/aqua.yaml",
+				"/workspace/foo/tmp/aqua.yaml",
+			},
+		},
+		{
+			name: "not a git repository",
+			executor: &osexec.Mock{
+				ExitCode: 128,
+				Err:      errors.New("exit status 128"),
+				Output:   "fatal: not a git repository (or any of the parent directories): .git\n",
+			},
+			exp: []string{
+				"/workspace/aqua.yaml",
+				"/workspace/build/aqua.yaml",
+				"/workspace/foo/tmp/aqua.yaml",
+			},
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := &Controller{executor: d.executor}
+			paths := ctrl.filterIgnoredPaths(t.Context(), logE, "/workspace", []string{
+				"/workspace/aqua.yaml",
+				"/workspace/build/aqua.yaml",
+				"/workspace/foo/tmp/aqua.yaml",
+			})
+			if diff := cmp.Diff(d.exp, paths); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestController_filterIgnoredPaths_git(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't found")
+	}
+	dir := t.TempDir()
+	files := map[string]string{
+		".gitignore":             "# comment\n/build/\n*.local\n!keep.local\ndist/**/tmp\n",
+		"bar/.gitignore":         "aqua.yaml\n",
+		"foo/.gitignore":         "!/build/\n",
+		"aqua.yaml":              "",
+		"build/aqua.yaml":        "",
+		"foo/build/aqua.yaml":    "",
+		"foo.local/aqua.yaml":    "",
+		"keep.local/aqua.yaml":   "",
+		"dist/a/b/tmp/aqua.yaml": "",
+		"bar/aqua.yaml":          "",
+		"bar/.aqua/aqua.yaml":    "",
+	}
+	paths := make([]string, 0, len(files))
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil { //nolint:gosec
+			t.Fatal(err)
+		}
+		if filepath.Base(p) == "aqua.yaml" {
+			paths = append(paths, p)
+		}
+	}
+	slices.Sort(paths)
+	if out, err := exec.CommandContext(t.Context(), "git", "-C", dir, "init").CombinedOutput(); err != nil {
+		t.Fatal(string(out), err)
+	}
+	ctrl := &Controller{executor: osexec.New()}
+	exp := []string{
+		filepath.Join(dir, "aqua.yaml"),
+		filepath.Join(dir, "foo", "build", "aqua.yaml"),
+		filepath.Join(dir, "keep.local", "aqua.yaml"),
+	}
+	if diff := cmp.Diff(exp, ctrl.filterIgnoredPaths(t.Context(), logrus.NewEntry(logrus.New()), dir, paths)); diff != "" {
+		t.Fatal(diff)
+	}
+}
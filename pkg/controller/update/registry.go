@@ -16,12 +16,18 @@ func (c *Controller) newRegistryVersion(ctx context.Context, logE *logrus.Entry,
 		return "", nil
 	}
 
+	key := rgst.RepoOwner + "/" + rgst.RepoName
+	if version, ok := c.versionCache.getRegistry(key); ok {
+		return version, nil
+	}
+
 	logE.Debug("getting the latest release of a registry")
 	release, _, err := c.gh.GetLatestRelease(ctx, rgst.RepoOwner, rgst.RepoName)
 	if err != nil {
 		return "", fmt.Errorf("get the latest release by GitHub API: %w", err)
 	}
 	// TODO Get the latest tag if the latest release can't be got.
+	c.versionCache.setRegistry(key, release.GetTagName())
 	return release.GetTagName(), nil
 }
 
@@ -43,6 +49,7 @@ func (c *Controller) updateRegistries(ctx context.Context, logE *logrus.Entry, c
 			continue
 		}
 		newVersions[rgst.Name] = newVersion
+		c.report.add(cfgFilePath, rgst.Name, rgst.Ref, newVersion)
 	}
 
 	b, err := afero.ReadFile(c.fs, cfgFilePath)
@@ -0,0 +1,200 @@
+package update
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/github"
+	"github.com/aquaproj/aqua/v2/pkg/osfile"
+	"github.com/aquaproj/aqua/v2/pkg/versiongetter"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// maxReleaseNotePages is the maximum number of pages of GitHub Releases fetched per package.
+const maxReleaseNotePages = 10
+
+// breakingChangePattern matches lines of release notes about breaking changes.
+// e.g. "BREAKING CHANGE: ...", "## Breaking Changes", "feat!: ..."
+var breakingChangePattern = regexp.MustCompile(`(?i)\bbreaking\b|^\s*(?:[-*]\s+)?\w+(?:\([^)]*\))?!:`)
+
+// releaseNotes collects packages updated by `aqua update` to output release notes.
+// If the option --release-notes isn't set, releaseNotes is nil and methods do nothing.
+type releaseNotes struct {
+	updates []*packageUpdate
+}
+
+type packageUpdate struct {
+	pkg        *config.Package
+	oldVersion string
+	newVersion string
+}
+
+func newReleaseNotes(param *config.Param) *releaseNotes {
+	if param.ReleaseNotes == "" {
+		return nil
+	}
+	return &releaseNotes{}
+}
+
+// add adds a package to release notes if the package is updated.
+func (r *releaseNotes) add(pkg *config.Package, newVersion string) {
+	if r == nil || newVersion == "" || newVersion == pkg.Package.Version {
+		return
+	}
+	// Packages whose version is set by the field version and commit hashes aren't updated.
+	if pkg.Package.Pin || commitHashPattern.MatchString(pkg.Package.Version) {
+		return
+	}
+	for _, u := range r.updates {
+		if u.pkg.Package.Registry == pkg.Package.Registry && u.pkg.Package.Name == pkg.Package.Name && u.oldVersion == pkg.Package.Version {
+			return
+		}
+	}
+	r.updates = append(r.updates, &packageUpdate{
+		pkg:        pkg,
+		oldVersion: pkg.Package.Version,
+		newVersion: newVersion,
+	})
+}
+
+// writeReleaseNotes outputs a Markdown digest of GitHub Releases between old and new versions of updated packages.
+// If param.ReleaseNotes is "-", the digest is outputted to stdout.
+func (c *Controller) writeReleaseNotes(ctx context.Context, logE *logrus.Entry, param *config.Param, notes *releaseNotes) error {
+	if notes == nil {
+		return nil
+	}
+	buf := &bytes.Buffer{}
+	buf.WriteString("# Release Notes\n")
+	for _, u := range notes.updates {
+		logE := logE.WithFields(logrus.Fields{
+			"package_name": u.pkg.Package.Name,
+			"old_version":  u.oldVersion,
+			"new_version":  u.newVersion,
+		})
+		c.writePackageReleaseNotes(ctx, logE, buf, u)
+	}
+	if param.ReleaseNotes == "-" {
+		if _, err := buf.WriteTo(c.stdout); err != nil {
+			return fmt.Errorf("output release notes: %w", err)
+		}
+		return nil
+	}
+	if err := afero.WriteFile(c.fs, param.ReleaseNotes, buf.Bytes(), osfile.FilePermission); err != nil {
+		return fmt.Errorf("write release notes to a file: %w", logerr.WithFields(err, logrus.Fields{
+			"release_notes_file": param.ReleaseNotes,
+		}))
+	}
+	return nil
+}
+
+func (c *Controller) writePackageReleaseNotes(ctx context.Context, logE *logrus.Entry, buf *bytes.Buffer, u *packageUpdate) {
+	pkgInfo := u.pkg.PackageInfo
+	fmt.Fprintf(buf, "\n## %s %s -> %s\n", u.pkg.Package.Name, u.oldVersion, u.newVersion)
+	if !pkgInfo.HasRepo() {
+		buf.WriteString("\nRelease notes aren't available because the package isn't hosted on GitHub.\n")
+		return
+	}
+	releases, err := c.listReleasesBetween(ctx, pkgInfo.RepoOwner, pkgInfo.RepoName, u.oldVersion, u.newVersion)
+	if err != nil {
+		logerr.WithError(logE, err).Warn("list GitHub Releases to get release notes")
+		fmt.Fprintf(buf, "\nFailed to get release notes. https://github.com/%s/%s/releases\n", pkgInfo.RepoOwner, pkgInfo.RepoName)
+		return
+	}
+	if len(releases) == 0 {
+		fmt.Fprintf(buf, "\nNo release note is found. https://github.com/%s/%s/releases\n", pkgInfo.RepoOwner, pkgInfo.RepoName)
+		return
+	}
+	for _, release := range releases {
+		writeRelease(buf, release)
+	}
+}
+
+func writeRelease(buf *bytes.Buffer, release *github.RepositoryRelease) {
+	fmt.Fprintf(buf, "\n### [%s](%s)\n", release.GetTagName(), release.GetHTMLURL())
+	body := strings.TrimSpace(strings.ReplaceAll(release.GetBody(), "\r\n", "\n"))
+	if body == "" {
+		return
+	}
+	if breakingChanges := findBreakingChanges(body); len(breakingChanges) != 0 {
+		buf.WriteString("\n> [!WARNING]\n> This release may include breaking changes.\n>\n")
+		for _, line := range breakingChanges {
+			fmt.Fprintf(buf, "> - %s\n", line)
+		}
+	}
+	buf.WriteString("\n" + body + "\n")
+}
+
+// findBreakingChanges returns lines about breaking changes in the release note.
+func findBreakingChanges(body string) []string {
+	var lines []string
+	for line := range strings.SplitSeq(body, "\n") {
+		if !breakingChangePattern.MatchString(line) {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "-*#"))
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// listReleasesBetween returns GitHub Releases newer than oldVersion and older than or equal to newVersion.
+// If versions can't be parsed as semantic versions, only the release of newVersion is returned.
+func (c *Controller) listReleasesBetween(ctx context.Context, repoOwner, repoName, oldVersion, newVersion string) ([]*github.RepositoryRelease, error) {
+	oldV, oldPrefix, oldErr := versiongetter.GetVersionAndPrefix(oldVersion)
+	newV, newPrefix, newErr := versiongetter.GetVersionAndPrefix(newVersion)
+	isSemver := oldErr == nil && newErr == nil && oldV != nil && newV != nil && oldPrefix == newPrefix
+
+	releases := []*github.RepositoryRelease{}
+	opt := &github.ListOptions{
+		PerPage: 100, //nolint:mnd
+	}
+	for range maxReleaseNotePages {
+		arr, resp, err := c.gh.ListReleases(ctx, repoOwner, repoName, opt)
+		if err != nil {
+			return nil, fmt.Errorf("list GitHub Releases: %w", err)
+		}
+		reachOld := false
+		for _, release := range arr {
+			tag := release.GetTagName()
+			if tag == oldVersion {
+				reachOld = true
+				continue
+			}
+			if !isSemver {
+				if tag == newVersion {
+					return []*github.RepositoryRelease{release}, nil
+				}
+				continue
+			}
+			if release.GetDraft() {
+				continue
+			}
+			v, prefix, err := versiongetter.GetVersionAndPrefix(tag)
+			if err != nil || v == nil || prefix != oldPrefix {
+				continue
+			}
+			if v.LessThanOrEqual(oldV) {
+				reachOld = true
+				continue
+			}
+			if v.GreaterThan(newV) {
+				continue
+			}
+			releases = append(releases, release)
+		}
+		if reachOld || resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return releases, nil
+}
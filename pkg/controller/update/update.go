@@ -14,7 +14,26 @@ import (
 )
 
 func (c *Controller) Update(ctx context.Context, logE *logrus.Entry, param *config.Param) error {
-	if err := c.updateCommands(ctx, logE, param); err != nil {
+	notes := newReleaseNotes(param)
+	report := newUpdateReport(param)
+	ctrl := c.withVersionCache(newVersionCache()).withUpdateReport(report)
+	if param.DryRun {
+		return ctrl.updateDryRun(ctx, logE, param, notes)
+	}
+	if err := ctrl.updateAll(ctx, logE, param, notes); err != nil {
+		return err
+	}
+	if err := ctrl.writeUpdateReport(param.PWD, report); err != nil {
+		return err
+	}
+	return ctrl.writeReleaseNotes(ctx, logE, param, notes)
+}
+
+func (c *Controller) updateAll(ctx context.Context, logE *logrus.Entry, param *config.Param, notes *releaseNotes) error {
+	if param.Recursive {
+		return c.updateRecursively(ctx, logE, param, notes)
+	}
+	if err := c.updateCommands(ctx, logE, param, notes); err != nil {
 		return err
 	}
 	if len(param.Args) != 0 {
@@ -25,23 +44,20 @@ func (c *Controller) Update(ctx context.Context, logE *logrus.Entry, param *conf
 	if err != nil {
 		return fmt.Errorf("find a configuration file: %w", err)
 	}
-	if err := c.update(ctx, logE, param, cfgFilePath); err != nil {
-		return err
-	}
-	return nil
+	return c.update(ctx, logE, param, cfgFilePath, notes)
 }
 
-func (c *Controller) updateCommands(ctx context.Context, logE *logrus.Entry, param *config.Param) error {
+func (c *Controller) updateCommands(ctx context.Context, logE *logrus.Entry, param *config.Param, notes *releaseNotes) error {
 	newVersions := map[string]string{}
 	for _, arg := range param.Args {
-		if err := c.updateCommand(ctx, logE, param, newVersions, arg); err != nil {
+		if err := c.updateCommand(ctx, logE, param, newVersions, arg, notes); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (c *Controller) updateCommand(ctx context.Context, logE *logrus.Entry, param *config.Param, newVersions map[string]string, cmd string) error {
+func (c *Controller) updateCommand(ctx context.Context, logE *logrus.Entry, param *config.Param, newVersions map[string]string, cmd string, notes *releaseNotes) error {
 	command, newVersion, _ := strings.Cut(cmd, "@")
 	findResult, err := c.which.Which(ctx, logE, param, command)
 	if err != nil {
@@ -56,9 +72,11 @@ func (c *Controller) updateCommand(ctx context.Context, logE *logrus.Entry, para
 	if newVersion != "" {
 		newVersions[fmt.Sprintf("%s,%s", pkg.Package.Registry, pkg.PackageInfo.GetName())] = newVersion
 		newVersions[fmt.Sprintf("%s,%s", pkg.Package.Registry, pkg.Package.Name)] = newVersion
+		notes.add(pkg, newVersion)
 	} else if newVersion := c.getPackageNewVersion(ctx, logE, param, nil, pkg); newVersion != "" {
 		newVersions[fmt.Sprintf("%s,%s", pkg.Package.Registry, pkg.PackageInfo.GetName())] = newVersion
 		newVersions[fmt.Sprintf("%s,%s", pkg.Package.Registry, pkg.Package.Name)] = newVersion
+		notes.add(pkg, newVersion)
 	}
 	filePath := findResult.ConfigFilePath
 	if pkg.Package.FilePath != "" {
@@ -70,7 +88,7 @@ func (c *Controller) updateCommand(ctx context.Context, logE *logrus.Entry, para
 	return nil
 }
 
-func (c *Controller) update(ctx context.Context, logE *logrus.Entry, param *config.Param, cfgFilePath string) error { //nolint:cyclop
+func (c *Controller) update(ctx context.Context, logE *logrus.Entry, param *config.Param, cfgFilePath string, notes *releaseNotes) error { //nolint:cyclop
 	cfg := &aqua.Config{}
 	if cfgFilePath == "" {
 		return finder.ErrConfigFileNotFound
@@ -95,7 +113,7 @@ func (c *Controller) update(ctx context.Context, logE *logrus.Entry, param *conf
 			return err //nolint:wrapcheck
 		}
 
-		if err := c.updatePackages(ctx, logE, param, cfgFilePath, registryConfigs); err != nil {
+		if err := c.updatePackages(ctx, logE, param, cfgFilePath, registryConfigs, notes); err != nil {
 			return err
 		}
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/fuzzyfinder"
 	"github.com/aquaproj/aqua/v2/pkg/github"
 	rgst "github.com/aquaproj/aqua/v2/pkg/install-registry"
+	"github.com/aquaproj/aqua/v2/pkg/osexec"
 	"github.com/aquaproj/aqua/v2/pkg/ptr"
 	"github.com/aquaproj/aqua/v2/pkg/runtime"
 	"github.com/aquaproj/aqua/v2/pkg/testutil"
@@ -28,6 +29,7 @@ func TestController_Update(t *testing.T) { //nolint:funlen,maintidx
 		name           string
 		isErr          bool
 		files          map[string]string
+		gitIgnored     string
 		expFiles       map[string]string
 		param          *config.Param
 		releases       []*github.RepositoryRelease
@@ -199,6 +201,221 @@ packages:
 				},
 			},
 		},
+		{
+			name: "update rules",
+			rt: &runtime.Runtime{
+				GOOS:   "darwin",
+				GOARCH: "arm64",
+			},
+			param: &config.Param{
+				PWD: "/workspace",
+			},
+			versions: map[string]string{
+				"suzuki-shunsuke/tfcmt": "v4.0.0",
+				"cli/cli":               "v2.30.0",
+			},
+			registries: map[string]*registry.Config{
+				"standard": {
+					PackageInfos: registry.PackageInfos{
+						{
+							Type:      "github_release",
+							RepoOwner: "suzuki-shunsuke",
+							RepoName:  "tfcmt",
+							Asset:     "tfcmt_{{.OS}}_{{.Arch}}.tar.gz",
+						},
+						{
+							Type:      "github_release",
+							RepoOwner: "cli",
+							RepoName:  "cli",
+							Asset:     "gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.zip",
+						},
+					},
+				},
+			},
+			files: map[string]string{
+				"/workspace/aqua.yaml": `registries:
+- type: standard
+  ref: v4.0.0
+packages:
+- name: suzuki-shunsuke/tfcmt@v3.0.0
+  update:
+    types: [minor, patch]
+- name: cli/cli@v2.0.0
+  update:
+    allowed_version: semver("< 2.20.0")
+`,
+			},
+			expFiles: map[string]string{
+				"/workspace/aqua.yaml": `registries:
+- type: standard
+  ref: v4.60.0
+packages:
+- name: suzuki-shunsuke/tfcmt@v3.0.0
+  update:
+    types: [minor, patch]
+- name: cli/cli@v2.0.0
+  update:
+    allowed_version: semver("< 2.20.0")
+`,
+			},
+			releases: []*github.RepositoryRelease{
+				{
+					TagName: ptr.String("v4.60.0"),
+				},
+			},
+		},
+		{
+			name: "recursive",
+			rt: &runtime.Runtime{
+				GOOS:   "darwin",
+				GOARCH: "arm64",
+			},
+			param: &config.Param{
+				PWD:           "/workspace",
+				Recursive:     true,
+				ExcludedPaths: []string{"testdata"},
+			},
+			versions: map[string]string{
+				"suzuki-shunsuke/tfcmt": "v4.0.0",
+			},
+			registries: map[string]*registry.Config{
+				"standard": {
+					PackageInfos: registry.PackageInfos{
+						{
+							Type:      "github_release",
+							RepoOwner: "suzuki-shunsuke",
+							RepoName:  "tfcmt",
+							Asset:     "tfcmt_{{.OS}}_{{.Arch}}.tar.gz",
+						},
+					},
+				},
+			},
+			files: map[string]string{
+				"/workspace/aqua.yaml": `registries:
+- type: standard
+  ref: v4.0.0
+packages:
+- name: suzuki-shunsuke/tfcmt@v3.0.0
+`,
+				"/workspace/foo/.aqua/aqua.yaml": `registries:
+- type: standard
+  ref: v4.0.0
+packages:
+- name: suzuki-shunsuke/tfcmt@v3.0.0
+`,
+				"/workspace/testdata/aqua.yaml": `registries:
+- type: standard
+  ref: v4.0.0
+`,
+				"/workspace/node_modules/foo/aqua.yaml": `registries:
+- type: standard
+  ref: v4.0.0
+`,
+				"/workspace/tmp/aqua.yaml": `registries:
+- type: standard
+  ref: v4.0.0
+`,
+			},
+			gitIgnored: "tmp/aqua.yaml\n",
+			expFiles: map[string]string{
+				"/workspace/tmp/aqua.yaml": `registries:
+- type: standard
+  ref: v4.0.0
+`,
+				"/workspace/aqua.yaml": `registries:
+- type: standard
+  ref: v4.60.0
+packages:
+- name: suzuki-shunsuke/tfcmt@v4.0.0
+`,
+				"/workspace/foo/.aqua/aqua.yaml": `registries:
+- type: standard
+  ref: v4.60.0
+packages:
+- name: suzuki-shunsuke/tfcmt@v4.0.0
+`,
+				"/workspace/testdata/aqua.yaml": `registries:
+- type: standard
+  ref: v4.0.0
+`,
+				"/workspace/node_modules/foo/aqua.yaml": `registries:
+- type: standard
+  ref: v4.0.0
+`,
+			},
+			releases: []*github.RepositoryRelease{
+				{
+					TagName: ptr.String("v4.60.0"),
+				},
+			},
+		},
+		{
+			name: "recursive with arguments",
+			param: &config.Param{
+				PWD:       "/workspace",
+				Recursive: true,
+				Args:      []string{"tfcmt"},
+			},
+			files: map[string]string{
+				"/workspace/aqua.yaml": `registries:
+- type: standard
+  ref: v4.0.0
+`,
+			},
+			isErr: true,
+		},
+		{
+			name: "version source",
+			rt: &runtime.Runtime{
+				GOOS:   "darwin",
+				GOARCH: "arm64",
+			},
+			param: &config.Param{
+				PWD:                 "/workspace",
+				OnlyPackage:         true,
+				UpdateVersionSource: true,
+			},
+			versions: map[string]string{
+				"golang/go":           "go1.22.1",
+				"hashicorp/terraform": "v1.8.0",
+			},
+			registries: map[string]*registry.Config{
+				"standard": {
+					PackageInfos: registry.PackageInfos{
+						{
+							Type:      "github_release",
+							RepoOwner: "golang",
+							RepoName:  "go",
+							Asset:     "go{{.Version}}.{{.OS}}-{{.Arch}}.tar.gz",
+						},
+						{
+							Type:      "github_release",
+							RepoOwner: "hashicorp",
+							RepoName:  "terraform",
+							Asset:     "terraform_{{trimV .Version}}_{{.OS}}_{{.Arch}}.zip",
+						},
+					},
+				},
+			},
+			files: map[string]string{
+				"/workspace/aqua.yaml": `registries:
+- type: standard
+  ref: v4.0.0
+packages:
+- name: golang/go
+  go_version_file: go.mod
+- name: hashicorp/terraform
+  version_expr: readFile(".terraform-version")
+  version_expr_prefix: v
+`,
+				"/workspace/go.mod":             "module example.com/foo\n\ngo 1.21.0\n\ntoolchain go1.21.5\n",
+				"/workspace/.terraform-version": "1.7.0\n",
+			},
+			expFiles: map[string]string{
+				"/workspace/go.mod":             "module example.com/foo\n\ngo 1.22.1\n\ntoolchain go1.22.1\n",
+				"/workspace/.terraform-version": "1.8.0\n",
+			},
+		},
 		{
 			name: "only registry",
 			param: &config.Param{
@@ -221,6 +438,35 @@ packages:
 packages:
 - name: suzuki-shunsuke/tfcmt@v3.0.0
 - name: cli/cli@v2.0.0
+`,
+			},
+			releases: []*github.RepositoryRelease{
+				{
+					TagName: ptr.String("v4.60.0"),
+				},
+			},
+		},
+		{
+			name: "dry run",
+			param: &config.Param{
+				PWD:          "/workspace",
+				OnlyRegistry: true,
+				DryRun:       true,
+			},
+			files: map[string]string{
+				"/workspace/aqua.yaml": `registries:
+- type: standard
+  ref: v4.0.0
+packages:
+- name: suzuki-shunsuke/tfcmt@v3.0.0
+`,
+			},
+			expFiles: map[string]string{
+				"/workspace/aqua.yaml": `registries:
+- type: standard
+  ref: v4.0.0
+packages:
+- name: suzuki-shunsuke/tfcmt@v3.0.0
 `,
 			},
 			releases: []*github.RepositoryRelease{
@@ -283,6 +529,99 @@ packages:
 packages:
 - name: suzuki-shunsuke/tfcmt@v4.0.0
 - name: cli/cli@v2.30.0
+`,
+			},
+		},
+		{
+			name: "release notes",
+			rt: &runtime.Runtime{
+				GOOS:   "darwin",
+				GOARCH: "arm64",
+			},
+			param: &config.Param{
+				PWD:          "/workspace",
+				OnlyPackage:  true,
+				ReleaseNotes: "/workspace/release-notes.md",
+			},
+			versions: map[string]string{
+				"suzuki-shunsuke/tfcmt": "v4.0.0",
+				"cli/cli":               "v2.30.0",
+			},
+			registries: map[string]*registry.Config{
+				"standard": {
+					PackageInfos: registry.PackageInfos{
+						{
+							Type:      "github_release",
+							RepoOwner: "suzuki-shunsuke",
+							RepoName:  "tfcmt",
+							Asset:     "tfcmt_{{.OS}}_{{.Arch}}.tar.gz",
+						},
+						{
+							Type:      "github_release",
+							RepoOwner: "cli",
+							RepoName:  "cli",
+							Asset:     "gh_{{trimV .Version}}_{{.OS}}_{{.Arch}}.zip",
+						},
+					},
+				},
+			},
+			releases: []*github.RepositoryRelease{
+				{
+					TagName: ptr.String("v4.0.0"),
+					HTMLURL: ptr.String("https://github.com/suzuki-shunsuke/tfcmt/releases/tag/v4.0.0"),
+					Body:    ptr.String("## Features\n\n- feat!: remove the option --foo\n- fix a bug\n"),
+				},
+				{
+					TagName: ptr.String("v3.1.0"),
+					HTMLURL: ptr.String("https://github.com/suzuki-shunsuke/tfcmt/releases/tag/v3.1.0"),
+					Body:    ptr.String("- add the option --bar\r\n"),
+				},
+				{
+					TagName: ptr.String("v3.0.0"),
+					HTMLURL: ptr.String("https://github.com/suzuki-shunsuke/tfcmt/releases/tag/v3.0.0"),
+					Body:    ptr.String("BREAKING CHANGE: drop Go 1.20"),
+				},
+			},
+			files: map[string]string{
+				"/workspace/aqua.yaml": `registries:
+- type: standard
+  ref: v4.0.0
+packages:
+- name: suzuki-shunsuke/tfcmt@v3.0.0
+- name: cli/cli@v2.0.0
+`,
+			},
+			expFiles: map[string]string{
+				"/workspace/aqua.yaml": `registries:
+- type: standard
+  ref: v4.0.0
+packages:
+- name: suzuki-shunsuke/tfcmt@v4.0.0
+- name: cli/cli@v2.30.0
+`,
+				"/workspace/release-notes.md": `# Release Notes
+
+## suzuki-shunsuke/tfcmt v3.0.0 -> v4.0.0
+
+### [v4.0.0](https://github.com/suzuki-shunsuke/tfcmt/releases/tag/v4.0.0)
+
+> [!WARNING]
+> This release may include breaking changes.
+>
+> - feat!: remove the option --foo
+
+## Features
+
+- feat!: remove the option --foo
+- fix a bug
+
+### [v3.1.0](https://github.com/suzuki-shunsuke/tfcmt/releases/tag/v3.1.0)
+
+- add the option --bar
+
+## cli/cli v2.0.0 -> v2.30.0
+
+No release note is found. https://github.com/cli/cli/releases
 `,
 			},
 		},
@@ -528,7 +867,7 @@ packages:
 				FindResults: d.findResults,
 			}
 			fuzzyGetter := versiongetter.NewMockFuzzyGetter(d.versions)
-			ctrl := update.New(d.param, gh, configFinder, configReader, registryInstaller, fs, d.rt, fuzzyGetter, fuzzyFinder, whichCtrl)
+			ctrl := update.New(d.param, gh, configFinder, configReader, registryInstaller, fs, d.rt, fuzzyGetter, fuzzyFinder, whichCtrl, &osexec.Mock{Output: d.gitIgnored})
 			if err := ctrl.Update(ctx, logE, d.param); err != nil {
 				if d.isErr {
 					return
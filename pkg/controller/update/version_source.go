@@ -0,0 +1,266 @@
+package update
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/expr"
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+var (
+	goDirectivePattern        = regexp.MustCompile(`(?m)^go \d+\.\d+.\d+$`)
+	toolchainDirectivePattern = regexp.MustCompile(`(?m)^toolchain go\S+$`)
+	goVersionPattern          = regexp.MustCompile(`^(?:go|v)?(\d+\.\d+\.\d+)$`)
+
+	errInvalidGoVersion       = errors.New("the new version of go_version_file must be a semver x.y.z")
+	errGoDirectiveNotFound    = errors.New("no go directive is found")
+	errVersionPrefixUnmatched = errors.New("the new version doesn't have version_expr_prefix")
+	errVersionFieldNotFound   = errors.New("the field of version_expr isn't found")
+	errVersionMustBeString    = errors.New("the field of version_expr must be a string")
+	errVersionUnmatched       = errors.New("the current value of version_expr is unmatched with the package version")
+)
+
+// updateVersionSources updates files referred by go_version_file and version_expr.
+// Packages using them are resolved when aqua.yaml is read, so the new versions are written to the source files instead of aqua.yaml.
+// This is enabled only if the option --version-source is set.
+func (c *Controller) updateVersionSources(ctx context.Context, logE *logrus.Entry, param *config.Param, cfgFilePath string, rgstCfgs map[string]*registry.Config, updatedPkgs map[string]struct{}, notes *releaseNotes) error {
+	cfg := &aqua.Config{}
+	if err := c.configReader.Read(logE, cfgFilePath, cfg); err != nil {
+		return fmt.Errorf("read a configuration file: %w", err)
+	}
+	srcCfg := &aqua.Config{
+		Registries: cfg.Registries,
+	}
+	for _, pkg := range cfg.Packages {
+		if pkg.GoVersionFile != "" || pkg.VersionExpr != "" {
+			srcCfg.Packages = append(srcCfg.Packages, pkg)
+		}
+	}
+	if len(srcCfg.Packages) == 0 {
+		return nil
+	}
+	pkgs, _ := config.ListPackages(logE, srcCfg, c.runtime, rgstCfgs)
+	for _, pkg := range pkgs {
+		if !param.Insert && !pkg.Package.Update.GetEnabled() {
+			continue
+		}
+		logE := logE.WithFields(logrus.Fields{
+			"package_name":    pkg.Package.Name,
+			"package_version": pkg.Package.Version,
+			"registry":        pkg.Package.Registry,
+		})
+		if !aqua.FilterPackageByTag(pkg.Package, param.Tags, param.ExcludedTags) {
+			logE.Debug("skip updating the package because package tags are unmatched")
+			continue
+		}
+		newVersion := c.getPackageNewVersion(ctx, logE, param, updatedPkgs, pkg)
+		if newVersion == "" || newVersion == pkg.Package.Version {
+			continue
+		}
+		updated, err := c.updateVersionSource(logE, pkg.Package, newVersion)
+		if err != nil {
+			return fmt.Errorf("update a version source: %w", logerr.WithFields(err, logrus.Fields{
+				"package_name":    pkg.Package.Name,
+				"go_version_file": pkg.Package.GoVersionFile,
+				"version_expr":    pkg.Package.VersionExpr,
+			}))
+		}
+		if updated {
+			notes.add(pkg, newVersion)
+			c.report.addPackage(pkg.Package.FilePath, pkg, newVersion)
+		}
+	}
+	return nil
+}
+
+func (c *Controller) updateVersionSource(logE *logrus.Entry, pkg *aqua.Package, newVersion string) (bool, error) {
+	dir := filepath.Dir(pkg.FilePath)
+	if pkg.GoVersionFile != "" {
+		return c.updateGoVersionFile(logE, filepath.Join(dir, pkg.GoVersionFile), pkg.Version, newVersion)
+	}
+	src, err := expr.ParseVersionSource(pkg.VersionExpr)
+	if err != nil {
+		logerr.WithError(logE, err).Warn("skip updating the package because version_expr is too complicated to update")
+		return false, nil
+	}
+	oldValue, ok := strings.CutPrefix(pkg.Version, pkg.VersionExprPrefix)
+	if !ok {
+		return false, errVersionUnmatched
+	}
+	newValue, ok := strings.CutPrefix(newVersion, pkg.VersionExprPrefix)
+	if !ok {
+		return false, logerr.WithFields(errVersionPrefixUnmatched, logrus.Fields{ //nolint:wrapcheck
+			"new_version":         newVersion,
+			"version_expr_prefix": pkg.VersionExprPrefix,
+		})
+	}
+	if oldValue == newValue {
+		return false, nil
+	}
+	p := src.Path
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(dir, p)
+	}
+	logE = logE.WithField("version_source", p)
+	return c.updateSourceFile(logE, p, oldValue, newValue, func(b []byte) ([]byte, error) {
+		switch src.Func {
+		case "readJSON":
+			return replaceJSONValue(b, src.Keys, oldValue, newValue)
+		case "readYAML":
+			return replaceYAMLValue(b, src.Keys, oldValue, newValue)
+		default:
+			return replaceFileValue(b, oldValue, newValue)
+		}
+	})
+}
+
+// updateGoVersionFile updates the go directive of go.mod or go.work.
+// If the toolchain directive exists, it's also updated.
+func (c *Controller) updateGoVersionFile(logE *logrus.Entry, p, oldVersion, newVersion string) (bool, error) {
+	matches := goVersionPattern.FindStringSubmatch(newVersion)
+	if matches == nil {
+		return false, logerr.WithFields(errInvalidGoVersion, logrus.Fields{ //nolint:wrapcheck
+			"new_version": newVersion,
+		})
+	}
+	newValue := matches[1]
+	if newValue == oldVersion {
+		return false, nil
+	}
+	logE = logE.WithField("go_version_file", p)
+	return c.updateSourceFile(logE, p, oldVersion, newValue, func(b []byte) ([]byte, error) {
+		if !goDirectivePattern.Match(b) {
+			return nil, errGoDirectiveNotFound
+		}
+		b = goDirectivePattern.ReplaceAll(b, []byte("go "+newValue))
+		return toolchainDirectivePattern.ReplaceAll(b, []byte("toolchain go"+newValue)), nil
+	})
+}
+
+func (c *Controller) updateSourceFile(logE *logrus.Entry, p, oldValue, newValue string, replace func(b []byte) ([]byte, error)) (bool, error) {
+	b, err := afero.ReadFile(c.fs, p)
+	if err != nil {
+		return false, fmt.Errorf("read a version source file: %w", err)
+	}
+	nb, err := replace(b)
+	if err != nil {
+		return false, err
+	}
+	if bytes.Equal(b, nb) {
+		return false, nil
+	}
+	stat, err := c.fs.Stat(p)
+	if err != nil {
+		return false, fmt.Errorf("get a version source file stat: %w", err)
+	}
+	logE.WithFields(logrus.Fields{
+		"old_version": oldValue,
+		"new_version": newValue,
+	}).Info("updating a version source file")
+	if err := afero.WriteFile(c.fs, p, nb, stat.Mode()); err != nil {
+		return false, fmt.Errorf("write a version source file: %w", err)
+	}
+	return true, nil
+}
+
+// replaceFileValue replaces the content of a file read by readFile keeping surrounding white spaces.
+func replaceFileValue(b []byte, oldValue, newValue string) ([]byte, error) {
+	if strings.TrimSpace(string(b)) != oldValue {
+		return nil, errVersionUnmatched
+	}
+	return bytes.Replace(b, []byte(oldValue), []byte(newValue), 1), nil
+}
+
+// replaceJSONValue replaces the string value of a JSON file.
+// Only the value is replaced so that the format of the file is kept.
+func replaceJSONValue(b []byte, keys []string, oldValue, newValue string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	for _, key := range keys {
+		if err := findJSONKey(decoder, key); err != nil {
+			return nil, err
+		}
+	}
+	var raw json.RawMessage
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("parse a JSON file: %w", err)
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, errVersionMustBeString
+	}
+	if s != oldValue {
+		return nil, errVersionUnmatched
+	}
+	value, err := json.Marshal(newValue)
+	if err != nil {
+		return nil, fmt.Errorf("encode the new version as JSON: %w", err)
+	}
+	end := int(decoder.InputOffset())
+	start := end - len(raw)
+	return append(append(append([]byte{}, b[:start]...), value...), b[end:]...), nil
+}
+
+// findJSONKey reads tokens until the value of the key in the next object.
+func findJSONKey(decoder *json.Decoder, key string) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return fmt.Errorf("parse a JSON file: %w", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return errVersionFieldNotFound
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("parse a JSON file: %w", err)
+		}
+		if k, ok := token.(string); ok && k == key {
+			return nil
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return fmt.Errorf("parse a JSON file: %w", err)
+		}
+	}
+	return errVersionFieldNotFound
+}
+
+// replaceYAMLValue replaces the string value of a YAML file keeping comments.
+func replaceYAMLValue(b []byte, keys []string, oldValue, newValue string) ([]byte, error) {
+	file, err := parser.ParseBytes(b, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parse a YAML file: %w", err)
+	}
+	builder := (&yaml.PathBuilder{}).Root()
+	for _, key := range keys {
+		builder = builder.Child(key)
+	}
+	node, err := builder.Build().FilterFile(file)
+	if err != nil {
+		return nil, errVersionFieldNotFound
+	}
+	sn, ok := node.(*ast.StringNode)
+	if !ok {
+		return nil, errVersionMustBeString
+	}
+	if sn.Value != oldValue {
+		return nil, errVersionUnmatched
+	}
+	sn.Value = newValue
+	return []byte(file.String()), nil
+}
@@ -0,0 +1,135 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_replaceJSONValue(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name     string
+		content  string
+		keys     []string
+		oldValue string
+		newValue string
+		exp      string
+		isErr    bool
+	}{
+		{
+			name: "nested",
+			content: `{
+  "name": "foo",
+  "volta": {"npm": "10.0.0", "node": "20.0.0"}
+}
+`,
+			keys:     []string{"volta", "node"},
+			oldValue: "20.0.0",
+			newValue: "22.1.0",
+			exp: `{
+  "name": "foo",
+  "volta": {"npm": "10.0.0", "node": "22.1.0"}
+}
+`,
+		},
+		{
+			name:     "not found",
+			content:  `{"volta": {"npm": "10.0.0"}}`,
+			keys:     []string{"volta", "node"},
+			oldValue: "20.0.0",
+			newValue: "22.1.0",
+			isErr:    true,
+		},
+		{
+			name:     "unmatched",
+			content:  `{"volta": {"node": "18.0.0"}}`,
+			keys:     []string{"volta", "node"},
+			oldValue: "20.0.0",
+			newValue: "22.1.0",
+			isErr:    true,
+		},
+		{
+			name:     "not string",
+			content:  `{"version": 1}`,
+			keys:     []string{"version"},
+			oldValue: "1",
+			newValue: "2",
+			isErr:    true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			b, err := replaceJSONValue([]byte(d.content), d.keys, d.oldValue, d.newValue)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(d.exp, string(b)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_replaceYAMLValue(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name     string
+		content  string
+		keys     []string
+		oldValue string
+		newValue string
+		exp      string
+		isErr    bool
+	}{
+		{
+			name: "keep comments",
+			content: `# versions of tools
+tools:
+  terraform: 1.5.0 # comment
+  tflint: "0.50.0"
+`,
+			keys:     []string{"tools", "tflint"},
+			oldValue: "0.50.0",
+			newValue: "0.51.0",
+			exp: `# versions of tools
+tools:
+  terraform: 1.5.0 # comment
+  tflint: "0.51.0"
+`,
+		},
+		{
+			name:     "not found",
+			content:  "tools:\n  terraform: 1.5.0\n",
+			keys:     []string{"tools", "tflint"},
+			oldValue: "0.50.0",
+			newValue: "0.51.0",
+			isErr:    true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			b, err := replaceYAMLValue([]byte(d.content), d.keys, d.oldValue, d.newValue)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(d.exp, string(b)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -151,6 +151,9 @@ func (c *Controller) getChecksums(ctx context.Context, logE *logrus.Entry, check
 	if err != nil {
 		return fmt.Errorf("get supported platforms: %w", err)
 	}
+	if pkg.PackageInfo.DependsOnLibc() {
+		rts = runtime.ExpandLibc(rts)
+	}
 
 	pkgs, assetNames, err := c.getPkgs(pkg, rts)
 	if err != nil {
@@ -158,7 +161,7 @@ func (c *Controller) getChecksums(ctx context.Context, logE *logrus.Entry, check
 	}
 	checksumFiles := map[string]struct{}{}
 	for _, rt := range rts {
-		env := rt.Env()
+		env := rt.LibcEnv()
 		logE := logE.WithFields(logrus.Fields{
 			"checksum_env": env,
 		})
@@ -177,7 +180,7 @@ func (c *Controller) getPkgs(pkg *config.Package, rts []*runtime.Runtime) (map[s
 	pkgs := make(map[string]*config.Package, len(rts))
 	assets := make(map[string]struct{}, len(rts))
 	for _, rt := range rts {
-		env := rt.Env()
+		env := rt.LibcEnv()
 		pkgInfo := pkg.PackageInfo
 		pkgInfo = pkgInfo.Copy()
 		pkgInfo.OverrideByRuntime(rt)
@@ -27,5 +27,5 @@ func New(param *config.Param, rt *runtime.Runtime, fs afero.Fs, vc Vacuum) *Cont
 
 type Vacuum interface {
 	FindAll(logE *logrus.Entry) (map[string]time.Time, error)
-	Remove(pkgPath string) error
+	Remove(pkgPaths ...string) error
 }
@@ -36,7 +36,7 @@ func New(param *config.Param, rt *runtime.Runtime, fs afero.Fs, vc Vacuum, confi
 }
 
 type Vacuum interface {
-	Create(pkgPath string, timestamp time.Time) error
+	Create(pkgPaths []string, timestamp time.Time) error
 }
 
 type ConfigReader interface {
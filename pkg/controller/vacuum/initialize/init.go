@@ -55,7 +55,7 @@ func (c *Controller) create(ctx context.Context, logE *logrus.Entry, cfgFilePath
 	}
 
 	pkgs, _ := config.ListPackages(logE, cfg, c.runtime, registryContents)
-	now := time.Now()
+	pkgPaths := make([]string, 0, len(pkgs))
 	for _, pkg := range pkgs {
 		pkgPath, err := pkg.PkgPath(c.runtime)
 		if err != nil {
@@ -69,9 +69,10 @@ func (c *Controller) create(ctx context.Context, logE *logrus.Entry, cfgFilePath
 		} else if !f {
 			continue
 		}
-		if err := c.vacuum.Create(pkgPath, now); err != nil {
-			logerr.WithError(logE, err).Warn("create a timestamp file")
-		}
+		pkgPaths = append(pkgPaths, pkgPath)
+	}
+	if err := c.vacuum.Create(pkgPaths, time.Now()); err != nil {
+		logerr.WithError(logE, err).Warn("create timestamps")
 	}
 	return nil
 }
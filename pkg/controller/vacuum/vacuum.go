@@ -1,22 +1,34 @@
 package vacuum
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"time"
 
 	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
 	"github.com/aquaproj/aqua/v2/pkg/vacuum"
 	"github.com/sirupsen/logrus"
 	"github.com/suzuki-shunsuke/logrus-error/logerr"
 )
 
-func (c *Controller) Vacuum(logE *logrus.Entry, param *config.Param) error {
+func (c *Controller) Vacuum(logE *logrus.Entry, param *config.Param) (gErr error) {
 	timestamps, err := c.vacuum.FindAll(logE)
 	if err != nil {
-		return fmt.Errorf("find timestamp files: %w", err)
+		return fmt.Errorf("find timestamps: %w", err)
 	}
 	timestampChecker := vacuum.NewTimestampChecker(time.Now(), param.VacuumDays)
+	removedPaths := []string{}
+	defer func() {
+		// remove timestamps of removed packages at once
+		if len(removedPaths) == 0 {
+			return
+		}
+		if err := c.vacuum.Remove(removedPaths...); err != nil {
+			gErr = errors.Join(gErr, fmt.Errorf("remove timestamps: %w", err))
+		}
+	}()
 	for pkgPath, timestamp := range timestamps {
 		logE := logE.WithField("package_path", pkgPath)
 		if !timestampChecker.Expired(timestamp) {
@@ -29,11 +41,15 @@ func (c *Controller) Vacuum(logE *logrus.Entry, param *config.Param) error {
 				"package_path": p,
 			}))
 		}
-		// remove the timestamp file
-		if err := c.vacuum.Remove(pkgPath); err != nil {
-			return fmt.Errorf("remove a timestamp file: %w", err)
-		}
+		removedPaths = append(removedPaths, pkgPath)
 		logE.Info("removed the package")
 	}
+	removedCaches, err := registry.PruneCache(c.fs, c.rootDir, timestampChecker.Expired)
+	for _, p := range removedCaches {
+		logE.WithField("registry_cache", p).Info("removed the registry cache")
+	}
+	if err != nil {
+		return fmt.Errorf("remove unused registry caches: %w", err)
+	}
 	return nil
 }
@@ -5,4 +5,6 @@ import "errors"
 var (
 	ErrCommandIsNotFound = errors.New("command is not found")
 	errVersionIsRequired = errors.New("version is required")
+
+	errCircularExtends = errors.New("registries extend each other circularly")
 )
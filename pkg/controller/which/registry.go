@@ -0,0 +1,122 @@
+package which
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// registryCacheKey returns the key of the registry cache.
+// github_content registries are pinned by ref, and the checksum of the registry is used as the content hash if it's recorded.
+// local registries can be changed anytime, so they are identified by only the hash of the registry file.
+// The path isn't used so that the cache is still valid after a checkout is moved.
+// If the registry can't be cached, registryCacheKey returns nil.
+func (c *Controller) registryCacheKey(rg *aqua.Registry, cfgFilePath string, checksums *checksum.Checksums) (*registry.CacheKey, error) {
+	key := &registry.CacheKey{
+		Type: rg.Type,
+	}
+	switch rg.Type {
+	case aqua.RegistryTypeGitHubContent:
+		key.Path = rg.Path
+		key.RepoOwner = rg.RepoOwner
+		key.RepoName = rg.RepoName
+		key.Ref = rg.Ref
+		if checksums == nil {
+			return key, nil
+		}
+		if chk := checksums.Get(checksum.RegistryID(rg)); chk != nil {
+			key.ContentHash = chk.Algorithm + ":" + chk.Checksum
+		}
+		return key, nil
+	case aqua.RegistryTypeLocal:
+		p, err := rg.FilePath(c.rootDir, cfgFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("get a registry file path: %w", err)
+		}
+		b, err := afero.ReadFile(c.fs, p)
+		if err != nil {
+			return nil, fmt.Errorf("read a local registry: %w", err)
+		}
+		sum := sha256.Sum256(b)
+		key.ContentHash = "sha256:" + hex.EncodeToString(sum[:])
+		return key, nil
+	}
+	return nil, nil //nolint:nilnil
+}
+
+// registryIndex reads the index of the registry.
+// Indexes are created when github_content registries are installed.
+// If the index isn't available, registryIndex returns nil.
+func (c *Controller) registryIndex(logE *logrus.Entry, rg *aqua.Registry, cfgFilePath string, indexes map[string]*registry.Index) *registry.Index {
+	if idx, ok := indexes[rg.Name]; ok {
+		return idx
+	}
+	indexes[rg.Name] = nil
+	if rg.Type != aqua.RegistryTypeGitHubContent {
+		return nil
+	}
+	p, err := rg.FilePath(c.rootDir, cfgFilePath)
+	if err != nil {
+		return nil
+	}
+	idx, err := registry.ReadIndex(c.fs, registry.IndexFilePath(p))
+	if err != nil {
+		logerr.WithError(logE, err).Debug("read a registry index")
+		return nil
+	}
+	indexes[rg.Name] = idx
+	return idx
+}
+
+// extendPackage deep-merges the patch of the extending registry onto the package of the extended registry.
+// The package of the extended registry is got via findPkgInfo, so the registry cache and index of the extended registry are used.
+// Merged packages aren't cached because the cache key of the extending registry doesn't cover the extended registry.
+func (c *Controller) extendPackage(ctx context.Context, logE *logrus.Entry, cfgFilePath string, cfg *aqua.Config, rCache *registry.Cache, cacheKeys map[string]*registry.CacheKey, indexes map[string]*registry.Index, registries map[string]*registry.Config, exeName string, pkg *aqua.Package, checksums *checksum.Checksums, extends string, patch *registry.PackageInfo) (*registry.PackageInfo, bool, error) {
+	baseName, err := cfg.Registries.ExtendedRegistryName(extends)
+	if err != nil {
+		return nil, false, fmt.Errorf("resolve the extended registry: %w", err)
+	}
+	for name := baseName; ; {
+		if name == pkg.Registry {
+			return nil, false, errCircularExtends
+		}
+		rc, ok := registries[name]
+		if !ok || rc.Extends == "" {
+			break
+		}
+		next, err := cfg.Registries.ExtendedRegistryName(rc.Extends)
+		if err != nil {
+			break
+		}
+		name = next
+	}
+	basePkg := *pkg
+	basePkg.Registry = baseName
+	if patch != nil {
+		// The patch may add the command, so the package of the extended registry must not be skipped.
+		exeName = ""
+	}
+	baseInfo, skip, err := c.findPkgInfo(ctx, logE.WithField("extended_registry_name", baseName), cfgFilePath, cfg, rCache, cacheKeys, indexes, registries, exeName, &basePkg, checksums)
+	if err != nil || skip {
+		return nil, skip, err
+	}
+	if baseInfo == nil {
+		return patch, false, nil
+	}
+	if patch == nil {
+		return baseInfo, false, nil
+	}
+	pkgInfo, err := registry.MergePackage(baseInfo, patch)
+	if err != nil {
+		return nil, false, fmt.Errorf("merge a patch onto the package of the extended registry: %w", err)
+	}
+	return pkgInfo, false, nil
+}
@@ -0,0 +1,154 @@
+package which
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aquaproj/aqua/v2/pkg/config"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/sirupsen/logrus"
+)
+
+// Output is the machine-readable result of `aqua which`.
+type Output struct {
+	ExeName        string `json:"exe_name"`
+	ExePath        string `json:"exe_path"`
+	PackageName    string `json:"package_name,omitempty"`
+	PackageVersion string `json:"package_version,omitempty"`
+	RegistryName   string `json:"registry_name,omitempty"`
+	ConfigFilePath string `json:"config_file_path,omitempty"`
+	// ImportFilePath is the file where the package is defined.
+	// It's empty if the package is defined in the configuration file directly.
+	ImportFilePath string `json:"import_file_path,omitempty"`
+}
+
+// Output converts the result to Output.
+func (r *FindResult) Output(exeName string) *Output {
+	out := &Output{
+		ExeName: exeName,
+		ExePath: r.ExePath,
+	}
+	if r.Package == nil {
+		return out
+	}
+	pkg := r.Package.Package
+	out.PackageName = pkg.Name
+	out.PackageVersion = pkg.Version
+	out.RegistryName = pkg.Registry
+	out.ConfigFilePath = r.ConfigFilePath
+	if pkg.FilePath != r.ConfigFilePath {
+		out.ImportFilePath = pkg.FilePath
+	}
+	return out
+}
+
+// Trace records how a command is resolved.
+// Configuration files are recorded in the order they are checked.
+type Trace struct {
+	ExeName string         `json:"exe_name"`
+	Configs []*ConfigTrace `json:"config_files"`
+	// PATH is the executable file path found in the environment variable PATH.
+	PATH   string  `json:"path,omitempty"`
+	Result *Output `json:"result,omitempty"`
+}
+
+// ConfigTrace records packages which may provide the command in a configuration file.
+type ConfigTrace struct {
+	ConfigFilePath string       `json:"config_file_path"`
+	Global         bool         `json:"global,omitempty"`
+	SkipReason     string       `json:"skip_reason,omitempty"`
+	Candidates     []*Candidate `json:"candidates,omitempty"`
+}
+
+// Candidate is a package which may provide the command.
+type Candidate struct {
+	PackageName    string   `json:"package_name"`
+	PackageVersion string   `json:"package_version,omitempty"`
+	RegistryName   string   `json:"registry_name"`
+	ImportFilePath string   `json:"import_file_path,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	// Command is the original command name if the command is an alias.
+	Command    string `json:"command,omitempty"`
+	Selected   bool   `json:"selected,omitempty"`
+	SkipReason string `json:"skip_reason,omitempty"`
+}
+
+// Why resolves the command same as Which and returns how the command is resolved.
+// Even if the command isn't found, Why doesn't return an error but returns the trace without the result.
+func (c *Controller) Why(ctx context.Context, logE *logrus.Entry, param *config.Param, exeName string) (*Trace, error) {
+	trace := &Trace{
+		ExeName: exeName,
+		Configs: []*ConfigTrace{},
+	}
+	findResult, err := c.which(ctx, logE, param, exeName, trace)
+	if err != nil {
+		if errors.Is(err, ErrCommandIsNotFound) {
+			return trace, nil
+		}
+		return nil, err
+	}
+	trace.Result = findResult.Output(exeName)
+	return trace, nil
+}
+
+// The following methods do nothing if the receiver is nil, so the resolution works without tracing.
+
+func (t *Trace) addConfig(cfgFilePath string, global bool) *ConfigTrace {
+	if t == nil {
+		return nil
+	}
+	cfgTrace := &ConfigTrace{
+		ConfigFilePath: cfgFilePath,
+		Global:         global,
+	}
+	t.Configs = append(t.Configs, cfgTrace)
+	return cfgTrace
+}
+
+func (t *Trace) setPATH(exePath string) {
+	if t == nil {
+		return
+	}
+	t.PATH = exePath
+}
+
+func (t *ConfigTrace) skip(reason string) {
+	if t == nil {
+		return
+	}
+	t.SkipReason = reason
+}
+
+func (t *ConfigTrace) add(pkg *aqua.Package) *Candidate {
+	if t == nil {
+		return nil
+	}
+	candidate := &Candidate{
+		PackageName:    pkg.Name,
+		PackageVersion: pkg.Version,
+		RegistryName:   pkg.Registry,
+		Tags:           pkg.Tags,
+	}
+	if pkg.FilePath != t.ConfigFilePath {
+		candidate.ImportFilePath = pkg.FilePath
+	}
+	t.Candidates = append(t.Candidates, candidate)
+	return candidate
+}
+
+func (c *Candidate) skip(reason string) {
+	if c == nil {
+		return
+	}
+	c.SkipReason = reason
+}
+
+func (c *Candidate) selected(cmdName, exeName string) {
+	if c == nil {
+		return
+	}
+	c.Selected = true
+	if cmdName != exeName {
+		c.Command = cmdName
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
 	"github.com/aquaproj/aqua/v2/pkg/config/registry"
 	"github.com/aquaproj/aqua/v2/pkg/osfile"
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
 	"github.com/sirupsen/logrus"
 	"github.com/suzuki-shunsuke/logrus-error/logerr"
 )
@@ -21,16 +22,22 @@ type FindResult struct {
 	ExePath        string
 	ConfigFilePath string
 	EnableChecksum bool
+	// Checksums is used instead of the checksum file of ConfigFilePath if it isn't nil.
+	Checksums *checksum.Checksums
 }
 
 func (c *Controller) Which(ctx context.Context, logE *logrus.Entry, param *config.Param, exeName string) (*FindResult, error) {
+	return c.which(ctx, logE, param, exeName, nil)
+}
+
+func (c *Controller) which(ctx context.Context, logE *logrus.Entry, param *config.Param, exeName string, trace *Trace) (*FindResult, error) {
 	var filePaths []string
 	if param.ConfigFilePath != "" {
 		filePaths = []string{osfile.Abs(param.PWD, param.ConfigFilePath)}
 	}
 	for _, cfgFilePath := range append(filePaths, c.configFinder.Finds(param.PWD, "")...) {
 		logE := logE.WithField("config_file_path", cfgFilePath)
-		findResult, err := c.findExecFile(ctx, logE, param, cfgFilePath, exeName)
+		findResult, err := c.findExecFile(ctx, logE, param, cfgFilePath, exeName, trace.addConfig(cfgFilePath, false))
 		if err != nil {
 			return nil, err
 		}
@@ -42,10 +49,12 @@ func (c *Controller) Which(ctx context.Context, logE *logrus.Entry, param *confi
 	for _, cfgFilePath := range param.GlobalConfigFilePaths {
 		logE := logE.WithField("config_file_path", cfgFilePath)
 		logE.Debug("checking a global configuration file")
+		cfgTrace := trace.addConfig(cfgFilePath, true)
 		if _, err := c.fs.Stat(cfgFilePath); err != nil {
+			cfgTrace.skip("the file isn't found")
 			continue
 		}
-		findResult, err := c.findExecFile(ctx, logE, param, cfgFilePath, exeName)
+		findResult, err := c.findExecFile(ctx, logE, param, cfgFilePath, exeName, cfgTrace)
 		if err != nil {
 			return nil, err
 		}
@@ -55,6 +64,7 @@ func (c *Controller) Which(ctx context.Context, logE *logrus.Entry, param *confi
 	}
 
 	if exePath := c.lookPath(c.osenv.Getenv("PATH"), exeName); exePath != "" {
+		trace.setPATH(exePath)
 		return &FindResult{
 			ExePath: exePath,
 		}, nil
@@ -66,12 +76,15 @@ func (c *Controller) Which(ctx context.Context, logE *logrus.Entry, param *confi
 }
 
 func (c *Controller) getExePath(findResult *FindResult) (string, error) {
-	pkg := findResult.Package
-	file := findResult.File
+	return GetExePath(c.rootDir, c.runtime, findResult.Package, findResult.File)
+}
+
+// GetExePath returns the absolute path of the executable file of the package.
+func GetExePath(rootDir string, rt *runtime.Runtime, pkg *config.Package, file *registry.File) (string, error) {
 	if pkg.Package.Version == "" {
 		return "", errVersionIsRequired
 	}
-	exePath, err := pkg.ExePath(c.rootDir, file, c.runtime)
+	exePath, err := pkg.ExePath(rootDir, file, rt)
 	if err != nil {
 		return exePath, err //nolint:wrapcheck
 	}
@@ -81,7 +94,7 @@ func (c *Controller) getExePath(findResult *FindResult) (string, error) {
 	return filepath.Join(filepath.Dir(exePath), file.Link), nil
 }
 
-func (c *Controller) findExecFile(ctx context.Context, logE *logrus.Entry, param *config.Param, cfgFilePath, exeName string) (*FindResult, error) {
+func (c *Controller) findExecFile(ctx context.Context, logE *logrus.Entry, param *config.Param, cfgFilePath, exeName string, cfgTrace *ConfigTrace) (*FindResult, error) {
 	cfg := &aqua.Config{}
 	if err := c.configReader.Read(logE, cfgFilePath, cfg); err != nil {
 		return nil, err //nolint:wrapcheck
@@ -95,30 +108,20 @@ func (c *Controller) findExecFile(ctx context.Context, logE *logrus.Entry, param
 	}
 	defer updateChecksum()
 
-	logE.Debug("reading registry cache")
-	registryCache, err := registry.NewCache(c.fs, param.RootDir, cfgFilePath)
-	if err != nil {
-		logerr.WithError(logE, err).WithField("config_file_path", cfgFilePath).Debug("read a registry cache file")
-	}
-
-	rgPaths := map[string]string{}
+	registryCache := registry.NewCache(c.fs, param.RootDir)
+	cacheKeys := map[string]*registry.CacheKey{}
+	indexes := map[string]*registry.Index{}
 	registries := map[string]*registry.Config{}
 
-	cacheKeys := map[string]map[string]struct{}{}
-	if err := c.setRegistryCacheKeys(cfg, cfgFilePath, rgPaths, cacheKeys); err != nil {
-		logerr.WithError(logE, err).Warn("set registry cache keys")
-	}
-
 	defer func() {
 		logE.Debug("updating registry cache")
-		registryCache.Clean(cacheKeys)
 		if err := registryCache.Write(); err != nil {
 			logerr.WithError(logE, err).Warn("write a registry cache file")
 		}
 	}()
 
 	for _, pkg := range cfg.Packages {
-		findResult, err := c.findExecFileFromPkg(ctx, logE, cfgFilePath, cfg, registryCache, rgPaths, registries, exeName, pkg, checksums)
+		findResult, err := c.findExecFileFromPkg(ctx, logE, cfgFilePath, cfg, registryCache, cacheKeys, indexes, registries, exeName, pkg, checksums, cfgTrace)
 		if err != nil {
 			return nil, err
 		}
@@ -132,37 +135,7 @@ func (c *Controller) findExecFile(ctx context.Context, logE *logrus.Entry, param
 	return nil, nil //nolint:nilnil
 }
 
-func (c *Controller) setRegistryCacheKeys(cfg *aqua.Config, cfgFilePath string, rgPaths map[string]string, cacheKeys map[string]map[string]struct{}) error {
-	for _, pkg := range cfg.Packages {
-		rg, ok := cfg.Registries[pkg.Registry]
-		if !ok {
-			continue
-		}
-		if rg.Type != aqua.RegistryTypeGitHubContent {
-			continue
-		}
-		rgPath, ok := rgPaths[pkg.Registry]
-		if !ok {
-			p, err := rg.FilePath(c.rootDir, cfgFilePath)
-			if err != nil {
-				return fmt.Errorf("get a registry file path: %w", err)
-			}
-			rgPath = p
-			rgPaths[pkg.Registry] = rgPath
-		}
-		keys, ok := cacheKeys[rgPath]
-		if !ok {
-			cacheKeys[rgPath] = map[string]struct{}{
-				pkg.Name: {},
-			}
-			continue
-		}
-		keys[pkg.Name] = struct{}{}
-	}
-	return nil
-}
-
-func (c *Controller) findExecFileFromPkg(ctx context.Context, logE *logrus.Entry, cfgFilePath string, cfg *aqua.Config, rCache *registry.Cache, rgPaths map[string]string, registries map[string]*registry.Config, exeName string, pkg *aqua.Package, checksums *checksum.Checksums) (*FindResult, error) { //nolint:cyclop
+func (c *Controller) findExecFileFromPkg(ctx context.Context, logE *logrus.Entry, cfgFilePath string, cfg *aqua.Config, rCache *registry.Cache, cacheKeys map[string]*registry.CacheKey, indexes map[string]*registry.Index, registries map[string]*registry.Config, exeName string, pkg *aqua.Package, checksums *checksum.Checksums, cfgTrace *ConfigTrace) (*FindResult, error) { //nolint:cyclop,funlen
 	if pkg.Registry == "" || pkg.Name == "" {
 		logE.Debug("ignore a package because the package name or package registry name is empty")
 		return nil, nil //nolint:nilnil
@@ -171,33 +144,41 @@ func (c *Controller) findExecFileFromPkg(ctx context.Context, logE *logrus.Entry
 		"registry_name": pkg.Registry,
 		"package_name":  pkg.Name,
 	})
-	pkgInfo, err := c.findPkgInfo(ctx, logE, cfgFilePath, cfg, rCache, rgPaths, registries, pkg, checksums)
+	pkgInfo, skip, err := c.findPkgInfo(ctx, logE, cfgFilePath, cfg, rCache, cacheKeys, indexes, registries, exeName, pkg, checksums)
 	if err != nil {
 		return nil, err
 	}
+	if skip {
+		return nil, nil //nolint:nilnil
+	}
 
 	if pkgInfo == nil {
 		logE.Warn("package isn't found")
+		cfgTrace.add(pkg).skip("the package isn't found in the registry")
 		return nil, nil //nolint:nilnil
 	}
 
 	if !pkgInfo.MaybeHasCommand(exeName) && !pkg.HasCommandAlias(exeName) {
 		return nil, nil //nolint:nilnil
 	}
+	candidate := cfgTrace.add(pkg)
 
 	pkgInfo, err = pkgInfo.Override(logE, pkg.Version, c.runtime)
 	if err != nil {
 		logerr.WithError(logE, err).Warn("version constraint is invalid")
+		candidate.skip("version constraint is invalid: " + err.Error())
 		return nil, nil //nolint:nilnil
 	}
 
 	supported, err := pkgInfo.CheckSupported(c.runtime, c.runtime.GOOS+"/"+c.runtime.GOARCH)
 	if err != nil {
 		logerr.WithError(logE, err).Error("check if the package is supported")
+		candidate.skip("failed to check if the package is supported: " + err.Error())
 		return nil, nil //nolint:nilnil
 	}
 	if !supported {
 		logE.Debug("the package isn't supported on this environment")
+		candidate.skip("the package isn't supported on this environment (" + c.runtime.Env() + ")")
 		return nil, nil //nolint:nilnil
 	}
 
@@ -207,68 +188,90 @@ func (c *Controller) findExecFileFromPkg(ctx context.Context, logE *logrus.Entry
 			return nil, err
 		}
 		if findResult != nil {
+			candidate.selected(file.Name, exeName)
 			return findResult, nil
 		}
 	}
+	candidate.skip("the command isn't found in files of the package")
 	return nil, nil //nolint:nilnil
 }
 
-func (c *Controller) findPkgInfo(ctx context.Context, logE *logrus.Entry, cfgFilePath string, cfg *aqua.Config, rCache *registry.Cache, rgPaths map[string]string, registries map[string]*registry.Config, pkg *aqua.Package, checksums *checksum.Checksums) (*registry.PackageInfo, error) { //nolint:cyclop,funlen
+// findPkgInfo gets the package from the registry cache, the registry index, or the registry.
+// If the registry index shows the package doesn't have the command, the package is skipped without decoding it.
+// If exeName is empty, the package isn't skipped.
+func (c *Controller) findPkgInfo(ctx context.Context, logE *logrus.Entry, cfgFilePath string, cfg *aqua.Config, rCache *registry.Cache, cacheKeys map[string]*registry.CacheKey, indexes map[string]*registry.Index, registries map[string]*registry.Config, exeName string, pkg *aqua.Package, checksums *checksum.Checksums) (*registry.PackageInfo, bool, error) { //nolint:cyclop,funlen
 	rg, ok := cfg.Registries[pkg.Registry]
 	if !ok {
 		logE.Debug("ignore a package because the registry isn't found")
-		return nil, nil //nolint:nilnil
+		return nil, false, nil
 	}
 	if err := rg.Validate(); err != nil {
-		return nil, fmt.Errorf("validate the registry: %w", err)
+		return nil, false, fmt.Errorf("validate the registry: %w", err)
 	}
-	if rg.Type != aqua.RegistryTypeGitHubContent {
-		logE.Debug("getting a package from a registry")
-		rc, ok := registries[pkg.Registry]
-		if !ok {
-			a, err := c.registryInstaller.InstallRegistry(ctx, logE, rg, cfgFilePath, checksums)
-			if err != nil {
-				return nil, fmt.Errorf("install a registry: %w", err)
-			}
-			rc = a
-			registries[pkg.Registry] = rc
+	cacheKey, ok := cacheKeys[pkg.Registry]
+	if !ok {
+		k, err := c.registryCacheKey(rg, cfgFilePath, checksums)
+		if err != nil {
+			logerr.WithError(logE, err).Debug("get a registry cache key")
 		}
-		return rc.Package(logE, pkg.Name), nil
+		cacheKey = k
+		cacheKeys[pkg.Registry] = cacheKey
 	}
-	rgPath, ok := rgPaths[pkg.Registry]
-	if !ok {
-		p, err := rg.FilePath(c.rootDir, cfgFilePath)
+	if cacheKey != nil {
+		logE.Debug("getting a package from a registry cache")
+		pkgInfo, err := rCache.Get(cacheKey, pkg.Name)
 		if err != nil {
-			return nil, fmt.Errorf("get a registry file path: %w", err)
+			logerr.WithError(logE, err).Debug("read a registry cache file")
+		}
+		if pkgInfo != nil {
+			return pkgInfo, false, nil
 		}
-		rgPath = p
-		rgPaths[pkg.Registry] = rgPath
 	}
-	logE.WithFields(logrus.Fields{
-		"registry_file_path": rgPath,
-		"package_name":       pkg.Name,
-	}).Debug("getting a package from a registry cache")
-	if pkgInfo := rCache.Get(rgPath, pkg.Name); pkgInfo != nil {
-		return pkgInfo, nil
+	if idx := c.registryIndex(logE, rg, cfgFilePath, indexes); idx != nil {
+		if hasCommand, found := idx.MaybeHasCommand(pkg.Name, exeName); found {
+			if exeName != "" && !hasCommand && !pkg.HasCommandAlias(exeName) {
+				logE.Debug("skip a package because the registry index shows the package doesn't have the command")
+				return nil, true, nil
+			}
+			logE.Debug("getting a package from a registry index")
+			pkgInfo, err := idx.Package(c.fs, pkg.Name)
+			if err != nil {
+				logerr.WithError(logE, err).Debug("read a package from a registry index")
+			}
+			if pkgInfo != nil {
+				if cacheKey != nil {
+					rCache.Add(cacheKey, pkgInfo)
+				}
+				return pkgInfo, false, nil
+			}
+		}
 	}
 	logE.Debug("a package isn't found in a registry cache. Getting it from a registry")
 	rc, ok := registries[pkg.Registry]
-	if !ok {
-		a, err := c.registryInstaller.InstallRegistry(ctx, logE, rg, cfgFilePath, checksums)
+	// Split registries include only installed package files, so package files are installed per package.
+	if !ok || rc.IsSplit() {
+		a, err := c.registryInstaller.InstallRegistry(ctx, logE, rg, cfgFilePath, checksums, pkg.Name)
 		if err != nil {
-			return nil, fmt.Errorf("install a registry: %w", err)
+			return nil, false, fmt.Errorf("install a registry: %w", err)
 		}
 		rc = a
 		registries[pkg.Registry] = rc
 	}
 	pkgInfo := rc.Package(logE, pkg.Name)
+	if rc.Extends != "" {
+		return c.extendPackage(ctx, logE, cfgFilePath, cfg, rCache, cacheKeys, indexes, registries, exeName, pkg, checksums, rc.Extends, pkgInfo)
+	}
 	if pkgInfo == nil {
-		logE.Warn("package isn't found")
-		return nil, nil //nolint:nilnil
+		return nil, false, nil
+	}
+	// Packages of split registries aren't cached because the cache key doesn't cover package files.
+	// Reading a package file is cheap enough.
+	if cacheKey == nil || rc.IsSplit() {
+		return pkgInfo, false, nil
 	}
 	logE.Debug("adding a package to the registry cache")
-	rCache.Add(rgPath, pkgInfo)
-	return pkgInfo, nil
+	rCache.Add(cacheKey, pkgInfo)
+	return pkgInfo, false, nil
 }
 
 func (c *Controller) findExecFileFromFile(logE *logrus.Entry, exeName string, pkg *aqua.Package, pkgInfo *registry.PackageInfo, file *registry.File) (*FindResult, error) {
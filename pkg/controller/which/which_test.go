@@ -108,6 +108,102 @@ packages:
 				ConfigFilePath: "/home/foo/workspace/aqua.yaml",
 			},
 		},
+		{
+			name: "extends",
+			rt: &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+			},
+			param: &config.Param{
+				PWD:            "/home/foo/workspace",
+				ConfigFilePath: "aqua.yaml",
+				RootDir:        "/home/foo/.local/share/aquaproj-aqua",
+				MaxParallelism: 5,
+			},
+			exeName: "installer",
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+- type: local
+  name: overlay
+  path: overlay.yaml
+packages:
+- name: aquaproj/aqua-installer@v1.0.0
+  registry: overlay
+`,
+				"/home/foo/workspace/registry.yaml": `packages:
+- type: github_content
+  repo_owner: aquaproj
+  repo_name: aqua-installer
+  path: aqua-installer
+`,
+				"/home/foo/workspace/overlay.yaml": `extends: standard
+packages:
+- name: aquaproj/aqua-installer
+  files:
+  - name: installer
+    src: aqua-installer
+`,
+			},
+			exp: &which.FindResult{
+				Package: &config.Package{
+					Package: &aqua.Package{
+						Name:     "aquaproj/aqua-installer",
+						Registry: "overlay",
+						Version:  "v1.0.0",
+						FilePath: "/home/foo/workspace/aqua.yaml",
+					},
+					PackageInfo: &cfgRegistry.PackageInfo{
+						Name:      "aquaproj/aqua-installer",
+						Type:      "github_content",
+						RepoOwner: "aquaproj",
+						RepoName:  "aqua-installer",
+						Path:      "aqua-installer",
+						Files: []*cfgRegistry.File{
+							{
+								Name: "installer",
+								Src:  "aqua-installer",
+							},
+						},
+					},
+					Registry: &aqua.Registry{
+						Name: "overlay",
+						Type: "local",
+						Path: "/home/foo/workspace/overlay.yaml",
+					},
+				},
+				File: &cfgRegistry.File{
+					Name: "installer",
+					Src:  "aqua-installer",
+				},
+				Config: &aqua.Config{
+					Packages: []*aqua.Package{
+						{
+							Name:     "aquaproj/aqua-installer",
+							Registry: "overlay",
+							Version:  "v1.0.0",
+							FilePath: "/home/foo/workspace/aqua.yaml",
+						},
+					},
+					Registries: aqua.Registries{
+						"standard": {
+							Name: "standard",
+							Type: "local",
+							Path: "/home/foo/workspace/registry.yaml",
+						},
+						"overlay": {
+							Name: "overlay",
+							Type: "local",
+							Path: "/home/foo/workspace/overlay.yaml",
+						},
+					},
+				},
+				ExePath:        "/home/foo/.local/share/aquaproj-aqua/pkgs/github_content/github.com/aquaproj/aqua-installer/v1.0.0/aqua-installer/aqua-installer",
+				ConfigFilePath: "/home/foo/workspace/aqua.yaml",
+			},
+		},
 		{
 			name: "outside aqua",
 			rt: &runtime.Runtime{
@@ -268,3 +364,180 @@ packages:
 		})
 	}
 }
+
+func Test_controller_Why(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		name    string
+		files   map[string]string
+		env     map[string]string
+		param   *config.Param
+		exeName string
+		rt      *runtime.Runtime
+		isErr   bool
+		exp     *which.Trace
+	}{
+		{
+			name: "skip an unsupported package",
+			rt: &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+			},
+			param: &config.Param{
+				PWD:                   "/home/foo/workspace",
+				RootDir:               "/home/foo/.local/share/aquaproj-aqua",
+				MaxParallelism:        5,
+				GlobalConfigFilePaths: []string{"/etc/aqua/aqua.yaml"},
+			},
+			exeName: "installer",
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: suzuki-shunsuke/ci-info@v1.0.0
+- name: aquaproj/aqua-installer@v1.0.0
+  tags: [ci]
+  command_aliases:
+  - command: aqua-installer
+    alias: installer
+`,
+				"/home/foo/workspace/registry.yaml": `packages:
+- type: github_release
+  repo_owner: suzuki-shunsuke
+  repo_name: ci-info
+  asset: "ci-info_{{.Arch}}-{{.OS}}.tar.gz"
+- type: github_content
+  repo_owner: aquaproj
+  repo_name: aqua-installer
+  path: aqua-installer
+  supported_envs:
+  - darwin
+`,
+				"/home/foo/aqua.yaml": `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: aquaproj/aqua-installer@v2.0.0
+  command_aliases:
+  - command: aqua-installer
+    alias: installer
+`,
+				"/home/foo/registry.yaml": `packages:
+- type: github_content
+  repo_owner: aquaproj
+  repo_name: aqua-installer
+  path: aqua-installer
+`,
+			},
+			exp: &which.Trace{
+				ExeName: "installer",
+				Configs: []*which.ConfigTrace{
+					{
+						ConfigFilePath: "/home/foo/workspace/aqua.yaml",
+						Candidates: []*which.Candidate{
+							{
+								PackageName:    "aquaproj/aqua-installer",
+								PackageVersion: "v1.0.0",
+								RegistryName:   "standard",
+								Tags:           []string{"ci"},
+								SkipReason:     "the package isn't supported on this environment (linux/amd64)",
+							},
+						},
+					},
+					{
+						ConfigFilePath: "/home/foo/aqua.yaml",
+						Candidates: []*which.Candidate{
+							{
+								PackageName:    "aquaproj/aqua-installer",
+								PackageVersion: "v2.0.0",
+								RegistryName:   "standard",
+								Command:        "aqua-installer",
+								Selected:       true,
+							},
+						},
+					},
+				},
+				Result: &which.Output{
+					ExeName:        "installer",
+					ExePath:        "/home/foo/.local/share/aquaproj-aqua/pkgs/github_content/github.com/aquaproj/aqua-installer/v2.0.0/aqua-installer/aqua-installer",
+					PackageName:    "aquaproj/aqua-installer",
+					PackageVersion: "v2.0.0",
+					RegistryName:   "standard",
+					ConfigFilePath: "/home/foo/aqua.yaml",
+				},
+			},
+		},
+		{
+			name: "not found",
+			rt: &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+			},
+			param: &config.Param{
+				PWD:                   "/home/foo/workspace",
+				RootDir:               "/home/foo/.local/share/aquaproj-aqua",
+				MaxParallelism:        5,
+				GlobalConfigFilePaths: []string{"/etc/aqua/aqua.yaml"},
+			},
+			exeName: "gh",
+			files: map[string]string{
+				"/home/foo/workspace/aqua.yaml": `registries:
+- type: local
+  name: standard
+  path: registry.yaml
+packages:
+- name: aquaproj/aqua-installer@v1.0.0
+`,
+				"/home/foo/workspace/registry.yaml": `packages:
+- type: github_content
+  repo_owner: aquaproj
+  repo_name: aqua-installer
+  path: aqua-installer
+`,
+			},
+			exp: &which.Trace{
+				ExeName: "gh",
+				Configs: []*which.ConfigTrace{
+					{
+						ConfigFilePath: "/home/foo/workspace/aqua.yaml",
+					},
+					{
+						ConfigFilePath: "/etc/aqua/aqua.yaml",
+						Global:         true,
+						SkipReason:     "the file isn't found",
+					},
+				},
+			},
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+			fs, err := testutil.NewFs(d.files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			linker := installpackage.NewMockLinker(fs)
+			downloader := download.NewGitHubContentFileDownloader(nil, download.NewHTTPDownloader(logE, http.DefaultClient))
+			ctrl := which.New(d.param, finder.NewConfigFinder(fs), reader.New(fs, d.param), registry.New(d.param, downloader, fs, d.rt, &cosign.MockVerifier{}, &slsa.MockVerifier{}), d.rt, osenv.NewMock(d.env), fs, linker)
+			trace, err := ctrl.Why(ctx, logE, d.param, d.exeName)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(d.exp, trace); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -13,9 +13,11 @@ import (
 	finder "github.com/aquaproj/aqua/v2/pkg/config-finder"
 	reader "github.com/aquaproj/aqua/v2/pkg/config-reader"
 	"github.com/aquaproj/aqua/v2/pkg/controller/allowpolicy"
+	"github.com/aquaproj/aqua/v2/pkg/controller/cache"
 	"github.com/aquaproj/aqua/v2/pkg/controller/cp"
 	"github.com/aquaproj/aqua/v2/pkg/controller/denypolicy"
 	cexec "github.com/aquaproj/aqua/v2/pkg/controller/exec"
+	"github.com/aquaproj/aqua/v2/pkg/controller/export"
 	"github.com/aquaproj/aqua/v2/pkg/controller/generate"
 	genrgst "github.com/aquaproj/aqua/v2/pkg/controller/generate-registry"
 	"github.com/aquaproj/aqua/v2/pkg/controller/generate/output"
@@ -23,8 +25,13 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/controller/initcmd"
 	"github.com/aquaproj/aqua/v2/pkg/controller/initpolicy"
 	"github.com/aquaproj/aqua/v2/pkg/controller/install"
+	"github.com/aquaproj/aqua/v2/pkg/controller/lintregistry"
 	"github.com/aquaproj/aqua/v2/pkg/controller/list"
+	"github.com/aquaproj/aqua/v2/pkg/controller/outdated"
 	"github.com/aquaproj/aqua/v2/pkg/controller/remove"
+	"github.com/aquaproj/aqua/v2/pkg/controller/run"
+	"github.com/aquaproj/aqua/v2/pkg/controller/search"
+	"github.com/aquaproj/aqua/v2/pkg/controller/testregistry"
 	"github.com/aquaproj/aqua/v2/pkg/controller/update"
 	"github.com/aquaproj/aqua/v2/pkg/controller/updateaqua"
 	"github.com/aquaproj/aqua/v2/pkg/controller/updatechecksum"
@@ -37,6 +44,7 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/fuzzyfinder"
 	"github.com/aquaproj/aqua/v2/pkg/ghattestation"
 	"github.com/aquaproj/aqua/v2/pkg/github"
+	"github.com/aquaproj/aqua/v2/pkg/gitlab"
 	registry "github.com/aquaproj/aqua/v2/pkg/install-registry"
 	"github.com/aquaproj/aqua/v2/pkg/installpackage"
 	"github.com/aquaproj/aqua/v2/pkg/link"
@@ -108,6 +116,112 @@ func InitializeListCommandController(ctx context.Context, logE *logrus.Entry, pa
 	return &list.Controller{}
 }
 
+func InitializeExportCommandController(ctx context.Context, logE *logrus.Entry, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) *export.Controller {
+	wire.Build(
+		export.New,
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(export.ConfigFinder), new(*finder.ConfigFinder)),
+		),
+		wire.NewSet(
+			github.New,
+			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
+		),
+		wire.NewSet(
+			registry.New,
+			wire.Bind(new(export.RegistryInstaller), new(*registry.Installer)),
+		),
+		wire.NewSet(
+			download.NewGitHubContentFileDownloader,
+			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
+		),
+		wire.NewSet(
+			reader.New,
+			wire.Bind(new(export.ConfigReader), new(*reader.ConfigReader)),
+		),
+		afero.NewOsFs,
+		download.NewHTTPDownloader,
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			osexec.New,
+			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
+			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
+		),
+		wire.NewSet(
+			download.NewDownloader,
+			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+		),
+		wire.NewSet(
+			slsa.New,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+			wire.Bind(new(registry.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewExecutor,
+			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
+		),
+	)
+	return &export.Controller{}
+}
+
+func InitializeSearchCommandController(ctx context.Context, logE *logrus.Entry, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) *search.Controller {
+	wire.Build(
+		search.New,
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(search.ConfigFinder), new(*finder.ConfigFinder)),
+		),
+		wire.NewSet(
+			github.New,
+			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
+		),
+		wire.NewSet(
+			registry.New,
+			wire.Bind(new(search.RegistryInstaller), new(*registry.Installer)),
+		),
+		wire.NewSet(
+			download.NewGitHubContentFileDownloader,
+			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
+		),
+		wire.NewSet(
+			reader.New,
+			wire.Bind(new(search.ConfigReader), new(*reader.ConfigReader)),
+		),
+		afero.NewOsFs,
+		download.NewHTTPDownloader,
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			osexec.New,
+			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
+			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
+		),
+		wire.NewSet(
+			download.NewDownloader,
+			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+		),
+		wire.NewSet(
+			slsa.New,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+			wire.Bind(new(registry.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewExecutor,
+			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
+		),
+	)
+	return &search.Controller{}
+}
+
 func InitializeGenerateRegistryCommandController(ctx context.Context, logE *logrus.Entry, param *config.Param, httpClient *http.Client, stdout io.Writer) *genrgst.Controller {
 	wire.Build(
 		genrgst.NewController,
@@ -124,10 +238,103 @@ func InitializeGenerateRegistryCommandController(ctx context.Context, logE *logr
 			cargo.NewClient,
 			wire.Bind(new(genrgst.CargoClient), new(*cargo.Client)),
 		),
+		wire.NewSet(
+			gitlab.NewClient,
+			wire.Bind(new(genrgst.GitLabClient), new(*gitlab.Client)),
+		),
 	)
 	return &genrgst.Controller{}
 }
 
+func InitializePackageInstaller(ctx context.Context, logE *logrus.Entry, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*installpackage.Installer, error) {
+	wire.Build(
+		installpackage.New,
+		wire.NewSet(
+			download.NewDownloader,
+			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+		),
+		wire.NewSet(
+			github.New,
+			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
+		),
+		wire.NewSet(
+			osexec.New,
+			wire.Bind(new(installpackage.Executor), new(*osexec.Executor)),
+			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
+			wire.Bind(new(unarchive.Executor), new(*osexec.Executor)),
+			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
+			wire.Bind(new(minisign.CommandExecutor), new(*osexec.Executor)),
+			wire.Bind(new(ghattestation.CommandExecutor), new(*osexec.Executor)),
+		),
+		wire.NewSet(
+			download.NewChecksumDownloader,
+			wire.Bind(new(download.ChecksumDownloader), new(*download.ChecksumDownloaderImpl)),
+		),
+		wire.NewSet(
+			afero.NewOsFs,
+			wire.Bind(new(installpackage.Cleaner), new(afero.Fs)),
+		),
+		wire.NewSet(
+			link.New,
+			wire.Bind(new(installpackage.Linker), new(*link.Linker)),
+		),
+		download.NewHTTPDownloader,
+		wire.NewSet(
+			checksum.NewCalculator,
+			wire.Bind(new(installpackage.ChecksumCalculator), new(*checksum.Calculator)),
+		),
+		wire.NewSet(
+			unarchive.New,
+			wire.Bind(new(installpackage.Unarchiver), new(*unarchive.Unarchiver)),
+		),
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			slsa.New,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewExecutor,
+			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
+		),
+		wire.NewSet(
+			minisign.New,
+			wire.Bind(new(installpackage.MinisignVerifier), new(*minisign.Verifier)),
+		),
+		wire.NewSet(
+			ghattestation.New,
+			wire.Bind(new(installpackage.GitHubArtifactAttestationsVerifier), new(*ghattestation.Verifier)),
+		),
+		wire.NewSet(
+			ghattestation.NewExecutor,
+			wire.Bind(new(ghattestation.Executor), new(*ghattestation.ExecutorImpl)),
+		),
+		wire.NewSet(
+			minisign.NewExecutor,
+			wire.Bind(new(minisign.Executor), new(*minisign.ExecutorImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewGoInstallInstallerImpl,
+			wire.Bind(new(installpackage.GoInstallInstaller), new(*installpackage.GoInstallInstallerImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewGoBuildInstallerImpl,
+			wire.Bind(new(installpackage.GoBuildInstaller), new(*installpackage.GoBuildInstallerImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewCargoPackageInstallerImpl,
+			wire.Bind(new(installpackage.CargoPackageInstaller), new(*installpackage.CargoPackageInstallerImpl)),
+		),
+		wire.NewSet(
+			vacuum.New,
+			wire.Bind(new(installpackage.Vacuum), new(*vacuum.Client)),
+		),
+	)
+	return &installpackage.Installer{}, nil
+}
+
 func InitializeInitCommandController(ctx context.Context, logE *logrus.Entry, param *config.Param) *initcmd.Controller {
 	wire.Build(
 		initcmd.New,
@@ -176,6 +383,7 @@ func InitializeGenerateCommandController(ctx context.Context, logE *logrus.Entry
 			wire.Bind(new(generate.ConfigReader), new(*reader.ConfigReader)),
 		),
 		afero.NewOsFs,
+		osenv.New,
 		wire.NewSet(
 			fuzzyfinder.New,
 			wire.Bind(new(generate.FuzzyFinder), new(*fuzzyfinder.Finder)),
@@ -189,6 +397,7 @@ func InitializeGenerateCommandController(ctx context.Context, logE *logrus.Entry
 		),
 		wire.NewSet(
 			osexec.New,
+			wire.Bind(new(generate.CommandExecutor), new(*osexec.Executor)),
 			wire.Bind(new(installpackage.Executor), new(*osexec.Executor)),
 			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
 			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
@@ -553,6 +762,185 @@ func InitializeExecCommandController(ctx context.Context, logE *logrus.Entry, pa
 	return &cexec.Controller{}, nil
 }
 
+func InitializeRunCommandController(ctx context.Context, logE *logrus.Entry, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*run.Controller, error) {
+	wire.Build(
+		run.New,
+		wire.NewSet(
+			cexec.New,
+			wire.Bind(new(run.PackageExecutor), new(*cexec.Controller)),
+		),
+		wire.NewSet(
+			generate.New,
+			wire.Bind(new(run.PackageResolver), new(*generate.Controller)),
+		),
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(which.ConfigFinder), new(*finder.ConfigFinder)),
+			wire.Bind(new(generate.ConfigFinder), new(*finder.ConfigFinder)),
+			wire.Bind(new(run.ConfigFinder), new(*finder.ConfigFinder)),
+		),
+		wire.NewSet(
+			download.NewDownloader,
+			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+		),
+		wire.NewSet(
+			installpackage.New,
+			wire.Bind(new(cexec.Installer), new(*installpackage.Installer)),
+		),
+		wire.NewSet(
+			github.New,
+			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
+			wire.Bind(new(generate.RepositoriesService), new(*github.RepositoriesService)),
+			wire.Bind(new(run.RepositoriesService), new(*github.RepositoriesService)),
+			wire.Bind(new(versiongetter.GitHubTagClient), new(*github.RepositoriesService)),
+			wire.Bind(new(versiongetter.GitHubReleaseClient), new(*github.RepositoriesService)),
+		),
+		wire.NewSet(
+			registry.New,
+			wire.Bind(new(which.RegistryInstaller), new(*registry.Installer)),
+			wire.Bind(new(generate.RegistryInstaller), new(*registry.Installer)),
+		),
+		wire.NewSet(
+			download.NewGitHubContentFileDownloader,
+			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
+		),
+		wire.NewSet(
+			reader.New,
+			wire.Bind(new(which.ConfigReader), new(*reader.ConfigReader)),
+			wire.Bind(new(generate.ConfigReader), new(*reader.ConfigReader)),
+			wire.Bind(new(run.ConfigReader), new(*reader.ConfigReader)),
+		),
+		wire.NewSet(
+			which.New,
+			wire.Bind(new(cexec.WhichController), new(*which.Controller)),
+		),
+		wire.NewSet(
+			osexec.New,
+			wire.Bind(new(generate.CommandExecutor), new(*osexec.Executor)),
+			wire.Bind(new(installpackage.Executor), new(*osexec.Executor)),
+			wire.Bind(new(cexec.Executor), new(*osexec.Executor)),
+			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
+			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
+			wire.Bind(new(minisign.CommandExecutor), new(*osexec.Executor)),
+			wire.Bind(new(ghattestation.CommandExecutor), new(*osexec.Executor)),
+			wire.Bind(new(unarchive.Executor), new(*osexec.Executor)),
+		),
+		wire.NewSet(
+			download.NewChecksumDownloader,
+			wire.Bind(new(download.ChecksumDownloader), new(*download.ChecksumDownloaderImpl)),
+		),
+		osenv.New,
+		wire.NewSet(
+			afero.NewOsFs,
+			wire.Bind(new(installpackage.Cleaner), new(afero.Fs)),
+		),
+		wire.NewSet(
+			link.New,
+			wire.Bind(new(installpackage.Linker), new(*link.Linker)),
+			wire.Bind(new(which.Linker), new(*link.Linker)),
+		),
+		download.NewHTTPDownloader,
+		wire.NewSet(
+			checksum.NewCalculator,
+			wire.Bind(new(installpackage.ChecksumCalculator), new(*checksum.Calculator)),
+		),
+		wire.NewSet(
+			unarchive.New,
+			wire.Bind(new(installpackage.Unarchiver), new(*unarchive.Unarchiver)),
+		),
+		wire.NewSet(
+			policy.NewConfigReader,
+			wire.Bind(new(policy.ConfigReader), new(*policy.ConfigReaderImpl)),
+		),
+		wire.NewSet(
+			policy.NewConfigFinder,
+			wire.Bind(new(policy.ConfigFinder), new(*policy.ConfigFinderImpl)),
+		),
+		wire.NewSet(
+			policy.NewValidator,
+			wire.Bind(new(policy.Validator), new(*policy.ValidatorImpl)),
+		),
+		wire.NewSet(
+			policy.NewReader,
+			wire.Bind(new(cexec.PolicyReader), new(*policy.Reader)),
+		),
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			slsa.New,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+			wire.Bind(new(registry.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewExecutor,
+			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
+		),
+		wire.NewSet(
+			minisign.New,
+			wire.Bind(new(installpackage.MinisignVerifier), new(*minisign.Verifier)),
+		),
+		wire.NewSet(
+			minisign.NewExecutor,
+			wire.Bind(new(minisign.Executor), new(*minisign.ExecutorImpl)),
+		),
+		wire.NewSet(
+			ghattestation.New,
+			wire.Bind(new(installpackage.GitHubArtifactAttestationsVerifier), new(*ghattestation.Verifier)),
+		),
+		wire.NewSet(
+			ghattestation.NewExecutor,
+			wire.Bind(new(ghattestation.Executor), new(*ghattestation.ExecutorImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewGoInstallInstallerImpl,
+			wire.Bind(new(installpackage.GoInstallInstaller), new(*installpackage.GoInstallInstallerImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewGoBuildInstallerImpl,
+			wire.Bind(new(installpackage.GoBuildInstaller), new(*installpackage.GoBuildInstallerImpl)),
+		),
+		wire.NewSet(
+			installpackage.NewCargoPackageInstallerImpl,
+			wire.Bind(new(installpackage.CargoPackageInstaller), new(*installpackage.CargoPackageInstallerImpl)),
+		),
+		wire.NewSet(
+			vacuum.New,
+			wire.Bind(new(installpackage.Vacuum), new(*vacuum.Client)),
+			wire.Bind(new(cexec.Vacuum), new(*vacuum.Client)),
+		),
+		wire.NewSet(
+			fuzzyfinder.New,
+			wire.Bind(new(generate.FuzzyFinder), new(*fuzzyfinder.Finder)),
+			wire.Bind(new(versiongetter.FuzzyFinder), new(*fuzzyfinder.Finder)),
+		),
+		wire.NewSet(
+			cargo.NewClient,
+			wire.Bind(new(versiongetter.CargoClient), new(*cargo.Client)),
+		),
+		wire.NewSet(
+			versiongetter.NewFuzzy,
+			wire.Bind(new(generate.FuzzyGetter), new(*versiongetter.FuzzyGetter)),
+		),
+		wire.NewSet(
+			versiongetter.NewGeneralVersionGetter,
+			wire.Bind(new(versiongetter.VersionGetter), new(*versiongetter.GeneralVersionGetter)),
+		),
+		versiongetter.NewCargo,
+		versiongetter.NewGitHubRelease,
+		versiongetter.NewGitHubTag,
+		versiongetter.NewGoGetter,
+		wire.NewSet(
+			goproxy.New,
+			wire.Bind(new(versiongetter.GoProxyClient), new(*goproxy.Client)),
+		),
+	)
+	return &run.Controller{}, nil
+}
+
 func InitializeUpdateAquaCommandController(ctx context.Context, logE *logrus.Entry, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*updateaqua.Controller, error) {
 	wire.Build(
 		updateaqua.New,
@@ -897,6 +1285,7 @@ func InitializeUpdateCommandController(ctx context.Context, logE *logrus.Entry,
 			osexec.New,
 			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
 			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
+			wire.Bind(new(update.CommandExecutor), new(*osexec.Executor)),
 		),
 		wire.NewSet(
 			slsa.New,
@@ -946,6 +1335,78 @@ func InitializeUpdateCommandController(ctx context.Context, logE *logrus.Entry,
 	return &update.Controller{}
 }
 
+func InitializeOutdatedCommandController(ctx context.Context, logE *logrus.Entry, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) *outdated.Controller {
+	wire.Build(
+		outdated.New,
+		wire.NewSet(
+			finder.NewConfigFinder,
+			wire.Bind(new(outdated.ConfigFinder), new(*finder.ConfigFinder)),
+		),
+		wire.NewSet(
+			reader.New,
+			wire.Bind(new(outdated.ConfigReader), new(*reader.ConfigReader)),
+		),
+		wire.NewSet(
+			registry.New,
+			wire.Bind(new(outdated.RegistryInstaller), new(*registry.Installer)),
+		),
+		wire.NewSet(
+			github.New,
+			wire.Bind(new(download.GitHub), new(*github.RepositoriesService)),
+			wire.Bind(new(outdated.RepositoriesService), new(*github.RepositoriesService)),
+			wire.Bind(new(download.GitHubContentAPI), new(*github.RepositoriesService)),
+			wire.Bind(new(versiongetter.GitHubTagClient), new(*github.RepositoriesService)),
+			wire.Bind(new(versiongetter.GitHubReleaseClient), new(*github.RepositoriesService)),
+		),
+		wire.NewSet(
+			download.NewGitHubContentFileDownloader,
+			wire.Bind(new(registry.GitHubContentFileDownloader), new(*download.GitHubContentFileDownloader)),
+		),
+		download.NewHTTPDownloader,
+		wire.NewSet(
+			download.NewDownloader,
+			wire.Bind(new(download.ClientAPI), new(*download.Downloader)),
+		),
+		afero.NewOsFs,
+		wire.NewSet(
+			cosign.NewVerifier,
+			wire.Bind(new(installpackage.CosignVerifier), new(*cosign.Verifier)),
+			wire.Bind(new(registry.CosignVerifier), new(*cosign.Verifier)),
+		),
+		wire.NewSet(
+			osexec.New,
+			wire.Bind(new(cosign.Executor), new(*osexec.Executor)),
+			wire.Bind(new(slsa.CommandExecutor), new(*osexec.Executor)),
+		),
+		wire.NewSet(
+			slsa.New,
+			wire.Bind(new(installpackage.SLSAVerifier), new(*slsa.Verifier)),
+			wire.Bind(new(registry.SLSAVerifier), new(*slsa.Verifier)),
+		),
+		wire.NewSet(
+			slsa.NewExecutor,
+			wire.Bind(new(slsa.Executor), new(*slsa.ExecutorImpl)),
+		),
+		wire.NewSet(
+			versiongetter.NewGeneralVersionGetter,
+			wire.Bind(new(outdated.VersionGetter), new(*versiongetter.GeneralVersionGetter)),
+		),
+		versiongetter.NewCargo,
+		versiongetter.NewGitHubRelease,
+		versiongetter.NewGitHubTag,
+		versiongetter.NewGoGetter,
+		wire.NewSet(
+			cargo.NewClient,
+			wire.Bind(new(versiongetter.CargoClient), new(*cargo.Client)),
+		),
+		wire.NewSet(
+			goproxy.New,
+			wire.Bind(new(versiongetter.GoProxyClient), new(*goproxy.Client)),
+		),
+	)
+	return &outdated.Controller{}
+}
+
 func InitializeAllowPolicyCommandController(ctx context.Context, param *config.Param) *allowpolicy.Controller {
 	wire.Build(
 		allowpolicy.New,
@@ -1132,3 +1593,27 @@ func InitializeVacuumInitCommandController(ctx context.Context, logE *logrus.Ent
 	)
 	return &initialize.Controller{}
 }
+
+func InitializeLintRegistryCommandController(ctx context.Context, param *config.Param) *lintregistry.Controller {
+	wire.Build(
+		lintregistry.New,
+		afero.NewOsFs,
+	)
+	return &lintregistry.Controller{}
+}
+
+func InitializeTestRegistryCommandController(ctx context.Context, param *config.Param) *testregistry.Controller {
+	wire.Build(
+		testregistry.New,
+		afero.NewOsFs,
+	)
+	return &testregistry.Controller{}
+}
+
+func InitializeCacheCommandController(ctx context.Context, param *config.Param) *cache.Controller {
+	wire.Build(
+		cache.New,
+		afero.NewOsFs,
+	)
+	return &cache.Controller{}
+}
@@ -17,9 +17,11 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/config-finder"
 	"github.com/aquaproj/aqua/v2/pkg/config-reader"
 	"github.com/aquaproj/aqua/v2/pkg/controller/allowpolicy"
+	"github.com/aquaproj/aqua/v2/pkg/controller/cache"
 	"github.com/aquaproj/aqua/v2/pkg/controller/cp"
 	"github.com/aquaproj/aqua/v2/pkg/controller/denypolicy"
 	"github.com/aquaproj/aqua/v2/pkg/controller/exec"
+	"github.com/aquaproj/aqua/v2/pkg/controller/export"
 	"github.com/aquaproj/aqua/v2/pkg/controller/generate"
 	"github.com/aquaproj/aqua/v2/pkg/controller/generate-registry"
 	"github.com/aquaproj/aqua/v2/pkg/controller/generate/output"
@@ -27,8 +29,13 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/controller/initcmd"
 	"github.com/aquaproj/aqua/v2/pkg/controller/initpolicy"
 	"github.com/aquaproj/aqua/v2/pkg/controller/install"
+	"github.com/aquaproj/aqua/v2/pkg/controller/lintregistry"
 	"github.com/aquaproj/aqua/v2/pkg/controller/list"
+	"github.com/aquaproj/aqua/v2/pkg/controller/outdated"
 	"github.com/aquaproj/aqua/v2/pkg/controller/remove"
+	"github.com/aquaproj/aqua/v2/pkg/controller/run"
+	"github.com/aquaproj/aqua/v2/pkg/controller/search"
+	"github.com/aquaproj/aqua/v2/pkg/controller/testregistry"
 	"github.com/aquaproj/aqua/v2/pkg/controller/update"
 	"github.com/aquaproj/aqua/v2/pkg/controller/updateaqua"
 	"github.com/aquaproj/aqua/v2/pkg/controller/updatechecksum"
@@ -40,6 +47,7 @@ import (
 	"github.com/aquaproj/aqua/v2/pkg/fuzzyfinder"
 	"github.com/aquaproj/aqua/v2/pkg/ghattestation"
 	"github.com/aquaproj/aqua/v2/pkg/github"
+	"github.com/aquaproj/aqua/v2/pkg/gitlab"
 	"github.com/aquaproj/aqua/v2/pkg/install-registry"
 	"github.com/aquaproj/aqua/v2/pkg/installpackage"
 	"github.com/aquaproj/aqua/v2/pkg/link"
@@ -72,7 +80,41 @@ func InitializeListCommandController(ctx context.Context, logE *logrus.Entry, pa
 	executorImpl := slsa.NewExecutor(executor, param)
 	slsaVerifier := slsa.New(downloader, fs, executorImpl)
 	installer := registry.New(param, gitHubContentFileDownloader, fs, rt, verifier, slsaVerifier)
-	controller := list.NewController(configFinder, configReader, installer, fs)
+	controller := list.NewController(configFinder, configReader, installer, fs, rt)
+	return controller
+}
+
+func InitializeExportCommandController(ctx context.Context, logE *logrus.Entry, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) *export.Controller {
+	fs := afero.NewOsFs()
+	configFinder := finder.NewConfigFinder(fs)
+	configReader := reader.New(fs, param)
+	repositoriesService := github.New(ctx, logE)
+	httpDownloader := download.NewHTTPDownloader(logE, httpClient)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
+	executor := osexec.New()
+	downloader := download.NewDownloader(repositoriesService, httpDownloader)
+	verifier := cosign.NewVerifier(executor, fs, downloader, param)
+	executorImpl := slsa.NewExecutor(executor, param)
+	slsaVerifier := slsa.New(downloader, fs, executorImpl)
+	installer := registry.New(param, gitHubContentFileDownloader, fs, rt, verifier, slsaVerifier)
+	controller := export.New(configFinder, configReader, installer, fs, rt)
+	return controller
+}
+
+func InitializeSearchCommandController(ctx context.Context, logE *logrus.Entry, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) *search.Controller {
+	fs := afero.NewOsFs()
+	configFinder := finder.NewConfigFinder(fs)
+	configReader := reader.New(fs, param)
+	repositoriesService := github.New(ctx, logE)
+	httpDownloader := download.NewHTTPDownloader(logE, httpClient)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
+	executor := osexec.New()
+	downloader := download.NewDownloader(repositoriesService, httpDownloader)
+	verifier := cosign.NewVerifier(executor, fs, downloader, param)
+	executorImpl := slsa.NewExecutor(executor, param)
+	slsaVerifier := slsa.New(downloader, fs, executorImpl)
+	installer := registry.New(param, gitHubContentFileDownloader, fs, rt, verifier, slsaVerifier)
+	controller := search.New(configFinder, configReader, installer, fs)
 	return controller
 }
 
@@ -81,10 +123,42 @@ func InitializeGenerateRegistryCommandController(ctx context.Context, logE *logr
 	repositoriesService := github.New(ctx, logE)
 	outputter := output.New(stdout, fs)
 	client := cargo.NewClient(httpClient)
-	controller := genrgst.NewController(fs, repositoriesService, outputter, client, stdout)
+	gitlabClient := gitlab.NewClient(httpClient)
+	controller := genrgst.NewController(fs, repositoriesService, outputter, client, gitlabClient, stdout)
 	return controller
 }
 
+func InitializePackageInstaller(ctx context.Context, logE *logrus.Entry, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*installpackage.Installer, error) {
+	repositoriesService := github.New(ctx, logE)
+	httpDownloader := download.NewHTTPDownloader(logE, httpClient)
+	downloader := download.NewDownloader(repositoriesService, httpDownloader)
+	fs := afero.NewOsFs()
+	linker := link.New()
+	checksumDownloaderImpl := download.NewChecksumDownloader(repositoriesService, rt, httpDownloader)
+	calculator := checksum.NewCalculator()
+	executor := osexec.New()
+	unarchiver := unarchive.New(executor, fs)
+	verifier := cosign.NewVerifier(executor, fs, downloader, param)
+	executorImpl := slsa.NewExecutor(executor, param)
+	slsaVerifier := slsa.New(downloader, fs, executorImpl)
+	minisignExecutorImpl, err := minisign.NewExecutor(logE, executor, param)
+	if err != nil {
+		return nil, err
+	}
+	minisignVerifier := minisign.New(downloader, fs, minisignExecutorImpl)
+	ghattestationExecutorImpl, err := ghattestation.NewExecutor(executor, param)
+	if err != nil {
+		return nil, err
+	}
+	ghattestationVerifier := ghattestation.New(ghattestationExecutorImpl)
+	goInstallInstallerImpl := installpackage.NewGoInstallInstallerImpl(executor)
+	goBuildInstallerImpl := installpackage.NewGoBuildInstallerImpl(executor)
+	cargoPackageInstallerImpl := installpackage.NewCargoPackageInstallerImpl(executor, fs)
+	client := vacuum.New(fs, param)
+	installer := installpackage.New(param, downloader, rt, fs, linker, checksumDownloaderImpl, calculator, unarchiver, verifier, slsaVerifier, minisignVerifier, ghattestationVerifier, goInstallInstallerImpl, goBuildInstallerImpl, cargoPackageInstallerImpl, client)
+	return installer, nil
+}
+
 func InitializeInitCommandController(ctx context.Context, logE *logrus.Entry, param *config.Param) *initcmd.Controller {
 	repositoriesService := github.New(ctx, logE)
 	fs := afero.NewOsFs()
@@ -120,7 +194,8 @@ func InitializeGenerateCommandController(ctx context.Context, logE *logrus.Entry
 	goGetter := versiongetter.NewGoGetter(goproxyClient)
 	generalVersionGetter := versiongetter.NewGeneralVersionGetter(cargoVersionGetter, gitHubTagVersionGetter, gitHubReleaseVersionGetter, goGetter)
 	fuzzyGetter := versiongetter.NewFuzzy(fuzzyfinderFinder, generalVersionGetter)
-	controller := generate.New(configFinder, configReader, installer, repositoriesService, fs, fuzzyfinderFinder, fuzzyGetter)
+	osEnv := osenv.New()
+	controller := generate.New(configFinder, configReader, installer, repositoriesService, fs, fuzzyfinderFinder, fuzzyGetter, osEnv, executor)
 	return controller
 }
 
@@ -225,6 +300,59 @@ func InitializeExecCommandController(ctx context.Context, logE *logrus.Entry, pa
 	return execController, nil
 }
 
+func InitializeRunCommandController(ctx context.Context, logE *logrus.Entry, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*run.Controller, error) {
+	fs := afero.NewOsFs()
+	configFinder := finder.NewConfigFinder(fs)
+	configReader := reader.New(fs, param)
+	repositoriesService := github.New(ctx, logE)
+	httpDownloader := download.NewHTTPDownloader(logE, httpClient)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
+	executor := osexec.New()
+	downloader := download.NewDownloader(repositoriesService, httpDownloader)
+	verifier := cosign.NewVerifier(executor, fs, downloader, param)
+	executorImpl := slsa.NewExecutor(executor, param)
+	slsaVerifier := slsa.New(downloader, fs, executorImpl)
+	installer := registry.New(param, gitHubContentFileDownloader, fs, rt, verifier, slsaVerifier)
+	fuzzyfinderFinder := fuzzyfinder.New()
+	client := cargo.NewClient(httpClient)
+	cargoVersionGetter := versiongetter.NewCargo(client)
+	gitHubTagVersionGetter := versiongetter.NewGitHubTag(repositoriesService)
+	gitHubReleaseVersionGetter := versiongetter.NewGitHubRelease(repositoriesService)
+	goproxyClient := goproxy.New(httpClient)
+	goGetter := versiongetter.NewGoGetter(goproxyClient)
+	generalVersionGetter := versiongetter.NewGeneralVersionGetter(cargoVersionGetter, gitHubTagVersionGetter, gitHubReleaseVersionGetter, goGetter)
+	fuzzyGetter := versiongetter.NewFuzzy(fuzzyfinderFinder, generalVersionGetter)
+	osEnv := osenv.New()
+	controller := generate.New(configFinder, configReader, installer, repositoriesService, fs, fuzzyfinderFinder, fuzzyGetter, osEnv, executor)
+	linker := link.New()
+	checksumDownloaderImpl := download.NewChecksumDownloader(repositoriesService, rt, httpDownloader)
+	calculator := checksum.NewCalculator()
+	unarchiver := unarchive.New(executor, fs)
+	minisignExecutorImpl, err := minisign.NewExecutor(logE, executor, param)
+	if err != nil {
+		return nil, err
+	}
+	minisignVerifier := minisign.New(downloader, fs, minisignExecutorImpl)
+	ghattestationExecutorImpl, err := ghattestation.NewExecutor(executor, param)
+	if err != nil {
+		return nil, err
+	}
+	ghattestationVerifier := ghattestation.New(ghattestationExecutorImpl)
+	goInstallInstallerImpl := installpackage.NewGoInstallInstallerImpl(executor)
+	goBuildInstallerImpl := installpackage.NewGoBuildInstallerImpl(executor)
+	cargoPackageInstallerImpl := installpackage.NewCargoPackageInstallerImpl(executor, fs)
+	vacuumClient := vacuum.New(fs, param)
+	installpackageInstaller := installpackage.New(param, downloader, rt, fs, linker, checksumDownloaderImpl, calculator, unarchiver, verifier, slsaVerifier, minisignVerifier, ghattestationVerifier, goInstallInstallerImpl, goBuildInstallerImpl, cargoPackageInstallerImpl, vacuumClient)
+	whichController := which.New(param, configFinder, configReader, installer, rt, osEnv, fs, linker)
+	validatorImpl := policy.NewValidator(param, fs)
+	configFinderImpl := policy.NewConfigFinder(fs)
+	configReaderImpl := policy.NewConfigReader(fs)
+	policyReader := policy.NewReader(fs, validatorImpl, configFinderImpl, configReaderImpl)
+	execController := exec.New(installpackageInstaller, whichController, executor, osEnv, fs, policyReader, vacuumClient)
+	runController := run.New(configFinder, configReader, controller, execController, repositoriesService, rt, fs)
+	return runController, nil
+}
+
 func InitializeUpdateAquaCommandController(ctx context.Context, logE *logrus.Entry, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) (*updateaqua.Controller, error) {
 	fs := afero.NewOsFs()
 	repositoriesService := github.New(ctx, logE)
@@ -343,10 +471,34 @@ func InitializeUpdateCommandController(ctx context.Context, logE *logrus.Entry,
 	osEnv := osenv.New()
 	linker := link.New()
 	controller := which.New(param, configFinder, configReader, installer, rt, osEnv, fs, linker)
-	updateController := update.New(param, repositoriesService, configFinder, configReader, installer, fs, rt, fuzzyGetter, fuzzyfinderFinder, controller)
+	updateController := update.New(param, repositoriesService, configFinder, configReader, installer, fs, rt, fuzzyGetter, fuzzyfinderFinder, controller, executor)
 	return updateController
 }
 
+func InitializeOutdatedCommandController(ctx context.Context, logE *logrus.Entry, param *config.Param, httpClient *http.Client, rt *runtime.Runtime) *outdated.Controller {
+	repositoriesService := github.New(ctx, logE)
+	fs := afero.NewOsFs()
+	configFinder := finder.NewConfigFinder(fs)
+	configReader := reader.New(fs, param)
+	httpDownloader := download.NewHTTPDownloader(logE, httpClient)
+	gitHubContentFileDownloader := download.NewGitHubContentFileDownloader(repositoriesService, httpDownloader)
+	executor := osexec.New()
+	downloader := download.NewDownloader(repositoriesService, httpDownloader)
+	verifier := cosign.NewVerifier(executor, fs, downloader, param)
+	executorImpl := slsa.NewExecutor(executor, param)
+	slsaVerifier := slsa.New(downloader, fs, executorImpl)
+	installer := registry.New(param, gitHubContentFileDownloader, fs, rt, verifier, slsaVerifier)
+	client := cargo.NewClient(httpClient)
+	cargoVersionGetter := versiongetter.NewCargo(client)
+	gitHubTagVersionGetter := versiongetter.NewGitHubTag(repositoriesService)
+	gitHubReleaseVersionGetter := versiongetter.NewGitHubRelease(repositoriesService)
+	goproxyClient := goproxy.New(httpClient)
+	goGetter := versiongetter.NewGoGetter(goproxyClient)
+	generalVersionGetter := versiongetter.NewGeneralVersionGetter(cargoVersionGetter, gitHubTagVersionGetter, gitHubReleaseVersionGetter, goGetter)
+	controller := outdated.New(repositoriesService, configFinder, configReader, installer, fs, generalVersionGetter)
+	return controller
+}
+
 func InitializeAllowPolicyCommandController(ctx context.Context, param *config.Param) *allowpolicy.Controller {
 	fs := afero.NewOsFs()
 	configFinderImpl := policy.NewConfigFinder(fs)
@@ -416,3 +568,21 @@ func InitializeVacuumInitCommandController(ctx context.Context, logE *logrus.Ent
 	controller := initialize.New(param, rt, fs, client, configFinder, configReader, installer)
 	return controller
 }
+
+func InitializeLintRegistryCommandController(ctx context.Context, param *config.Param) *lintregistry.Controller {
+	fs := afero.NewOsFs()
+	controller := lintregistry.New(fs)
+	return controller
+}
+
+func InitializeTestRegistryCommandController(ctx context.Context, param *config.Param) *testregistry.Controller {
+	fs := afero.NewOsFs()
+	controller := testregistry.New(fs)
+	return controller
+}
+
+func InitializeCacheCommandController(ctx context.Context, param *config.Param) *cache.Controller {
+	fs := afero.NewOsFs()
+	controller := cache.New(fs)
+	return controller
+}
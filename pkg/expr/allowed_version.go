@@ -0,0 +1,27 @@
+package expr
+
+import (
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+)
+
+// CompileAllowedVersion compiles the field `update.allowed_version` of aqua.yaml.
+func CompileAllowedVersion(allowedVersion string) (*vm.Program, error) {
+	return expr.Compile(allowedVersion, expr.AsBool(), expr.Env(map[string]any{ //nolint:wrapcheck
+		"Version":           "",
+		"SemVer":            "",
+		"semver":            emptySemver,
+		"semverWithVersion": compare,
+	}))
+}
+
+// EvaluateAllowedVersion evaluates the compiled `update.allowed_version`.
+// v is a tag and semver is a tag without the version prefix.
+func EvaluateAllowedVersion(prog *vm.Program, v, semver string) (bool, error) {
+	return evaluateBoolProg(prog, map[string]any{
+		"Version":           v,
+		"SemVer":            semver,
+		"semver":            getCompareFunc(semver),
+		"semverWithVersion": compare,
+	})
+}
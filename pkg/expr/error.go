@@ -5,4 +5,7 @@ import "errors"
 var (
 	errMustBeBoolean = errors.New("the evaluation result must be a boolean")
 	errMustBeString  = errors.New("the evaluation result must be a string")
+
+	errUnsupportedVersionExpr = errors.New(`version_expr must be a simple expression like readJSON("<file path>").<key>`)
+	errReadFileWithKeys       = errors.New("readFile can't be used with keys")
 )
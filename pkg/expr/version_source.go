@@ -0,0 +1,48 @@
+package expr
+
+import "regexp"
+
+// VersionSource is a file and a field referenced by a simple version_expr.
+// e.g. readJSON("package.json").devDependencies.typescript
+type VersionSource struct {
+	// Func is one of readFile, readJSON, and readYAML.
+	Func string
+	// Path is a file path. A relative path is relative to the configuration file.
+	Path string
+	// Keys is a path to the field in a JSON or YAML file.
+	Keys []string
+}
+
+var (
+	versionSourcePattern    = regexp.MustCompile(`^\s*(readFile|readJSON|readYAML)\(\s*(?:"([^"]*)"|'([^']*)'|` + "`([^`]*)`" + `)\s*\)((?:\s*(?:\.[A-Za-z_][A-Za-z0-9_]*|\[\s*(?:"[^"]*"|'[^']*')\s*\]))*)\s*$`)
+	versionSourceKeyPattern = regexp.MustCompile(`\.([A-Za-z_][A-Za-z0-9_]*)|\[\s*(?:"([^"]*)"|'([^']*)')\s*\]`)
+)
+
+// ParseVersionSource parses a simple version_expr such as readFile(".terraform-version") and readYAML("versions.yaml").tools.terraform.
+// Complicated expressions are unsupported because it's impossible to know which value should be updated.
+func ParseVersionSource(expression string) (*VersionSource, error) {
+	matches := versionSourcePattern.FindStringSubmatch(expression)
+	if matches == nil {
+		return nil, errUnsupportedVersionExpr
+	}
+	src := &VersionSource{
+		Func: matches[1],
+		Path: firstNonEmpty(matches[2:5]),
+	}
+	for _, m := range versionSourceKeyPattern.FindAllStringSubmatch(matches[5], -1) {
+		src.Keys = append(src.Keys, firstNonEmpty(m[1:]))
+	}
+	if src.Func == "readFile" && len(src.Keys) != 0 {
+		return nil, errReadFileWithKeys
+	}
+	return src, nil
+}
+
+func firstNonEmpty(arr []string) string {
+	for _, s := range arr {
+		if s != "" {
+			return s
+		}
+	}
+	return ""
+}
@@ -0,0 +1,74 @@
+package expr_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/expr"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseVersionSource(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title      string
+		expression string
+		exp        *expr.VersionSource
+		isErr      bool
+	}{
+		{
+			title:      "readFile",
+			expression: `readFile(".terraform-version")`,
+			exp: &expr.VersionSource{
+				Func: "readFile",
+				Path: ".terraform-version",
+			},
+		},
+		{
+			title:      "readJSON",
+			expression: `readJSON('package.json').devDependencies["@types/node"]`,
+			exp: &expr.VersionSource{
+				Func: "readJSON",
+				Path: "package.json",
+				Keys: []string{"devDependencies", "@types/node"},
+			},
+		},
+		{
+			title:      "readYAML",
+			expression: "readYAML(`versions.yaml`).tools.terraform",
+			exp: &expr.VersionSource{
+				Func: "readYAML",
+				Path: "versions.yaml",
+				Keys: []string{"tools", "terraform"},
+			},
+		},
+		{
+			title:      "readFile with keys",
+			expression: `readFile(".terraform-version").version`,
+			isErr:      true,
+		},
+		{
+			title:      "complicated expression",
+			expression: `"v" + readFile(".terraform-version")`,
+			isErr:      true,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			src, err := expr.ParseVersionSource(d.expression)
+			if d.isErr {
+				if err == nil {
+					t.Fatal("err should be returned")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(d.exp, src); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -26,7 +26,13 @@ type (
 	RepositoryContent           = github.RepositoryContent
 	Response                    = github.Response
 	RepositoryTag               = github.RepositoryTag
+	RepositoryCommit            = github.RepositoryCommit
+	Commit                      = github.Commit
+	CommitAuthor                = github.CommitAuthor
+	Timestamp                   = github.Timestamp
 	ArchiveFormat               = github.ArchiveFormat
+	AttestationsResponse        = github.AttestationsResponse
+	Attestation                 = github.Attestation
 )
 
 const Tarball = github.Tarball
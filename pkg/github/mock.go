@@ -19,6 +19,7 @@ var (
 	errGetTar          = errors.New("failed to get tar")
 	errGetRepo         = errors.New("failed to get repo")
 	errListAssets      = errors.New("failed to list assets")
+	errCommitNotFound  = errors.New("commit isn't found")
 )
 
 type MockRepositoriesService struct {
@@ -29,6 +30,9 @@ type MockRepositoriesService struct {
 	Asset    string
 	Assets   []*github.ReleaseAsset
 	URL      *url.URL
+	Commits  map[string]*github.RepositoryCommit
+	// Attestations is a map of subject digests to attestations.
+	Attestations map[string]*github.AttestationsResponse
 }
 
 func (m *MockRepositoriesService) GetLatestRelease(ctx context.Context, repoOwner, repoName string) (*github.RepositoryRelease, *github.Response, error) {
@@ -77,6 +81,14 @@ func (m *MockRepositoriesService) ListTags(ctx context.Context, owner string, re
 	return m.Tags, &github.Response{}, nil
 }
 
+func (m *MockRepositoriesService) GetCommit(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) (*github.RepositoryCommit, *github.Response, error) {
+	commit, ok := m.Commits[sha]
+	if !ok {
+		return nil, nil, errCommitNotFound
+	}
+	return commit, &github.Response{}, nil
+}
+
 func (m *MockRepositoriesService) GetArchiveLink(ctx context.Context, owner, repo string, archiveformat github.ArchiveFormat, opts *github.RepositoryContentGetOptions, maxRedirects int) (*url.URL, *github.Response, error) {
 	if m.URL == nil {
 		return nil, nil, errGetTar
@@ -97,3 +109,10 @@ func (m *MockRepositoriesService) ListReleaseAssets(ctx context.Context, owner,
 	}
 	return m.Assets, &github.Response{}, nil
 }
+
+func (m *MockRepositoriesService) ListAttestations(ctx context.Context, owner, repo, subjectDigest string, opts *github.ListOptions) (*github.AttestationsResponse, *github.Response, error) {
+	if resp, ok := m.Attestations[subjectDigest]; ok {
+		return resp, &github.Response{}, nil
+	}
+	return &github.AttestationsResponse{}, &github.Response{}, nil
+}
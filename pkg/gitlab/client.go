@@ -0,0 +1,152 @@
+// Package gitlab provides a minimal client of GitLab REST API to get projects and releases.
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/aquaproj/aqua/v2/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+const DefaultHost = "gitlab.com"
+
+type Project struct {
+	Description string `json:"description"`
+	WebURL      string `json:"web_url"`
+}
+
+type Release struct {
+	TagName string  `json:"tag_name"`
+	Assets  *Assets `json:"assets"`
+}
+
+type Assets struct {
+	Links []*Link `json:"links"`
+}
+
+type Link struct {
+	Name           string `json:"name"`
+	URL            string `json:"url"`
+	DirectAssetURL string `json:"direct_asset_url"`
+}
+
+// GetURL returns the URL to download the asset.
+// The direct asset URL is preferred because it is stable across releases.
+func (l *Link) GetURL() string {
+	if l.DirectAssetURL != "" {
+		return l.DirectAssetURL
+	}
+	return l.URL
+}
+
+type Client struct {
+	client *http.Client
+	token  string
+}
+
+// NewClient returns a client of GitLab API.
+// If the environment variable GITLAB_TOKEN is set, it's used to access private projects.
+func NewClient(client *http.Client) *Client {
+	return &Client{
+		client: client,
+		token:  os.Getenv("GITLAB_TOKEN"),
+	}
+}
+
+// GetProject gets a project.
+// host is a host name of GitLab such as gitlab.com.
+// project is a path of the project such as gitlab-org/cli.
+func (c *Client) GetProject(ctx context.Context, host, project string) (*Project, error) {
+	p := &Project{}
+	if _, err := c.get(ctx, projectURL(host, project), p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ListOptions is options of pagination.
+// If Page is zero, the first page is returned.
+type ListOptions struct {
+	Page    int
+	PerPage int
+}
+
+// ListReleases lists releases of a project sorted by the released date in descending order.
+// It returns the next page number given by the header X-Next-Page.
+// The next page number is zero if there is no next page.
+func (c *Client) ListReleases(ctx context.Context, host, project string, opt *ListOptions) ([]*Release, int, error) {
+	query := url.Values{}
+	if opt.PerPage > 0 {
+		query.Set("per_page", strconv.Itoa(opt.PerPage))
+	}
+	if opt.Page > 0 {
+		query.Set("page", strconv.Itoa(opt.Page))
+	}
+	uri := projectURL(host, project) + "/releases"
+	if len(query) != 0 {
+		uri += "?" + query.Encode()
+	}
+	var releases []*Release
+	header, err := c.get(ctx, uri, &releases)
+	if err != nil {
+		return nil, 0, err
+	}
+	return releases, nextPage(header), nil
+}
+
+// nextPage returns the next page number given by the header X-Next-Page.
+// The header is empty on the last page.
+func nextPage(header http.Header) int {
+	page, err := strconv.Atoi(header.Get("X-Next-Page"))
+	if err != nil {
+		return 0
+	}
+	return page
+}
+
+// GetRelease gets a release by a tag.
+func (c *Client) GetRelease(ctx context.Context, host, project, tag string) (*Release, error) {
+	release := &Release{}
+	if _, err := c.get(ctx, projectURL(host, project)+"/releases/"+url.PathEscape(tag), release); err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+func projectURL(host, project string) string {
+	return fmt.Sprintf("https://%s/api/v4/projects/%s", host, url.PathEscape(project))
+}
+
+// get sends a GET request and decodes the response body as JSON.
+// It returns the response header for pagination.
+func (c *Client) get(ctx context.Context, uri string, payload any) (http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create a HTTP request: %w", err)
+	}
+	if c.token != "" {
+		req.Header.Add("PRIVATE-TOKEN", c.token)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send a HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 { //nolint:mnd
+		return nil, logerr.WithFields(errors.ErrHTTPStatusCodeIsGreaterEqualThan300, logrus.Fields{ //nolint:wrapcheck
+			"url":         uri,
+			"status_code": resp.StatusCode,
+		})
+	}
+	if err := json.NewDecoder(resp.Body).Decode(payload); err != nil {
+		return nil, fmt.Errorf("decode the response body as JSON: %w", err)
+	}
+	return resp.Header, nil
+}
@@ -0,0 +1,27 @@
+package gitlab
+
+import (
+	"net/http"
+	"testing"
+)
+
+func Test_nextPage(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name   string
+		header http.Header
+		exp    int
+	}{
+		{name: "next page", header: http.Header{"X-Next-Page": []string{"2"}}, exp: 2},
+		{name: "last page", header: http.Header{"X-Next-Page": []string{""}}},
+		{name: "no header", header: http.Header{}},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			if page := nextPage(d.header); page != d.exp {
+				t.Fatalf("got %d, wanted %d", page, d.exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+)
+
+var errReleaseNotFound = errors.New("release isn't found")
+
+type MockClient struct {
+	Project  *Project
+	Releases []*Release
+	Err      error
+}
+
+func (m *MockClient) GetProject(ctx context.Context, host, project string) (*Project, error) {
+	return m.Project, m.Err
+}
+
+// ListReleases returns a page of Releases like GitLab API.
+func (m *MockClient) ListReleases(ctx context.Context, host, project string, opt *ListOptions) ([]*Release, int, error) {
+	if m.Err != nil {
+		return nil, 0, m.Err
+	}
+	page := max(opt.Page, 1)
+	perPage := opt.PerPage
+	if perPage <= 0 {
+		perPage = 20
+	}
+	start := min((page-1)*perPage, len(m.Releases))
+	end := min(start+perPage, len(m.Releases))
+	if end == len(m.Releases) {
+		return m.Releases[start:end], 0, nil
+	}
+	return m.Releases[start:end], page + 1, nil
+}
+
+func (m *MockClient) GetRelease(ctx context.Context, host, project, tag string) (*Release, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	for _, release := range m.Releases {
+		if release.TagName == tag {
+			return release, nil
+		}
+	}
+	return nil, errReleaseNotFound
+}
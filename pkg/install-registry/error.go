@@ -3,7 +3,9 @@ package registry
 import "errors"
 
 var (
-	errUnsupportedRegistryType = errors.New("unsupported registry type")
-	errLocalRegistryNotFound   = errors.New("local registry isn't found")
-	errInstallFailure          = errors.New("it failed to install some registries")
+	errUnsupportedRegistryType  = errors.New("unsupported registry type")
+	errLocalRegistryNotFound    = errors.New("local registry isn't found")
+	errInstallFailure           = errors.New("it failed to install some registries")
+	errExtendedRegistryNotFound = errors.New("the extended registry isn't found")
+	errCircularExtends          = errors.New("registries extend each other circularly")
 )
@@ -0,0 +1,81 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// extendRegistries resolves registries extending other registries.
+// Packages of an extending registry are deep-merged onto packages of the extended registry.
+func (is *Installer) extendRegistries(ctx context.Context, logE *logrus.Entry, cfg *aqua.Config, cfgFilePath string, checksums *checksum.Checksums, registryContents map[string]*registry.Config, pkgNames map[string][]string) (map[string]*registry.Config, error) {
+	extended := make(map[string]*registry.Config, len(registryContents))
+	for name, content := range registryContents {
+		c, err := is.extendRegistry(ctx, logE, cfg, cfgFilePath, checksums, registryContents, name, content, pkgNames[name], map[string]struct{}{})
+		if err != nil {
+			return nil, logerr.WithFields(err, logrus.Fields{ //nolint:wrapcheck
+				"registry_name": name,
+			})
+		}
+		extended[name] = c
+	}
+	return extended, nil
+}
+
+// extendRegistry resolves the registry recursively.
+// pkgNames are names of packages which are needed from extended registries.
+// They are used to install package files of extended split registries.
+func (is *Installer) extendRegistry(ctx context.Context, logE *logrus.Entry, cfg *aqua.Config, cfgFilePath string, checksums *checksum.Checksums, registryContents map[string]*registry.Config, name string, content *registry.Config, pkgNames []string, visited map[string]struct{}) (*registry.Config, error) {
+	if content.Extends == "" {
+		return content, nil
+	}
+	if _, ok := visited[name]; ok {
+		return nil, errCircularExtends
+	}
+	visited[name] = struct{}{}
+	baseName, err := cfg.Registries.ExtendedRegistryName(content.Extends)
+	if err != nil {
+		return nil, fmt.Errorf("resolve the extended registry: %w", err)
+	}
+	base, ok := registryContents[baseName]
+	if !ok {
+		return nil, logerr.WithFields(errExtendedRegistryNotFound, logrus.Fields{ //nolint:wrapcheck
+			"extended_registry_name": baseName,
+		})
+	}
+	baseNames := slices.Concat(pkgNames, registryPackageNames(base))
+	if base.IsSplit() {
+		// The split registry includes only package files of its own packages,
+		// so package files of patched packages are installed.
+		b, err := is.InstallRegistry(ctx, logE, cfg.Registries[baseName], cfgFilePath, checksums, baseNames...)
+		if err != nil {
+			return nil, fmt.Errorf("install the extended registry: %w", err)
+		}
+		base = b
+	}
+	base, err = is.extendRegistry(ctx, logE, cfg, cfgFilePath, checksums, registryContents, baseName, base, baseNames, visited)
+	if err != nil {
+		return nil, err
+	}
+	extended, err := content.Extend(logE, base)
+	if err != nil {
+		return nil, fmt.Errorf("extend the registry: %w", err)
+	}
+	return extended, nil
+}
+
+func registryPackageNames(content *registry.Config) []string {
+	names := make([]string, 0, len(content.PackageInfos))
+	for _, pkgInfo := range content.PackageInfos {
+		if pkgInfo != nil {
+			names = append(names, pkgInfo.GetName())
+		}
+	}
+	return names
+}
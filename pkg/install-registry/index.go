@@ -0,0 +1,32 @@
+package registry
+
+import (
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// createIndex creates the index of the registry if it doesn't exist or it can't be read.
+// Indexes of other versions and broken indexes are rebuilt.
+// The index is used to look up packages without decoding the whole registry.
+// Failing to create the index isn't fatal because the registry itself is available.
+func (is *Installer) createIndex(logE *logrus.Entry, registryFilePath string, content *registry.Config) {
+	indexPath := registry.IndexFilePath(registryFilePath)
+	logE = logE.WithField("registry_index_path", indexPath)
+	if exist, err := afero.Exists(is.fs, indexPath); err != nil {
+		logerr.WithError(logE, err).Debug("check if a registry index exists")
+		return
+	} else if exist {
+		_, err := registry.ReadIndex(is.fs, indexPath)
+		if err == nil {
+			return
+		}
+		logerr.WithError(logE, err).Debug("rebuild a registry index")
+	}
+	if err := registry.WriteIndex(logE, is.fs, indexPath, content); err != nil {
+		logerr.WithError(logE, err).Warn("create a registry index")
+		return
+	}
+	logE.Debug("created a registry index")
+}
@@ -0,0 +1,86 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+func TestInstaller_createIndex(t *testing.T) {
+	t.Parallel()
+	const registryFilePath = "/home/foo/registry.yaml"
+	data := []struct {
+		name  string
+		index string
+	}{
+		{
+			name: "index doesn't exist",
+		},
+		{
+			name:  "stale version",
+			index: `{"version":0,"packages":{}}` + "\n",
+		},
+		{
+			name:  "broken index",
+			index: "{",
+		},
+	}
+	logE := logrus.NewEntry(logrus.New())
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			fs := afero.NewMemMapFs()
+			indexPath := registry.IndexFilePath(registryFilePath)
+			if d.index != "" {
+				if err := afero.WriteFile(fs, indexPath, []byte(d.index), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			is := &Installer{fs: fs}
+			is.createIndex(logE, registryFilePath, &registry.Config{
+				PackageInfos: registry.PackageInfos{
+					{
+						Type:      "github_release",
+						RepoOwner: "suzuki-shunsuke",
+						RepoName:  "tfcmt",
+					},
+				},
+			})
+			idx, err := registry.ReadIndex(fs, indexPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, found := idx.MaybeHasCommand("suzuki-shunsuke/tfcmt", "tfcmt"); !found {
+				t.Fatal("the index must be rebuilt")
+			}
+		})
+	}
+}
+
+func TestInstaller_createIndex_valid(t *testing.T) {
+	t.Parallel()
+	const registryFilePath = "/home/foo/registry.yaml"
+	fs := afero.NewMemMapFs()
+	logE := logrus.NewEntry(logrus.New())
+	is := &Installer{fs: fs}
+	is.createIndex(logE, registryFilePath, &registry.Config{
+		PackageInfos: registry.PackageInfos{
+			{
+				Type:      "github_release",
+				RepoOwner: "suzuki-shunsuke",
+				RepoName:  "tfcmt",
+			},
+		},
+	})
+	// A valid index isn't rebuilt.
+	is.createIndex(logE, registryFilePath, &registry.Config{})
+	idx, err := registry.ReadIndex(fs, registry.IndexFilePath(registryFilePath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := idx.MaybeHasCommand("suzuki-shunsuke/tfcmt", "tfcmt"); !found {
+		t.Fatal("a valid index must not be rebuilt")
+	}
+}
@@ -28,6 +28,13 @@ func (is *Installer) InstallRegistries(ctx context.Context, logE *logrus.Entry,
 	}
 	maxInstallChan := make(chan struct{}, is.param.MaxParallelism)
 	registryContents := make(map[string]*registry.Config, len(cfg.Registries)+1)
+	pkgNames := map[string][]string{}
+	for _, pkg := range cfg.Packages {
+		if pkg == nil {
+			continue
+		}
+		pkgNames[pkg.Registry] = append(pkgNames[pkg.Registry], pkg.Name)
+	}
 
 	for _, registry := range cfg.Registries {
 		if registry == nil {
@@ -41,7 +48,7 @@ func (is *Installer) InstallRegistries(ctx context.Context, logE *logrus.Entry,
 				return
 			}
 			maxInstallChan <- struct{}{}
-			registryContent, err := is.InstallRegistry(ctx, logE, registry, cfgFilePath, checksums)
+			registryContent, err := is.InstallRegistry(ctx, logE, registry, cfgFilePath, checksums, pkgNames[registry.Name]...)
 			if err != nil {
 				<-maxInstallChan
 				logerr.WithError(logE, err).WithFields(logrus.Fields{
@@ -63,12 +70,13 @@ func (is *Installer) InstallRegistries(ctx context.Context, logE *logrus.Entry,
 		return nil, errInstallFailure
 	}
 
-	return registryContents, nil
+	return is.extendRegistries(ctx, logE, cfg, cfgFilePath, checksums, registryContents, pkgNames)
 }
 
 // InstallRegistry installs and reads the registry file and returns the registry content.
 // If the registry file already exists, the installation is skipped.
-func (is *Installer) InstallRegistry(ctx context.Context, logE *logrus.Entry, regist *aqua.Registry, cfgFilePath string, checksums *checksum.Checksums) (*registry.Config, error) {
+// If the registry is a split registry, only package files of pkgNames are installed.
+func (is *Installer) InstallRegistry(ctx context.Context, logE *logrus.Entry, regist *aqua.Registry, cfgFilePath string, checksums *checksum.Checksums, pkgNames ...string) (*registry.Config, error) {
 	if err := regist.Validate(); err != nil {
 		return nil, fmt.Errorf("validate the registry: %w", err)
 	}
@@ -78,18 +86,44 @@ func (is *Installer) InstallRegistry(ctx context.Context, logE *logrus.Entry, re
 	}
 
 	if regist.Type == aqua.RegistryTypeLocal {
-		registryContent := &registry.Config{}
-		if err := is.readRegistry(registryFilePath, registryContent); err != nil {
-			if errors.Is(err, os.ErrNotExist) {
-				return nil, logerr.WithFields(errLocalRegistryNotFound, logrus.Fields{ //nolint:wrapcheck
-					"local_registry_file_path": registryFilePath,
-				})
-			}
+		registryContent, err := is.readLocalRegistry(registryFilePath)
+		if err != nil {
 			return nil, err
 		}
+		if registryContent.IsSplit() {
+			return is.installPackageFiles(ctx, logE, regist, registryFilePath, registryContent, checksums, pkgNames)
+		}
 		return registryContent, nil
 	}
 
+	registryContent, err := is.installGitHubContentRegistry(ctx, logE, regist, registryFilePath, checksums)
+	if err != nil {
+		return nil, err
+	}
+	if registryContent.IsSplit() {
+		return is.installPackageFiles(ctx, logE, regist, registryFilePath, registryContent, checksums, pkgNames)
+	}
+	if registryContent.Extends == "" {
+		// Packages of extending registries are patches, so they can't be looked up by the index.
+		is.createIndex(logE, registryFilePath, registryContent)
+	}
+	return registryContent, nil
+}
+
+func (is *Installer) readLocalRegistry(registryFilePath string) (*registry.Config, error) {
+	registryContent := &registry.Config{}
+	if err := is.readRegistry(registryFilePath, registryContent); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, logerr.WithFields(errLocalRegistryNotFound, logrus.Fields{ //nolint:wrapcheck
+				"local_registry_file_path": registryFilePath,
+			})
+		}
+		return nil, err
+	}
+	return registryContent, nil
+}
+
+func (is *Installer) installGitHubContentRegistry(ctx context.Context, logE *logrus.Entry, regist *aqua.Registry, registryFilePath string, checksums *checksum.Checksums) (*registry.Config, error) {
 	if !isJSON(registryFilePath) {
 		return is.handleYAMLGitHubContent(ctx, logE, regist, checksums, registryFilePath)
 	}
@@ -155,6 +155,298 @@ func TestInstaller_InstallRegistries(t *testing.T) { //nolint:funlen
 				},
 			})),
 		},
+		{
+			name: "split",
+			param: &config.Param{
+				MaxParallelism: 5,
+			},
+			cfgFilePath: "aqua.yaml",
+			files: map[string]string{
+				// package files which aren't referenced don't exist
+				"registry/registry.yaml": `package_files:
+- name: aquaproj/aqua-installer
+  aliases:
+  - name: aqua-installer
+- name: suzuki-shunsuke/tfcmt
+- name: cli/cli
+  path: gh.yaml
+`,
+				"registry/pkgs/aquaproj/aqua-installer/registry.yaml": `packages:
+- type: github_content
+  repo_owner: aquaproj
+  repo_name: aqua-installer
+  path: aqua-installer
+`,
+				"registry/gh.yaml": `packages:
+- type: github_release
+  repo_owner: cli
+  repo_name: cli
+  asset: gh.tar.gz
+`,
+			},
+			cfg: &aqua.Config{
+				Registries: aqua.Registries{
+					"local": {
+						Type: "local",
+						Name: "local",
+						Path: "registry/registry.yaml",
+					},
+					"standard": {
+						Type:      "github_content",
+						Name:      "standard",
+						RepoOwner: "aquaproj",
+						RepoName:  "aqua-registry",
+						Ref:       "v2.16.0",
+						Path:      "registry/registry.yaml",
+					},
+				},
+				Packages: []*aqua.Package{
+					{Name: "aqua-installer", Registry: "local"},
+					{Name: "cli/cli", Registry: "local"},
+					{Name: "suzuki-shunsuke/ci-info", Registry: "standard"},
+				},
+			},
+			exp: map[string]*cfgRegistry.Config{
+				"local": {
+					PackageFiles: []*cfgRegistry.PackageFile{
+						{
+							Name: "aquaproj/aqua-installer",
+							Aliases: []*cfgRegistry.Alias{
+								{Name: "aqua-installer"},
+							},
+						},
+						{Name: "suzuki-shunsuke/tfcmt"},
+						{Name: "cli/cli", Path: "gh.yaml"},
+					},
+					PackageInfos: cfgRegistry.PackageInfos{
+						{
+							Type:      "github_content",
+							RepoOwner: "aquaproj",
+							RepoName:  "aqua-installer",
+							Path:      "aqua-installer",
+							Aliases: []*cfgRegistry.Alias{
+								{Name: "aqua-installer"},
+							},
+						},
+						{
+							Type:      "github_release",
+							RepoOwner: "cli",
+							RepoName:  "cli",
+							Asset:     "gh.tar.gz",
+						},
+					},
+				},
+				"standard": {
+					PackageFiles: []*cfgRegistry.PackageFile{
+						{Name: "suzuki-shunsuke/ci-info"},
+						{Name: "suzuki-shunsuke/tfcmt"},
+					},
+					PackageInfos: cfgRegistry.PackageInfos{
+						{
+							Type:      "github_release",
+							RepoOwner: "suzuki-shunsuke",
+							RepoName:  "ci-info",
+							Asset:     "ci-info_{{.Arch}}-{{.OS}}.tar.gz",
+						},
+					},
+				},
+			},
+			downloader: download.NewGitHubContentFileDownloader(nil, download.NewHTTPDownloader(logE, &http.Client{
+				Transport: &flute.Transport{
+					Services: []flute.Service{
+						{
+							Endpoint: "https://raw.githubusercontent.com",
+							Routes: []flute.Route{
+								{
+									Name: "download an index file",
+									Matcher: &flute.Matcher{
+										Method: "GET",
+										Path:   "/aquaproj/aqua-registry/v2.16.0/registry/registry.yaml",
+									},
+									Response: &flute.Response{
+										Base: http.Response{
+											StatusCode: http.StatusOK,
+										},
+										BodyString: `package_files:
+- name: suzuki-shunsuke/ci-info
+- name: suzuki-shunsuke/tfcmt
+`,
+									},
+								},
+								{
+									Name: "download a package file",
+									Matcher: &flute.Matcher{
+										Method: "GET",
+										Path:   "/aquaproj/aqua-registry/v2.16.0/registry/pkgs/suzuki-shunsuke/ci-info/registry.yaml",
+									},
+									Response: &flute.Response{
+										Base: http.Response{
+											StatusCode: http.StatusOK,
+										},
+										BodyString: `packages:
+- type: github_release
+  repo_owner: suzuki-shunsuke
+  repo_name: ci-info
+  asset: "ci-info_{{.Arch}}-{{.OS}}.tar.gz"
+`,
+									},
+								},
+							},
+						},
+					},
+				},
+			})),
+		},
+		{
+			name: "extends",
+			param: &config.Param{
+				MaxParallelism: 5,
+			},
+			cfgFilePath: "aqua.yaml",
+			files: map[string]string{
+				"registry.yaml": `packages:
+- type: github_release
+  repo_owner: cli
+  repo_name: cli
+  asset: gh_{{.OS}}_{{.Arch}}.tar.gz
+  supported_envs:
+  - darwin
+  - linux
+- type: github_release
+  repo_owner: suzuki-shunsuke
+  repo_name: tfcmt
+  asset: tfcmt_{{.OS}}_{{.Arch}}.tar.gz
+`,
+				"overlay.yaml": `extends: base
+packages:
+- name: cli/cli
+  supported_envs:
+  - darwin
+  - linux
+  - windows
+- type: github_release
+  repo_owner: example
+  repo_name: internal-tool
+  asset: tool.tar.gz
+`,
+			},
+			cfg: &aqua.Config{
+				Registries: aqua.Registries{
+					"base": {
+						Type: "local",
+						Name: "base",
+						Path: "registry.yaml",
+					},
+					"overlay": {
+						Type: "local",
+						Name: "overlay",
+						Path: "overlay.yaml",
+					},
+				},
+			},
+			exp: map[string]*cfgRegistry.Config{
+				"base": {
+					PackageInfos: cfgRegistry.PackageInfos{
+						{
+							Type:          "github_release",
+							RepoOwner:     "cli",
+							RepoName:      "cli",
+							Asset:         "gh_{{.OS}}_{{.Arch}}.tar.gz",
+							SupportedEnvs: cfgRegistry.SupportedEnvs{"darwin", "linux"},
+						},
+						{
+							Type:      "github_release",
+							RepoOwner: "suzuki-shunsuke",
+							RepoName:  "tfcmt",
+							Asset:     "tfcmt_{{.OS}}_{{.Arch}}.tar.gz",
+						},
+					},
+				},
+				"overlay": {
+					PackageInfos: cfgRegistry.PackageInfos{
+						{
+							Name:          "cli/cli",
+							Type:          "github_release",
+							RepoOwner:     "cli",
+							RepoName:      "cli",
+							Asset:         "gh_{{.OS}}_{{.Arch}}.tar.gz",
+							SupportedEnvs: cfgRegistry.SupportedEnvs{"darwin", "linux", "windows"},
+						},
+						{
+							Type:      "github_release",
+							RepoOwner: "suzuki-shunsuke",
+							RepoName:  "tfcmt",
+							Asset:     "tfcmt_{{.OS}}_{{.Arch}}.tar.gz",
+						},
+						{
+							Type:      "github_release",
+							RepoOwner: "example",
+							RepoName:  "internal-tool",
+							Asset:     "tool.tar.gz",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "the local name of the extended registry is different",
+			param: &config.Param{
+				MaxParallelism: 5,
+			},
+			cfgFilePath: "aqua.yaml",
+			files: map[string]string{
+				"registry.yaml": `packages: []
+`,
+				"overlay.yaml": `extends: standard
+packages: []
+`,
+			},
+			cfg: &aqua.Config{
+				Registries: aqua.Registries{
+					"base": {
+						Type: "local",
+						Name: "base",
+						Path: "registry.yaml",
+					},
+					"overlay": {
+						Type: "local",
+						Name: "overlay",
+						Path: "overlay.yaml",
+					},
+				},
+			},
+			isErr: true,
+		},
+		{
+			name: "circular extends",
+			param: &config.Param{
+				MaxParallelism: 5,
+			},
+			cfgFilePath: "aqua.yaml",
+			files: map[string]string{
+				"a.yaml": `extends: b
+packages: []
+`,
+				"b.yaml": `extends: a
+packages: []
+`,
+			},
+			cfg: &aqua.Config{
+				Registries: aqua.Registries{
+					"a": {
+						Type: "local",
+						Name: "a",
+						Path: "a.yaml",
+					},
+					"b": {
+						Type: "local",
+						Name: "b",
+						Path: "b.yaml",
+					},
+				},
+			},
+			isErr: true,
+		},
 	}
 	rt := &runtime.Runtime{
 		GOOS:   "linux",
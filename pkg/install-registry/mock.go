@@ -17,3 +17,7 @@ type MockInstaller struct {
 func (m *MockInstaller) InstallRegistries(ctx context.Context, logE *logrus.Entry, cfg *aqua.Config, cfgFilePath string, checksums *checksum.Checksums) (map[string]*registry.Config, error) {
 	return m.M, m.Err
 }
+
+func (m *MockInstaller) InstallRegistry(ctx context.Context, logE *logrus.Entry, regist *aqua.Registry, cfgFilePath string, checksums *checksum.Checksums, pkgNames ...string) (*registry.Config, error) {
+	return m.M[regist.Name], m.Err
+}
@@ -0,0 +1,84 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/aquaproj/aqua/v2/pkg/checksum"
+	"github.com/aquaproj/aqua/v2/pkg/config/aqua"
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/logrus-error/logerr"
+)
+
+// installPackageFiles installs package files of a split registry and returns the registry content consisting of them.
+// Only package files of the given packages are downloaded or read.
+func (is *Installer) installPackageFiles(ctx context.Context, logE *logrus.Entry, regist *aqua.Registry, registryFilePath string, index *registry.Config, checksums *checksum.Checksums, pkgNames []string) (*registry.Config, error) {
+	registryContent := &registry.Config{
+		PackageFiles: index.PackageFiles,
+		Extends:      index.Extends,
+	}
+	installed := map[string]struct{}{}
+	for _, pkgName := range pkgNames {
+		pf := index.PackageFile(pkgName)
+		if pf == nil {
+			logE.WithField("package_name", pkgName).Debug("the package isn't found in the split registry")
+			continue
+		}
+		if _, ok := installed[pf.Name]; ok {
+			continue
+		}
+		installed[pf.Name] = struct{}{}
+		pkgContent, err := is.installPackageFile(ctx, logE, regist, registryFilePath, pf, checksums)
+		if err != nil {
+			return nil, logerr.WithFields(err, logrus.Fields{ //nolint:wrapcheck
+				"package_name": pf.Name,
+			})
+		}
+		for _, pkgInfo := range pkgContent.PackageInfos {
+			if pkgInfo != nil && pkgInfo.GetName() == pf.Name {
+				addAliases(pkgInfo, pf.Aliases)
+			}
+		}
+		registryContent.PackageInfos = append(registryContent.PackageInfos, pkgContent.PackageInfos...)
+	}
+	return registryContent, nil
+}
+
+// installPackageFile installs a package file of a split registry.
+// Package files are located relatively from the index file.
+func (is *Installer) installPackageFile(ctx context.Context, logE *logrus.Entry, regist *aqua.Registry, registryFilePath string, pf *registry.PackageFile, checksums *checksum.Checksums) (*registry.Config, error) {
+	pkgPath, err := pf.GetPath()
+	if err != nil {
+		return nil, fmt.Errorf("get the path of the package file: %w", err)
+	}
+	pkgFilePath := filepath.Join(filepath.Dir(registryFilePath), filepath.FromSlash(pkgPath))
+	if regist.Type == aqua.RegistryTypeLocal {
+		return is.readLocalRegistry(pkgFilePath)
+	}
+	pkgRegistry := *regist
+	pkgRegistry.Path = path.Join(path.Dir(regist.Path), pkgPath)
+	return is.installGitHubContentRegistry(ctx, logE, &pkgRegistry, pkgFilePath, checksums)
+}
+
+// addAliases adds aliases in the index file to the package
+// so that the package can be found by the aliases even if the package file doesn't have them.
+func addAliases(pkgInfo *registry.PackageInfo, aliases []*registry.Alias) {
+	for _, alias := range aliases {
+		if alias == nil || alias.Name == "" {
+			continue
+		}
+		found := false
+		for _, a := range pkgInfo.Aliases {
+			if a != nil && a.Name == alias.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			pkgInfo.Aliases = append(pkgInfo.Aliases, alias)
+		}
+	}
+}
@@ -64,6 +64,16 @@ func (e *Executor) ExecStderr(cmd *exec.Cmd) (int, error) {
 	return e.Exec(cmd)
 }
 
+// ExecAndGetOutput executes a command without the standard input and returns the combined output instead of outputting it.
+func (e *Executor) ExecAndGetOutput(cmd *exec.Cmd) (string, int, error) {
+	out := &bytes.Buffer{}
+	cmd.Stdin = nil
+	cmd.Stdout = out
+	cmd.Stderr = out
+	code, err := e.Exec(cmd)
+	return out.String(), code, err
+}
+
 func (e *Executor) execAndGetCombinedOutput(cmd *exec.Cmd) (string, int, error) {
 	out := &bytes.Buffer{}
 	cmd.Stdout = io.MultiWriter(cmd.Stdout, out)
@@ -14,7 +14,7 @@ func (e *Mock) ExecStderr(cmd *Cmd) (int, error) {
 	return e.ExitCode, e.Err
 }
 
-func (e *Mock) ExecXSys(_, _ string, _ ...string) error {
+func (e *Mock) ExecXSys(_, _ string, _ []string, _ ...string) error {
 	return e.Err
 }
 
@@ -25,3 +25,7 @@ func (e *Mock) ExecAndOutputWhenFailure(cmd *Cmd) (int, error) {
 func (e *Mock) ExecStderrAndGetCombinedOutput(cmd *Cmd) (string, int, error) {
 	return e.Output, e.ExitCode, e.Err
 }
+
+func (e *Mock) ExecAndGetOutput(cmd *Cmd) (string, int, error) {
+	return e.Output, e.ExitCode, e.Err
+}
@@ -0,0 +1,84 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+const (
+	glibc = "glibc"
+	musl  = "musl"
+)
+
+var errInvalidLibc = errors.New("AQUA_LIBC must be either glibc or musl")
+
+func libc(goos string) string {
+	if goos != linux {
+		return ""
+	}
+	if s := os.Getenv("AQUA_LIBC"); s != "" {
+		return s
+	}
+	if runtime.GOOS != linux {
+		// The libc of the target platform can't be detected on other platforms.
+		return ""
+	}
+	return detectLibc()
+}
+
+// detectLibc detects the libc of the running Linux.
+var detectLibc = sync.OnceValue(func() string { //nolint:gochecknoglobals
+	if runtime.GOOS != linux {
+		return ""
+	}
+	return detectLibcByLoader(filepath.Glob)
+})
+
+// detectLibcByLoader detects the libc by the dynamic linker.
+// glibc based distributions have the dynamic linker of glibc such as /lib64/ld-linux-x86-64.so.2 and /lib/ld-linux-aarch64.so.1,
+// and musl based distributions such as Alpine Linux have the dynamic linker of musl such as /lib/ld-musl-x86_64.so.1.
+// If neither is found, the libc is unknown and an empty string is returned.
+func detectLibcByLoader(glob func(pattern string) ([]string, error)) string {
+	if matches, err := glob("/lib*/ld-linux-*.so.*"); err == nil && len(matches) > 0 {
+		return glibc
+	}
+	if matches, err := glob("/lib/ld-musl-*.so.1"); err == nil && len(matches) > 0 {
+		return musl
+	}
+	return ""
+}
+
+// Validate returns an error if the runtime is invalid.
+// AQUA_LIBC must be either glibc or musl.
+func (rt *Runtime) Validate() error {
+	switch rt.Libc {
+	case "", glibc, musl:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", errInvalidLibc, rt.Libc)
+	}
+}
+
+// ExpandLibc returns runtimes where each Linux runtime without libc is expanded to runtimes of glibc and musl.
+// This is used to handle packages whose overrides depend on libc.
+func ExpandLibc(rts []*Runtime) []*Runtime {
+	ret := make([]*Runtime, 0, len(rts))
+	for _, rt := range rts {
+		if rt.GOOS != linux || rt.Libc != "" {
+			ret = append(ret, rt)
+			continue
+		}
+		for _, l := range []string{glibc, musl} {
+			ret = append(ret, &Runtime{
+				GOOS:   rt.GOOS,
+				GOARCH: rt.GOARCH,
+				Libc:   l,
+			})
+		}
+	}
+	return ret
+}
@@ -0,0 +1,61 @@
+package runtime
+
+import (
+	"path"
+	"testing"
+)
+
+func Test_detectLibcByLoader(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name  string
+		files []string
+		exp   string
+	}{
+		{
+			name:  "glibc amd64",
+			files: []string{"/lib64/ld-linux-x86-64.so.2"},
+			exp:   "glibc",
+		},
+		{
+			name:  "glibc arm64",
+			files: []string{"/lib/ld-linux-aarch64.so.1"},
+			exp:   "glibc",
+		},
+		{
+			name:  "musl",
+			files: []string{"/lib/ld-musl-x86_64.so.1"},
+			exp:   "musl",
+		},
+		{
+			name:  "glibc is preferred",
+			files: []string{"/lib/ld-musl-x86_64.so.1", "/lib64/ld-linux-x86-64.so.2"},
+			exp:   "glibc",
+		},
+		{
+			name: "unknown",
+			exp:  "",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			glob := func(pattern string) ([]string, error) {
+				var matches []string
+				for _, file := range d.files {
+					ok, err := path.Match(pattern, file)
+					if err != nil {
+						return nil, err
+					}
+					if ok {
+						matches = append(matches, file)
+					}
+				}
+				return matches, nil
+			}
+			if libc := detectLibcByLoader(glob); libc != d.exp {
+				t.Fatalf("wanted %q, got %q", d.exp, libc)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/aquaproj/aqua/v2/pkg/runtime"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNew_libc(t *testing.T) {
+	t.Setenv("AQUA_GOOS", "linux")
+	t.Setenv("AQUA_LIBC", "musl")
+	if rt := runtime.New(); rt.Libc != "musl" {
+		t.Fatalf("wanted musl, got %q", rt.Libc)
+	}
+	t.Setenv("AQUA_GOOS", "darwin")
+	if rt := runtime.New(); rt.Libc != "" {
+		t.Fatalf("libc must be empty on darwin, got %q", rt.Libc)
+	}
+}
+
+func TestRuntime_Validate(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		libc  string
+		isErr bool
+	}{
+		{libc: ""},
+		{libc: "glibc"},
+		{libc: "musl"},
+		{libc: "gnu", isErr: true},
+	}
+	for _, d := range data {
+		t.Run(d.libc, func(t *testing.T) {
+			t.Parallel()
+			rt := &runtime.Runtime{
+				GOOS:   "linux",
+				GOARCH: "amd64",
+				Libc:   d.libc,
+			}
+			err := rt.Validate()
+			if d.isErr {
+				if err == nil {
+					t.Fatal("error must be returned")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestExpandLibc(t *testing.T) {
+	t.Parallel()
+	rts := runtime.ExpandLibc([]*runtime.Runtime{
+		{
+			GOOS:   "darwin",
+			GOARCH: "arm64",
+		},
+		{
+			GOOS:   "linux",
+			GOARCH: "amd64",
+		},
+		{
+			GOOS:   "linux",
+			GOARCH: "arm64",
+			Libc:   "musl",
+		},
+	})
+	envs := make([]string, len(rts))
+	for i, rt := range rts {
+		envs[i] = rt.LibcEnv()
+	}
+	exp := []string{
+		"darwin/arm64",
+		"linux/amd64/glibc",
+		"linux/amd64/musl",
+		"linux/arm64/musl",
+	}
+	if diff := cmp.Diff(exp, envs); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -17,12 +17,17 @@ const (
 type Runtime struct {
 	GOOS   string
 	GOARCH string
+	// Libc is the C standard library of Linux, which is either "glibc" or "musl".
+	// Libc is empty on other platforms or if it's unknown.
+	Libc string
 }
 
 func New() *Runtime {
+	o := goos()
 	return &Runtime{
-		GOOS:   goos(),
+		GOOS:   o,
 		GOARCH: goarch(),
+		Libc:   libc(o),
 	}
 }
 
@@ -30,6 +35,7 @@ func NewR() *Runtime {
 	return &Runtime{
 		GOOS:   runtime.GOOS,
 		GOARCH: runtime.GOARCH,
+		Libc:   detectLibc(),
 	}
 }
 
@@ -41,6 +47,15 @@ func (rt *Runtime) Env() string {
 	return fmt.Sprintf("%s/%s", rt.GOOS, rt.GOARCH)
 }
 
+// LibcEnv returns Env with libc such as "linux/amd64/musl".
+// If libc is empty, LibcEnv returns Env.
+func (rt *Runtime) LibcEnv() string {
+	if rt.Libc == "" {
+		return rt.Env()
+	}
+	return rt.Env() + "/" + rt.Libc
+}
+
 func (rt *Runtime) Arch(rosetta2, windowsARMEmulation bool) string {
 	if rt.GOARCH == amd64 {
 		return amd64
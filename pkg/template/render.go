@@ -23,6 +23,7 @@ func renderParam(art *Artifact, rt *runtime.Runtime) map[string]any {
 		"SemVer":          art.SemVer,
 		"GOOS":            rt.GOOS,
 		"GOARCH":          rt.GOARCH,
+		"Libc":            rt.Libc,
 		"OS":              art.OS,
 		"Arch":            art.Arch,
 		"Format":          art.Format,
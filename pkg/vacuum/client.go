@@ -2,13 +2,12 @@ package vacuum
 
 import (
 	"fmt"
-	"io/fs"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aquaproj/aqua/v2/pkg/config"
-	"github.com/aquaproj/aqua/v2/pkg/osfile"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 	"github.com/suzuki-shunsuke/logrus-error/logerr"
@@ -17,7 +16,11 @@ import (
 const (
 	filePermission = 0o644
 	fileName       = "timestamp.txt"
+	indexFileName  = "timestamps.json"
 	baseDir        = "metadata"
+	// updateInterval is the minimum interval to update a package's timestamp.
+	// Timestamps are used to remove packages unused for days, so they don't have to be precise.
+	updateInterval = time.Hour
 )
 
 func FormatTime(t time.Time) string {
@@ -28,92 +31,169 @@ func ParseTime(s string) (time.Time, error) {
 	return time.Parse(time.RFC3339, s) //nolint:wrapcheck
 }
 
+// Client manages packages' last used date times.
+// Date times are stored in a single index file $AQUA_ROOT_DIR/metadata/timestamps.json.
+// The index file is protected by a lock file, so it can be updated by multiple aqua processes concurrently.
+// Timestamp files created by old aqua versions ($AQUA_ROOT_DIR/metadata/pkgs/**/timestamp.txt) are migrated to the index file automatically.
 type Client struct {
 	fs      afero.Fs
 	rootDir string
+	mutex   *sync.Mutex
 }
 
 func New(fs afero.Fs, param *config.Param) *Client {
 	return &Client{
 		fs:      fs,
 		rootDir: filepath.Join(param.RootDir, baseDir),
+		mutex:   &sync.Mutex{},
 	}
 }
 
-func (c *Client) Remove(pkgPath string) error {
-	file := c.file(pkgPath)
-	if err := c.fs.Remove(file); err != nil {
-		return fmt.Errorf("reamove a package timestamp file: %w", err)
+// Remove removes packages' timestamps from the index.
+func (c *Client) Remove(pkgPaths ...string) error {
+	if err := c.modify(func(idx *index) bool {
+		changed := false
+		for _, pkgPath := range pkgPaths {
+			if _, ok := idx.Packages[pkgPath]; ok {
+				delete(idx.Packages, pkgPath)
+				changed = true
+			}
+		}
+		return changed
+	}); err != nil {
+		return fmt.Errorf("remove package timestamps: %w", err)
 	}
 	return nil
 }
 
-func (c *Client) Update(pkgPath string, timestamp time.Time) error {
-	dir := c.dir(pkgPath)
-	file := filepath.Join(dir, fileName)
-	return c.update(file, dir, timestamp)
+// RemoveMatched removes timestamps of packages whose paths match a glob pattern.
+// The pattern is matched per path segment by filepath.Match,
+// and a path also matches if the pattern matches its leading segments.
+// For example, "pkgs/github_release/github.com/cli/cli" matches "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip".
+func (c *Client) RemoveMatched(pattern string) error {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return fmt.Errorf("parse a package path pattern: %w", err)
+	}
+	if err := c.modify(func(idx *index) bool {
+		changed := false
+		for pkgPath := range idx.Packages {
+			if matchPkgPath(pattern, pkgPath) {
+				delete(idx.Packages, pkgPath)
+				changed = true
+			}
+		}
+		return changed
+	}); err != nil {
+		return fmt.Errorf("remove package timestamps: %w", err)
+	}
+	return nil
 }
 
-func (c *Client) Create(pkgPath string, timestamp time.Time) error {
-	dir := c.dir(pkgPath)
-	file := filepath.Join(dir, fileName)
-	if f, err := afero.Exists(c.fs, file); err != nil {
-		return fmt.Errorf("check whether a package timestamp file exists: %w", err)
-	} else if f {
+// matchPkgPath returns true if the package path equals the pattern or is under a directory matching the pattern.
+func matchPkgPath(pattern, pkgPath string) bool {
+	patterns := strings.Split(filepath.ToSlash(pattern), "/")
+	segments := strings.Split(filepath.ToSlash(pkgPath), "/")
+	if len(segments) < len(patterns) {
+		return false
+	}
+	for i, p := range patterns {
+		if f, _ := filepath.Match(p, segments[i]); !f {
+			return false
+		}
+	}
+	return true
+}
+
+// Update records the timestamp as the package's last used date time.
+// Update is called whenever a package is executed, so writes are debounced.
+// If the recorded timestamp is newer than updateInterval before the timestamp, the index is neither locked nor written.
+func (c *Client) Update(pkgPath string, timestamp time.Time) error {
+	if idx, err := c.read(); err == nil && isRecent(idx, pkgPath, timestamp) {
 		return nil
 	}
-	return c.update(file, dir, timestamp)
+	if err := c.modify(func(idx *index) bool {
+		if isRecent(idx, pkgPath, timestamp) {
+			return false
+		}
+		idx.Packages[pkgPath] = FormatTime(timestamp)
+		return true
+	}); err != nil {
+		return fmt.Errorf("update a package timestamp: %w", err)
+	}
+	return nil
 }
 
+// isRecent returns true if the recorded timestamp of the package is newer than updateInterval before the timestamp.
+func isRecent(idx *index, pkgPath string, timestamp time.Time) bool {
+	s, ok := idx.Packages[pkgPath]
+	if !ok {
+		return false
+	}
+	t, err := ParseTime(s)
+	if err != nil {
+		return false
+	}
+	return timestamp.Sub(t) < updateInterval
+}
+
+// Create records the timestamp as packages' last used date time if their timestamps aren't recorded yet.
+// All packages are written at once.
+func (c *Client) Create(pkgPaths []string, timestamp time.Time) error {
+	ts := FormatTime(timestamp)
+	if err := c.modify(func(idx *index) bool {
+		changed := false
+		for _, pkgPath := range pkgPaths {
+			if _, ok := idx.Packages[pkgPath]; ok {
+				continue
+			}
+			idx.Packages[pkgPath] = ts
+			changed = true
+		}
+		return changed
+	}); err != nil {
+		return fmt.Errorf("create package timestamps: %w", err)
+	}
+	return nil
+}
+
+// FindAll returns timestamps of all packages.
+// Keys are package paths relative to $AQUA_ROOT_DIR.
 func (c *Client) FindAll(logE *logrus.Entry) (map[string]time.Time, error) {
 	timestamps := map[string]time.Time{}
-	if err := afero.Walk(c.fs, filepath.Join(c.rootDir, "pkgs"), func(path string, info fs.FileInfo, err error) error {
-		if err != nil {
-			return fmt.Errorf("walk directory to find timestamp files: %w", err)
-		}
-		name := info.Name()
-		if name != fileName {
-			return nil
-		}
-		b, err := afero.ReadFile(c.fs, path)
-		if err != nil {
-			return fmt.Errorf("read a timestamp file: %w", err)
-		}
-		t, err := ParseTime(strings.TrimSpace(string(b)))
-		if err != nil {
-			logerr.WithError(logE, err).WithField("timestamp_file", path).Warn("a timestamp file is broken, so recreating it")
-			if err := c.Update(path, time.Now()); err != nil {
-				return fmt.Errorf("recreate a broken package timestamp file: %w", err)
+	brokenPaths := []string{}
+	if err := c.modify(func(idx *index) bool {
+		for pkgPath, s := range idx.Packages {
+			t, err := ParseTime(s)
+			if err != nil {
+				logerr.WithError(logE, err).WithField("package_path", pkgPath).Warn("a timestamp is broken, so recreating it")
+				brokenPaths = append(brokenPaths, pkgPath)
+				continue
 			}
-			return nil
+			timestamps[pkgPath] = t
 		}
-		rel, err := filepath.Rel(c.rootDir, filepath.Dir(path))
-		if err != nil {
-			return fmt.Errorf("get a relative file path: %w", err)
+		if len(brokenPaths) == 0 {
+			return false
 		}
-		timestamps[rel] = t
-		return nil
+		now := time.Now()
+		for _, pkgPath := range brokenPaths {
+			idx.Packages[pkgPath] = FormatTime(now)
+			timestamps[pkgPath] = now
+		}
+		return true
 	}); err != nil {
-		return nil, fmt.Errorf("find timestamp files: %w", err)
+		return nil, fmt.Errorf("find package timestamps: %w", err)
 	}
 	return timestamps, nil
 }
 
-func (c *Client) update(file, dir string, timestamp time.Time) error {
-	if err := osfile.MkdirAll(c.fs, dir); err != nil {
-		return fmt.Errorf("create a package metadata directory: %w", err)
-	}
-	timestampStr := FormatTime(timestamp)
-	if err := afero.WriteFile(c.fs, file, []byte(timestampStr+"\n"), filePermission); err != nil {
-		return fmt.Errorf("create a package timestamp file: %w", err)
-	}
-	return nil
+func (c *Client) indexFile() string {
+	return filepath.Join(c.rootDir, indexFileName)
 }
 
-func (c *Client) dir(pkgPath string) string {
-	return filepath.Join(c.rootDir, pkgPath)
+func (c *Client) lockFile() string {
+	return filepath.Join(c.rootDir, lockFileName)
 }
 
-func (c *Client) file(pkgPath string) string {
-	return filepath.Join(c.dir(pkgPath), fileName)
+func (c *Client) legacyDir() string {
+	return filepath.Join(c.rootDir, "pkgs")
 }
@@ -4,6 +4,7 @@ import (
 	"path"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/aquaproj/aqua/v2/pkg/config"
 	"github.com/aquaproj/aqua/v2/pkg/osfile"
@@ -13,48 +14,67 @@ import (
 	"github.com/spf13/afero"
 )
 
-const rootDir = "/home/foo/.local/share/aquaproj-aqua"
+const (
+	rootDir   = "/home/foo/.local/share/aquaproj-aqua"
+	pkgPath   = "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip"
+	pkgPath2  = "pkgs/github_release/github.com/cli/cli/v2.60.0/gh_2.60.0_macOS_arm64.zip"
+	indexFile = rootDir + "/metadata/timestamps.json"
+	lockFile  = rootDir + "/metadata/timestamps.lock"
+)
+
+func writeFiles(t *testing.T, fs afero.Fs, files map[string]string) {
+	t.Helper()
+	for k, v := range files {
+		if err := osfile.MkdirAll(fs, filepath.Dir(k)); err != nil {
+			t.Fatal(err)
+		}
+		if err := afero.WriteFile(fs, k, []byte(v), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
 
 func TestClient_Create(t *testing.T) { //nolint:dupl
 	t.Parallel()
 	data := []struct {
 		name       string
-		pkgPath    string
+		pkgPaths   []string
 		timestamp  string
 		files      map[string]string
-		expPath    string
 		expContent string
 	}{
 		{
-			name:       "create",
-			pkgPath:    "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip",
-			timestamp:  "2025-01-10T00:15:00+09:00",
-			expPath:    path.Join(rootDir, "metadata", "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip", "timestamp.txt"),
-			expContent: "2025-01-10T00:15:00+09:00\n",
+			name:      "create",
+			pkgPaths:  []string{pkgPath},
+			timestamp: "2025-01-10T00:15:00+09:00",
+			expContent: `{
+  "packages": {
+    "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip": "2025-01-10T00:15:00+09:00"
+  }
+}
+`,
 		},
 		{
-			name:      "file exists",
-			pkgPath:   "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip",
+			name:      "timestamp exists",
+			pkgPaths:  []string{pkgPath, pkgPath2},
 			timestamp: "2025-01-10T00:15:00+09:00",
 			files: map[string]string{
-				path.Join(rootDir, "metadata", "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip", "timestamp.txt"): "2025-01-01T00:15:00+09:00\n",
+				indexFile: `{"packages": {"pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip": "2025-01-01T00:15:00+09:00"}}`,
 			},
-			expPath:    path.Join(rootDir, "metadata", "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip", "timestamp.txt"),
-			expContent: "2025-01-01T00:15:00+09:00\n",
+			expContent: `{
+  "packages": {
+    "pkgs/github_release/github.com/cli/cli/v2.60.0/gh_2.60.0_macOS_arm64.zip": "2025-01-10T00:15:00+09:00",
+    "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip": "2025-01-01T00:15:00+09:00"
+  }
+}
+`,
 		},
 	}
 	for _, tt := range data {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			fs := afero.NewMemMapFs()
-			for k, v := range tt.files {
-				if err := osfile.MkdirAll(fs, filepath.Dir(k)); err != nil {
-					t.Fatal(err)
-				}
-				if err := afero.WriteFile(fs, k, []byte(v), 0o644); err != nil {
-					t.Fatal(err)
-				}
-			}
+			writeFiles(t, fs, tt.files)
 			client := vacuum.New(fs, &config.Param{
 				RootDir: rootDir,
 			})
@@ -62,10 +82,10 @@ func TestClient_Create(t *testing.T) { //nolint:dupl
 			if err != nil {
 				t.Fatal(err)
 			}
-			if err := client.Create(tt.pkgPath, ts); err != nil {
+			if err := client.Create(tt.pkgPaths, ts); err != nil {
 				t.Fatal(err)
 			}
-			b, err := afero.ReadFile(fs, tt.expPath)
+			b, err := afero.ReadFile(fs, indexFile)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -83,36 +103,81 @@ func TestClient_Update(t *testing.T) { //nolint:dupl
 		pkgPath    string
 		timestamp  string
 		files      map[string]string
-		expPath    string
+		staleLock  bool
 		expContent string
 	}{
 		{
-			name:       "create",
-			pkgPath:    "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip",
-			timestamp:  "2025-01-10T00:15:00+09:00",
-			expPath:    path.Join(rootDir, "metadata", "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip", "timestamp.txt"),
-			expContent: "2025-01-10T00:15:00+09:00\n",
+			name:      "create",
+			pkgPath:   pkgPath,
+			timestamp: "2025-01-10T00:15:00+09:00",
+			expContent: `{
+  "packages": {
+    "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip": "2025-01-10T00:15:00+09:00"
+  }
+}
+`,
 		},
 		{
-			name:      "file exists (overwrite)",
-			pkgPath:   "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip",
+			name:      "recent timestamp isn't updated",
+			pkgPath:   pkgPath,
 			timestamp: "2025-01-10T00:15:00+09:00",
 			files: map[string]string{
-				path.Join(rootDir, "metadata", "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip", "timestamp.txt"): "2025-01-01T00:15:00+09:00\n",
+				indexFile: `{"packages": {"pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip": "2025-01-10T00:00:00+09:00"}}`,
 			},
-			expPath:    path.Join(rootDir, "metadata", "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip", "timestamp.txt"),
-			expContent: "2025-01-10T00:15:00+09:00\n",
+			expContent: `{"packages": {"pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip": "2025-01-10T00:00:00+09:00"}}`,
+		},
+		{
+			name:      "stale lock file",
+			pkgPath:   pkgPath,
+			timestamp: "2025-01-10T00:15:00+09:00",
+			files: map[string]string{
+				lockFile: "",
+			},
+			staleLock: true,
+			expContent: `{
+  "packages": {
+    "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip": "2025-01-10T00:15:00+09:00"
+  }
+}
+`,
+		},
+		{
+			name:      "timestamp exists (overwrite)",
+			pkgPath:   pkgPath,
+			timestamp: "2025-01-10T00:15:00+09:00",
+			files: map[string]string{
+				indexFile: `{"packages": {"pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip": "2025-01-01T00:15:00+09:00"}}`,
+			},
+			expContent: `{
+  "packages": {
+    "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip": "2025-01-10T00:15:00+09:00"
+  }
+}
+`,
+		},
+		{
+			name:      "broken index",
+			pkgPath:   pkgPath,
+			timestamp: "2025-01-10T00:15:00+09:00",
+			files: map[string]string{
+				indexFile: `{"packages": `,
+			},
+			expContent: `{
+  "packages": {
+    "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip": "2025-01-10T00:15:00+09:00"
+  }
+}
+`,
 		},
 	}
 	for _, tt := range data {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			fs := afero.NewMemMapFs()
-			for k, v := range tt.files {
-				if err := osfile.MkdirAll(fs, filepath.Dir(k)); err != nil {
-					t.Fatal(err)
-				}
-				if err := afero.WriteFile(fs, k, []byte(v), 0o644); err != nil {
+			writeFiles(t, fs, tt.files)
+			if tt.staleLock {
+				old := time.Now().Add(-time.Minute)
+				if err := fs.Chtimes(lockFile, old, old); err != nil {
 					t.Fatal(err)
 				}
 			}
@@ -126,13 +191,18 @@ func TestClient_Update(t *testing.T) { //nolint:dupl
 			if err := client.Update(tt.pkgPath, ts); err != nil {
 				t.Fatal(err)
 			}
-			b, err := afero.ReadFile(fs, tt.expPath)
+			b, err := afero.ReadFile(fs, indexFile)
 			if err != nil {
 				t.Fatal(err)
 			}
 			if diff := cmp.Diff(tt.expContent, string(b)); diff != "" {
 				t.Fatal(diff)
 			}
+			if f, err := afero.Exists(fs, lockFile); err != nil {
+				t.Fatal(err)
+			} else if f {
+				t.Fatal("the lock file isn't removed")
+			}
 		})
 	}
 }
@@ -140,42 +210,47 @@ func TestClient_Update(t *testing.T) { //nolint:dupl
 func TestClient_Remove(t *testing.T) {
 	t.Parallel()
 	data := []struct {
-		name    string
-		pkgPath string
-		files   map[string]string
-		expPath string
+		name     string
+		pkgPaths []string
+		files    map[string]string
+		exp      map[string]string
 	}{
 		{
-			name:    "remove",
-			pkgPath: "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip",
+			name:     "remove",
+			pkgPaths: []string{pkgPath},
 			files: map[string]string{
-				path.Join(rootDir, "metadata", "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip", "timestamp.txt"): "2025-01-01T00:15:00+09:00\n",
+				indexFile: `{"packages": {
+  "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip": "2025-01-01T00:15:00+09:00",
+  "pkgs/github_release/github.com/cli/cli/v2.60.0/gh_2.60.0_macOS_arm64.zip": "2025-01-20T00:15:00+09:00"
+}}`,
+			},
+			exp: map[string]string{
+				pkgPath2: "2025-01-20T00:15:00+09:00",
 			},
-			expPath: path.Join(rootDir, "metadata", "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip", "timestamp.txt"),
 		},
 	}
+	logE := logrus.NewEntry(logrus.New())
 	for _, tt := range data {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			fs := afero.NewMemMapFs()
-			for k, v := range tt.files {
-				if err := osfile.MkdirAll(fs, filepath.Dir(k)); err != nil {
-					t.Fatal(err)
-				}
-				if err := afero.WriteFile(fs, k, []byte(v), 0o644); err != nil {
-					t.Fatal(err)
-				}
-			}
+			writeFiles(t, fs, tt.files)
 			client := vacuum.New(fs, &config.Param{
 				RootDir: rootDir,
 			})
-			if err := client.Remove(tt.pkgPath); err != nil {
+			if err := client.Remove(tt.pkgPaths...); err != nil {
 				t.Fatal(err)
 			}
-			if a, err := afero.Exists(fs, tt.expPath); err != nil {
+			timestamps, err := client.FindAll(logE)
+			if err != nil {
 				t.Fatal(err)
-			} else if a {
-				t.Fatal("the file still exists")
+			}
+			a := make(map[string]string, len(timestamps))
+			for k, v := range timestamps {
+				a[k] = vacuum.FormatTime(v)
+			}
+			if diff := cmp.Diff(tt.exp, a); diff != "" {
+				t.Fatal(diff)
 			}
 		})
 	}
@@ -184,19 +259,48 @@ func TestClient_Remove(t *testing.T) {
 func TestClient_FindAll(t *testing.T) {
 	t.Parallel()
 	data := []struct {
-		name  string
-		files map[string]string
-		exp   map[string]string
+		name           string
+		files          map[string]string
+		exp            map[string]string
+		expLegacyFiles bool
 	}{
 		{
 			name: "normal",
 			files: map[string]string{
-				path.Join(rootDir, "metadata", "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip", "timestamp.txt"): "2025-01-01T00:15:00+09:00\n",
-				path.Join(rootDir, "metadata", "pkgs/github_release/github.com/cli/cli/v2.60.0/gh_2.60.0_macOS_arm64.zip", "timestamp.txt"): "2025-01-20T00:15:00+09:00\n",
+				indexFile: `{"packages": {
+  "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip": "2025-01-01T00:15:00+09:00",
+  "pkgs/github_release/github.com/cli/cli/v2.60.0/gh_2.60.0_macOS_arm64.zip": "2025-01-20T00:15:00+09:00"
+}}`,
+			},
+			exp: map[string]string{
+				pkgPath:  "2025-01-01T00:15:00+09:00",
+				pkgPath2: "2025-01-20T00:15:00+09:00",
+			},
+		},
+		{
+			name: "migrate timestamp files",
+			files: map[string]string{
+				path.Join(rootDir, "metadata", pkgPath, "timestamp.txt"):  "2025-01-01T00:15:00+09:00\n",
+				path.Join(rootDir, "metadata", pkgPath2, "timestamp.txt"): "2025-01-20T00:15:00+09:00\n",
+			},
+			exp: map[string]string{
+				pkgPath:  "2025-01-01T00:15:00+09:00",
+				pkgPath2: "2025-01-20T00:15:00+09:00",
+			},
+		},
+		{
+			name: "merge timestamp files into the index",
+			files: map[string]string{
+				indexFile: `{"packages": {
+  "pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip": "2025-01-05T00:15:00+09:00",
+  "pkgs/github_release/github.com/cli/cli/v2.60.0/gh_2.60.0_macOS_arm64.zip": "2025-01-05T00:15:00+09:00"
+}}`,
+				path.Join(rootDir, "metadata", pkgPath, "timestamp.txt"):  "2025-01-01T00:15:00+09:00\n",
+				path.Join(rootDir, "metadata", pkgPath2, "timestamp.txt"): "2025-01-20T00:15:00+09:00\n",
 			},
 			exp: map[string]string{
-				"pkgs/github_release/github.com/cli/cli/v2.65.0/gh_2.65.0_macOS_arm64.zip": "2025-01-01T00:15:00+09:00",
-				"pkgs/github_release/github.com/cli/cli/v2.60.0/gh_2.60.0_macOS_arm64.zip": "2025-01-20T00:15:00+09:00",
+				pkgPath:  "2025-01-05T00:15:00+09:00",
+				pkgPath2: "2025-01-20T00:15:00+09:00",
 			},
 		},
 	}
@@ -205,14 +309,7 @@ func TestClient_FindAll(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			fs := afero.NewMemMapFs()
-			for k, v := range tt.files {
-				if err := osfile.MkdirAll(fs, filepath.Dir(k)); err != nil {
-					t.Fatal(err)
-				}
-				if err := afero.WriteFile(fs, k, []byte(v), 0o644); err != nil {
-					t.Fatal(err)
-				}
-			}
+			writeFiles(t, fs, tt.files)
 			client := vacuum.New(fs, &config.Param{
 				RootDir: rootDir,
 			})
@@ -227,6 +324,11 @@ func TestClient_FindAll(t *testing.T) {
 			if diff := cmp.Diff(tt.exp, a); diff != "" {
 				t.Fatal(diff)
 			}
+			if f, err := afero.DirExists(fs, path.Join(rootDir, "metadata", "pkgs")); err != nil {
+				t.Fatal(err)
+			} else if f {
+				t.Fatal("old timestamp files aren't removed")
+			}
 		})
 	}
 }
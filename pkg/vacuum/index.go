@@ -0,0 +1,140 @@
+package vacuum
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/osfile"
+	"github.com/spf13/afero"
+)
+
+type index struct {
+	// Packages is a map of package paths and their last used date times in RFC3339.
+	Packages map[string]string `json:"packages"`
+}
+
+// modify reads the index, calls the function, and writes the index if the function returns true.
+// The whole operation is protected by both a mutex and a lock file,
+// so concurrent goroutines and processes don't lose each other's updates.
+func (c *Client) modify(fn func(idx *index) bool) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := osfile.MkdirAll(c.fs, c.rootDir); err != nil {
+		return fmt.Errorf("create a package metadata directory: %w", err)
+	}
+	unlock, err := c.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	idx, err := c.read()
+	if err != nil {
+		return err
+	}
+	migrated, err := c.migrate(idx)
+	if err != nil {
+		return err
+	}
+	changed := fn(idx)
+	if changed || migrated {
+		if err := c.write(idx); err != nil {
+			return err
+		}
+	}
+	if migrated {
+		if err := c.fs.RemoveAll(c.legacyDir()); err != nil {
+			return fmt.Errorf("remove old timestamp files: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *Client) read() (*index, error) {
+	idx := &index{}
+	b, err := afero.ReadFile(c.fs, c.indexFile())
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			idx.Packages = map[string]string{}
+			return idx, nil
+		}
+		return nil, fmt.Errorf("read a timestamp index file: %w", err)
+	}
+	if err := json.Unmarshal(b, idx); err != nil {
+		// The index file is written atomically, so it would be broken only if it's edited manually.
+		// Timestamps are recorded again when packages are used, so it's reset.
+		idx.Packages = nil
+	}
+	if idx.Packages == nil {
+		idx.Packages = map[string]string{}
+	}
+	return idx, nil
+}
+
+// write writes the index to a temporary file and renames it to the index file,
+// so readers never see a partially written index.
+func (c *Client) write(idx *index) error {
+	b, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode a timestamp index as JSON: %w", err)
+	}
+	file := c.indexFile()
+	tempFile := file + ".tmp." + strconv.Itoa(os.Getpid())
+	if err := afero.WriteFile(c.fs, tempFile, append(b, '\n'), filePermission); err != nil {
+		return fmt.Errorf("write a timestamp index file: %w", err)
+	}
+	if err := c.fs.Rename(tempFile, file); err != nil {
+		return fmt.Errorf("rename a timestamp index file: %w", err)
+	}
+	return nil
+}
+
+// migrate imports timestamp files created by old aqua versions into the index.
+// Timestamps in the index take precedence over older ones.
+// It returns true if any timestamp file is found.
+func (c *Client) migrate(idx *index) (bool, error) {
+	legacyDir := c.legacyDir()
+	if f, err := afero.DirExists(c.fs, legacyDir); err != nil {
+		return false, fmt.Errorf("check if old timestamp files exist: %w", err)
+	} else if !f {
+		return false, nil
+	}
+	now := time.Now()
+	if err := afero.Walk(c.fs, legacyDir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return fmt.Errorf("walk directory to find timestamp files: %w", err)
+		}
+		if info.IsDir() || info.Name() != fileName {
+			return nil
+		}
+		rel, err := filepath.Rel(c.rootDir, filepath.Dir(path))
+		if err != nil {
+			return fmt.Errorf("get a relative file path: %w", err)
+		}
+		b, err := afero.ReadFile(c.fs, path)
+		if err != nil {
+			return fmt.Errorf("read a timestamp file: %w", err)
+		}
+		t, err := ParseTime(strings.TrimSpace(string(b)))
+		if err != nil {
+			t = now
+		}
+		if s, ok := idx.Packages[rel]; ok {
+			if old, err := ParseTime(s); err == nil && !old.Before(t) {
+				return nil
+			}
+		}
+		idx.Packages[rel] = FormatTime(t)
+		return nil
+	}); err != nil {
+		return false, fmt.Errorf("find timestamp files: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,81 @@
+package vacuum
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	lockFileName = "timestamps.lock"
+	// lockRetryInterval is the interval to check if the lock file is released.
+	lockRetryInterval = 10 * time.Millisecond
+	// lockTimeout is the maximum duration to wait for the lock file.
+	lockTimeout = 10 * time.Second
+	// staleLockAge is the age of the lock file to regard it as stale.
+	// A lock file would be left if a process is killed while holding it.
+	// The lock is held only while the index is read and written,
+	// and staleLockAge must be shorter than lockTimeout so that stale lock files are removed before timeout.
+	staleLockAge = 3 * time.Second
+)
+
+var errLockTimeout = errors.New("timed out waiting for the lock of the timestamp index")
+
+// lock creates a lock file exclusively and returns a function to release it.
+// A lock file is used instead of flock(2) because flock(2) isn't reliable on some network file systems such as NFS.
+func (c *Client) lock() (func(), error) {
+	lockFile := c.lockFile()
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := c.fs.OpenFile(lockFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, filePermission)
+		if err == nil {
+			if err := f.Close(); err != nil {
+				return nil, fmt.Errorf("close a lock file: %w", err)
+			}
+			return func() {
+				_ = c.fs.Remove(lockFile)
+			}, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("create a lock file: %w", err)
+		}
+		if c.removeStaleLock(lockFile) {
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, errLockTimeout
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// removeStaleLock removes the lock file if it's stale.
+// To avoid removing a lock file created by another process after the check,
+// the lock file is renamed atomically first, and then the renamed file is checked.
+// If the renamed file is fresh, it's restored.
+func (c *Client) removeStaleLock(lockFile string) bool {
+	finfo, err := c.fs.Stat(lockFile)
+	if err != nil {
+		// The lock file would be released
+		return errors.Is(err, fs.ErrNotExist)
+	}
+	if time.Since(finfo.ModTime()) < staleLockAge {
+		return false
+	}
+	staleFile := lockFile + ".stale." + strconv.Itoa(os.Getpid())
+	if err := c.fs.Rename(lockFile, staleFile); err != nil {
+		// Another process would remove or rename the lock file
+		return errors.Is(err, fs.ErrNotExist)
+	}
+	finfo, err = c.fs.Stat(staleFile)
+	if err == nil && time.Since(finfo.ModTime()) < staleLockAge {
+		// The lock file was released and created again after the check
+		_ = c.fs.Rename(staleFile, lockFile)
+		return false
+	}
+	_ = c.fs.Remove(staleFile)
+	return true
+}
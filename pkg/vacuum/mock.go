@@ -2,6 +2,8 @@ package vacuum
 
 import (
 	"time"
+
+	"github.com/sirupsen/logrus"
 )
 
 type Mock struct {
@@ -18,7 +20,11 @@ func NewMock(rootDir string, timestamps map[string]time.Time, err error) *Mock {
 	}
 }
 
-func (m *Mock) Remove(pkgPath string) error {
+func (m *Mock) Remove(pkgPaths ...string) error {
+	return m.err
+}
+
+func (m *Mock) RemoveMatched(pattern string) error {
 	return m.err
 }
 
@@ -26,6 +32,10 @@ func (m *Mock) Update(pkgPath string, timestamp time.Time) error {
 	return m.err
 }
 
-func (m *Mock) FindAll() (map[string]time.Time, error) {
+func (m *Mock) Create(pkgPaths []string, timestamp time.Time) error {
+	return m.err
+}
+
+func (m *Mock) FindAll(logE *logrus.Entry) (map[string]time.Time, error) {
 	return m.timestamps, m.err
 }
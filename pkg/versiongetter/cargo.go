@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aquaproj/aqua/v2/pkg/cargo"
 	"github.com/aquaproj/aqua/v2/pkg/config/registry"
 	"github.com/aquaproj/aqua/v2/pkg/fuzzyfinder"
 	"github.com/sirupsen/logrus"
@@ -11,6 +12,7 @@ import (
 
 type CargoClient interface {
 	ListVersions(ctx context.Context, crate string) ([]string, error)
+	ListVersionInfos(ctx context.Context, crate string) ([]*cargo.PayloadVersion, error)
 	GetLatestVersion(ctx context.Context, crate string) (string, error)
 }
 
@@ -24,8 +26,21 @@ func NewCargo(client CargoClient) *CargoVersionGetter {
 	}
 }
 
-func (c *CargoVersionGetter) Get(ctx context.Context, _ *logrus.Entry, pkg *registry.PackageInfo, _ []*Filter) (string, error) {
-	return c.client.GetLatestVersion(ctx, pkg.Crate) //nolint:wrapcheck
+func (c *CargoVersionGetter) Get(ctx context.Context, _ *logrus.Entry, pkg *registry.PackageInfo, filters []*Filter) (string, error) {
+	rule := getUpdateRule(filters)
+	if rule == nil {
+		return c.client.GetLatestVersion(ctx, pkg.Crate) //nolint:wrapcheck
+	}
+	versions, err := c.client.ListVersionInfos(ctx, pkg.Crate)
+	if err != nil {
+		return "", fmt.Errorf("list versions of the crate: %w", err)
+	}
+	for _, v := range versions {
+		if rule.Match(v.Num) && rule.MatchTime(v.CreatedAt) {
+			return v.Num, nil
+		}
+	}
+	return "", nil
 }
 
 func (c *CargoVersionGetter) List(ctx context.Context, _ *logrus.Entry, pkg *registry.PackageInfo, _ []*Filter, _ int) ([]*fuzzyfinder.Item, error) {
@@ -13,9 +13,13 @@ type Filter struct {
 	Filter     *vm.Program
 	Constraint string
 	NoAsset    bool
+	// UpdateRule is the rule of the field `update` of aqua.yaml.
+	// It's nil if the rule isn't set.
+	UpdateRule *UpdateRule
 }
 
-func createFilters(pkgInfo *registry.PackageInfo) ([]*Filter, error) {
+// CreateFilters creates filters from version_prefix, version_filter, version_constraint, and version_overrides of the package.
+func CreateFilters(pkgInfo *registry.PackageInfo) ([]*Filter, error) {
 	filters := make([]*Filter, 0, 1+len(pkgInfo.VersionOverrides))
 	topFilter := &Filter{
 		NoAsset:    pkgInfo.NoAsset || pkgInfo.ErrorMessage != "",
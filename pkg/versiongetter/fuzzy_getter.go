@@ -28,8 +28,11 @@ type FuzzyFinder interface {
 	FindMulti(items []*fuzzyfinder.Item, hasPreview bool) ([]int, error)
 }
 
-func (g *FuzzyGetter) Get(ctx context.Context, logE *logrus.Entry, pkg *registry.PackageInfo, currentVersion string, useFinder bool, limit int) string { //nolint:cyclop
-	filters, err := createFilters(pkg)
+// Get returns the latest version of the package.
+// If useFinder is true, users select the version with the fuzzy finder.
+// rule is applied only if useFinder is false because users can select any versions explicitly.
+func (g *FuzzyGetter) Get(ctx context.Context, logE *logrus.Entry, pkg *registry.PackageInfo, currentVersion string, useFinder bool, limit int, rule *UpdateRule) string { //nolint:cyclop
+	filters, err := CreateFilters(pkg)
 	if err != nil {
 		logerr.WithError(logE, err).Warn("create filters")
 		return ""
@@ -70,6 +73,7 @@ func (g *FuzzyGetter) Get(ctx context.Context, logE *logrus.Entry, pkg *registry
 		return versions[idx].Item
 	}
 
+	SetUpdateRule(filters, rule)
 	start := time.Now()
 	version, err := g.getter.Get(ctx, logE, pkg, filters)
 	logE.Debug("retrieve package versions in ", time.Since(start))
@@ -74,7 +74,7 @@ func TestFuzzyGetter_Get(t *testing.T) { //nolint:funlen
 			finder := fuzzyfinder.NewMock(d.idxs, nil)
 			vg := versiongetter.NewMockVersionGetter(d.versions)
 			fg := versiongetter.NewFuzzy(finder, vg)
-			version := fg.Get(t.Context(), logE, d.pkg, d.currentVersion, d.useFinder, -1)
+			version := fg.Get(t.Context(), logE, d.pkg, d.currentVersion, d.useFinder, -1, nil)
 			if version != d.version {
 				t.Fatalf("wanted %s, got %s", d.version, version)
 			}
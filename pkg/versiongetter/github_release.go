@@ -170,7 +170,7 @@ func filterRelease(release *github.RepositoryRelease, filters []*Filter) bool {
 
 	for _, filter := range filters {
 		if matchTagByFilter(tagName, filter) {
-			return !filter.NoAsset
+			return !filter.NoAsset && filter.UpdateRule.Match(tagName) && filter.UpdateRule.MatchTime(release.GetPublishedAt().Time)
 		}
 	}
 	return false
@@ -3,6 +3,7 @@ package versiongetter
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/aquaproj/aqua/v2/pkg/config/registry"
 	"github.com/aquaproj/aqua/v2/pkg/fuzzyfinder"
@@ -22,6 +23,7 @@ func NewGitHubTag(gh GitHubTagClient) *GitHubTagVersionGetter {
 
 type GitHubTagClient interface {
 	ListTags(ctx context.Context, owner string, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error)
+	GetCommit(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) (*github.RepositoryCommit, *github.Response, error)
 }
 
 func convTag(tag *github.RepositoryTag) *Release {
@@ -47,6 +49,7 @@ func (g *GitHubTagVersionGetter) Get(ctx context.Context, logE *logrus.Entry, pk
 	}()
 
 	candidates := []*Release{}
+	rule := getUpdateRule(filters)
 
 	for {
 		tags, resp, err := g.gh.ListTags(ctx, repoOwner, repoName, opt)
@@ -54,12 +57,23 @@ func (g *GitHubTagVersionGetter) Get(ctx context.Context, logE *logrus.Entry, pk
 		if err != nil {
 			return "", fmt.Errorf("list tags: %w", err)
 		}
+		shas := make(map[string]string, len(tags))
 		for _, tag := range tags {
 			if filterTag(tag, filters) {
 				candidates = append(candidates, convTag(tag))
+				shas[tag.GetName()] = tag.GetCommit().GetSHA()
 			}
 		}
-		if len(candidates) > 0 {
+		if rule.HasMinAge() {
+			tag, err := g.getLatestTagOlderThanMinAge(ctx, repoOwner, repoName, rule, candidates, shas)
+			if err != nil {
+				return "", err
+			}
+			if tag != "" {
+				return tag, nil
+			}
+			candidates = candidates[:0]
+		} else if len(candidates) > 0 {
 			return getLatestRelease(candidates).Tag, nil
 		}
 		if resp.NextPage == 0 {
@@ -104,11 +118,32 @@ func (g *GitHubTagVersionGetter) List(ctx context.Context, logE *logrus.Entry, p
 	}
 }
 
+// getLatestTagOlderThanMinAge returns the latest tag committed before min_age.
+// Tags don't have the published time, so the time of the tagged commit is used.
+// To reduce API calls, commits are got in the descending order of versions until a tag satisfying min_age is found.
+func (g *GitHubTagVersionGetter) getLatestTagOlderThanMinAge(ctx context.Context, repoOwner, repoName string, rule *UpdateRule, candidates []*Release, shas map[string]string) (string, error) {
+	candidates = slices.Clone(candidates)
+	for len(candidates) > 0 {
+		latest := getLatestRelease(candidates)
+		commit, _, err := g.gh.GetCommit(ctx, repoOwner, repoName, shas[latest.Tag], nil)
+		if err != nil {
+			return "", fmt.Errorf("get a commit of the tag: %w", err)
+		}
+		if rule.MatchTime(commit.GetCommit().GetCommitter().GetDate().Time) {
+			return latest.Tag, nil
+		}
+		candidates = slices.DeleteFunc(candidates, func(r *Release) bool {
+			return r == latest
+		})
+	}
+	return "", nil
+}
+
 func filterTag(tag *github.RepositoryTag, filters []*Filter) bool {
 	tagName := tag.GetName()
 	for _, filter := range filters {
 		if matchTagByFilter(tagName, filter) {
-			return !filter.NoAsset
+			return !filter.NoAsset && filter.UpdateRule.Match(tagName)
 		}
 	}
 	return false
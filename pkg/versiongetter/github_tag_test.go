@@ -2,6 +2,7 @@ package versiongetter_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/aquaproj/aqua/v2/pkg/config/registry"
 	"github.com/aquaproj/aqua/v2/pkg/fuzzyfinder"
@@ -17,8 +18,10 @@ func TestGitHubTagVersionGetter_Get(t *testing.T) { //nolint:dupl
 	data := []struct {
 		name    string
 		tags    map[string][]*github.RepositoryTag
+		commits map[string]*github.RepositoryCommit
 		pkg     *registry.PackageInfo
 		filters []*versiongetter.Filter
+		minAge  string
 		isErr   bool
 		version string
 	}{
@@ -46,13 +49,52 @@ func TestGitHubTagVersionGetter_Get(t *testing.T) { //nolint:dupl
 			},
 			version: "v3.0.0",
 		},
+		{
+			name: "min_age",
+			filters: []*versiongetter.Filter{
+				{},
+			},
+			minAge: "72h",
+			tags: map[string][]*github.RepositoryTag{
+				"suzuki-shunsuke/tfcmt": {
+					{
+						Name:   ptr.String("v3.0.0"),
+						Commit: &github.Commit{SHA: ptr.String("sha3")},
+					},
+					{
+						Name:   ptr.String("v2.0.0"),
+						Commit: &github.Commit{SHA: ptr.String("sha2")},
+					},
+					{
+						Name:   ptr.String("v1.0.0"),
+						Commit: &github.Commit{SHA: ptr.String("sha1")},
+					},
+				},
+			},
+			commits: map[string]*github.RepositoryCommit{
+				"sha3": newCommit(time.Now().Add(-time.Hour)),
+				"sha2": newCommit(time.Now().Add(-100 * time.Hour)),
+				"sha1": newCommit(time.Now().Add(-200 * time.Hour)),
+			},
+			pkg: &registry.PackageInfo{
+				RepoOwner: "suzuki-shunsuke",
+				RepoName:  "tfcmt",
+			},
+			version: "v2.0.0",
+		},
 	}
 
 	for _, d := range data {
 		t.Run(d.name, func(t *testing.T) {
 			t.Parallel()
 			ctx := t.Context()
+			rule, err := versiongetter.NewUpdateRule("", nil, "", d.minAge)
+			if err != nil {
+				t.Fatal(err)
+			}
+			versiongetter.SetUpdateRule(d.filters, rule)
 			ghTagClient := versiongetter.NewMockGitHubTagClient(d.tags)
+			ghTagClient.Commits = d.commits
 			ghTagGetter := versiongetter.NewGitHubTag(ghTagClient)
 			version, err := ghTagGetter.Get(ctx, logrus.NewEntry(logrus.New()), d.pkg, d.filters)
 			if err != nil {
@@ -139,3 +181,13 @@ func TestGitHubTagVersionGetter_List(t *testing.T) { //nolint:funlen
 		})
 	}
 }
+
+func newCommit(date time.Time) *github.RepositoryCommit {
+	return &github.RepositoryCommit{
+		Commit: &github.Commit{
+			Committer: &github.CommitAuthor{
+				Date: &github.Timestamp{Time: date},
+			},
+		},
+	}
+}
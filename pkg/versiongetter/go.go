@@ -7,6 +7,7 @@ import (
 
 	"github.com/aquaproj/aqua/v2/pkg/config/registry"
 	"github.com/aquaproj/aqua/v2/pkg/fuzzyfinder"
+	"github.com/aquaproj/aqua/v2/pkg/versiongetter/goproxy"
 	"github.com/hashicorp/go-version"
 	"github.com/sirupsen/logrus"
 )
@@ -23,35 +24,46 @@ func NewGoGetter(gc GoProxyClient) *GoGetter {
 
 type GoProxyClient interface {
 	List(ctx context.Context, path string) ([]string, error)
+	Info(ctx context.Context, path, version string) (*goproxy.Info, error)
 }
 
-func (g *GoGetter) Get(ctx context.Context, logE *logrus.Entry, pkg *registry.PackageInfo, _ []*Filter) (string, error) { //nolint:cyclop
-	versions, err := g.gc.List(ctx, pkg.GoVersionPath)
+// Get returns the latest version of the Go module.
+// Stable versions take precedence over prereleases.
+// The update rule of filters is applied, and the published time is got from the Go module proxy only if min_age is set.
+func (g *GoGetter) Get(ctx context.Context, logE *logrus.Entry, pkg *registry.PackageInfo, filters []*Filter) (string, error) {
+	vs, err := g.gc.List(ctx, pkg.GoVersionPath)
 	if err != nil {
 		return "", fmt.Errorf("list versions: %w", err)
 	}
-	var latest *version.Version
-	var preLatest *version.Version
-	for _, vs := range versions {
-		v, err := version.NewSemver(vs)
+	rule := getUpdateRule(filters)
+	versions := make(version.Collection, 0, len(vs))
+	for _, s := range vs {
+		v, err := version.NewSemver(s)
 		if err != nil {
-			logE.WithError(err).WithField("version", vs).Warn("parse a version")
+			logE.WithError(err).WithField("version", s).Warn("parse a version")
 			continue
 		}
-		if v.Prerelease() == "" && (latest == nil || v.GreaterThan(latest)) {
-			latest = v
+		if !rule.Match(v.Original()) {
 			continue
 		}
-		if v.Prerelease() != "" && (preLatest == nil || v.GreaterThan(preLatest)) {
-			preLatest = v
-			continue
-		}
-	}
-	if latest != nil {
-		return latest.Original(), nil
+		versions = append(versions, v)
 	}
-	if preLatest != nil {
-		return preLatest.Original(), nil
+	// Sort versions in the descending order and move prereleases after stable versions.
+	sort.Sort(sort.Reverse(versions))
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].Prerelease() == "" && versions[j].Prerelease() != ""
+	})
+	for _, v := range versions {
+		if !rule.HasMinAge() {
+			return v.Original(), nil
+		}
+		info, err := g.gc.Info(ctx, pkg.GoVersionPath, v.Original())
+		if err != nil {
+			return "", fmt.Errorf("get the published time of the version: %w", err)
+		}
+		if rule.MatchTime(info.Time) {
+			return v.Original(), nil
+		}
 	}
 	return "", nil
 }
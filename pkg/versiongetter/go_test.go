@@ -0,0 +1,103 @@
+package versiongetter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/config/registry"
+	"github.com/aquaproj/aqua/v2/pkg/versiongetter"
+	"github.com/sirupsen/logrus"
+)
+
+func TestGoGetter_Get(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	now := time.Now()
+	data := []struct {
+		name     string
+		versions map[string]map[string]time.Time
+		rule     func() (*versiongetter.UpdateRule, error)
+		isErr    bool
+		version  string
+	}{
+		{
+			name: "latest",
+			versions: map[string]map[string]time.Time{
+				"golang.org/x/tools/gopls": {
+					"v0.16.0":     now.AddDate(0, -2, 0),
+					"v0.17.0":     now.AddDate(0, -1, 0),
+					"v0.18.0-pre": now,
+				},
+			},
+			version: "v0.17.0",
+		},
+		{
+			name: "update types",
+			versions: map[string]map[string]time.Time{
+				"golang.org/x/tools/gopls": {
+					"v0.16.0": now.AddDate(0, -2, 0),
+					"v0.16.1": now.AddDate(0, -1, 0),
+					"v0.17.0": now,
+				},
+			},
+			rule: func() (*versiongetter.UpdateRule, error) {
+				return versiongetter.NewUpdateRule("v0.16.0", []string{"patch"}, "", "")
+			},
+			version: "v0.16.1",
+		},
+		{
+			name: "min_age",
+			versions: map[string]map[string]time.Time{
+				"golang.org/x/tools/gopls": {
+					"v0.16.0": now.AddDate(0, -2, 0),
+					"v0.16.1": now.AddDate(0, -1, 0),
+					"v0.17.0": now.Add(-time.Hour),
+				},
+			},
+			rule: func() (*versiongetter.UpdateRule, error) {
+				return versiongetter.NewUpdateRule("v0.16.0", nil, "", "72h")
+			},
+			version: "v0.16.1",
+		},
+		{
+			name: "no version is allowed",
+			versions: map[string]map[string]time.Time{
+				"golang.org/x/tools/gopls": {
+					"v0.17.0": now,
+				},
+			},
+			rule: func() (*versiongetter.UpdateRule, error) {
+				return versiongetter.NewUpdateRule("v0.16.0", nil, `Version == "v0.16.5"`, "")
+			},
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			filters := []*versiongetter.Filter{{}}
+			if d.rule != nil {
+				rule, err := d.rule()
+				if err != nil {
+					t.Fatal(err)
+				}
+				versiongetter.SetUpdateRule(filters, rule)
+			}
+			getter := versiongetter.NewGoGetter(versiongetter.NewMockGoProxyClient(d.versions))
+			version, err := getter.Get(t.Context(), logrus.NewEntry(logrus.New()), &registry.PackageInfo{
+				GoVersionPath: "golang.org/x/tools/gopls",
+			}, filters)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if version != d.version {
+				t.Fatalf("wanted %s, got %s", d.version, version)
+			}
+		})
+	}
+}
@@ -2,10 +2,12 @@ package goproxy
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"time"
 )
 
 type Client struct {
@@ -41,3 +43,30 @@ func (c *Client) List(ctx context.Context, path string) ([]string, error) {
 	}
 	return strings.Split(s, "\n"), nil
 }
+
+// Info is the metadata of a module version.
+type Info struct {
+	Version string    `json:"Version"`
+	Time    time.Time `json:"Time"`
+}
+
+// Info gets the metadata of the module version such as the time when the version was published.
+func (c *Client) Info(ctx context.Context, path, version string) (*Info, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://proxy.golang.org/%s/@v/%s.info", path, version), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create a http request: %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send a http request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	info := &Info{}
+	if err := json.NewDecoder(resp.Body).Decode(info); err != nil {
+		return nil, fmt.Errorf("decode a response body as JSON: %w", err)
+	}
+	return info, nil
+}
@@ -3,6 +3,9 @@ package versiongetter
 import (
 	"context"
 	"errors"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/cargo"
 )
 
 type MockCargoClient struct {
@@ -30,3 +33,19 @@ func (g *MockCargoClient) GetLatestVersion(ctx context.Context, crate string) (s
 	}
 	return versions[0], nil
 }
+
+// ListVersionInfos returns versions published a year ago.
+func (g *MockCargoClient) ListVersionInfos(ctx context.Context, crate string) ([]*cargo.PayloadVersion, error) {
+	versions, ok := g.versions[crate]
+	if !ok {
+		return nil, errors.New("crate isn't found")
+	}
+	infos := make([]*cargo.PayloadVersion, len(versions))
+	for i, v := range versions {
+		infos[i] = &cargo.PayloadVersion{
+			Num:       v,
+			CreatedAt: time.Now().AddDate(-1, 0, 0),
+		}
+	}
+	return infos, nil
+}
@@ -17,6 +17,10 @@ func NewMockFuzzyGetter(versions map[string]string) *MockFuzzyGetter {
 	}
 }
 
-func (g *MockFuzzyGetter) Get(ctx context.Context, _ *logrus.Entry, pkg *registry.PackageInfo, currentVersion string, useFinder bool, limit int) string {
-	return g.versions[pkg.GetName()]
+func (g *MockFuzzyGetter) Get(ctx context.Context, _ *logrus.Entry, pkg *registry.PackageInfo, currentVersion string, useFinder bool, limit int, rule *UpdateRule) string {
+	version := g.versions[pkg.GetName()]
+	if !rule.Match(version) {
+		return ""
+	}
+	return version
 }
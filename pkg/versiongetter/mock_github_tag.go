@@ -10,6 +10,8 @@ import (
 
 type MockGitHubTagClient struct {
 	tags map[string][]*github.RepositoryTag
+	// Commits is a map of commit SHAs and commits.
+	Commits map[string]*github.RepositoryCommit
 }
 
 func NewMockGitHubTagClient(tags map[string][]*github.RepositoryTag) *MockGitHubTagClient {
@@ -27,3 +29,11 @@ func (g *MockGitHubTagClient) ListTags(ctx context.Context, owner string, repo s
 	resp := &github.Response{}
 	return tags[opts.Page*opts.PerPage : m], resp, nil
 }
+
+func (g *MockGitHubTagClient) GetCommit(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) (*github.RepositoryCommit, *github.Response, error) {
+	commit, ok := g.Commits[sha]
+	if !ok {
+		return nil, nil, errors.New("commit is not found")
+	}
+	return commit, &github.Response{}, nil
+}
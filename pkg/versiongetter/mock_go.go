@@ -0,0 +1,43 @@
+package versiongetter
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/versiongetter/goproxy"
+)
+
+type MockGoProxyClient struct {
+	// versions maps module paths to versions and their published times.
+	versions map[string]map[string]time.Time
+}
+
+func NewMockGoProxyClient(versions map[string]map[string]time.Time) *MockGoProxyClient {
+	return &MockGoProxyClient{
+		versions: versions,
+	}
+}
+
+func (g *MockGoProxyClient) List(ctx context.Context, path string) ([]string, error) {
+	versions, ok := g.versions[path]
+	if !ok {
+		return nil, errors.New("module isn't found")
+	}
+	arr := make([]string, 0, len(versions))
+	for v := range versions {
+		arr = append(arr, v)
+	}
+	return arr, nil
+}
+
+func (g *MockGoProxyClient) Info(ctx context.Context, path, version string) (*goproxy.Info, error) {
+	t, ok := g.versions[path][version]
+	if !ok {
+		return nil, errors.New("version isn't found")
+	}
+	return &goproxy.Info{
+		Version: version,
+		Time:    t,
+	}, nil
+}
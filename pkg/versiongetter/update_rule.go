@@ -0,0 +1,157 @@
+package versiongetter
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/expr"
+	"github.com/expr-lang/expr/vm"
+)
+
+// Update types of a version.
+const (
+	UpdateTypeMajor = "major"
+	UpdateTypeMinor = "minor"
+	UpdateTypePatch = "patch"
+	UpdateTypeOther = "other"
+)
+
+var errInvalidUpdateType = errors.New("update type must be one of major, minor, patch, and other")
+
+// GetUpdateType classifies the update from currentVersion to newVersion.
+// It returns an empty string if newVersion isn't newer than currentVersion.
+// If either version isn't a semantic version or their prefixes are different, it returns "other".
+func GetUpdateType(currentVersion, newVersion string) string {
+	if currentVersion == newVersion {
+		return ""
+	}
+	cv, cPrefix, err := GetVersionAndPrefix(currentVersion)
+	if err != nil || cv == nil {
+		return UpdateTypeOther
+	}
+	nv, nPrefix, err := GetVersionAndPrefix(newVersion)
+	if err != nil || nv == nil || cPrefix != nPrefix {
+		return UpdateTypeOther
+	}
+	if !nv.GreaterThan(cv) {
+		return ""
+	}
+	cs := cv.Segments()
+	ns := nv.Segments()
+	switch {
+	case ns[0] != cs[0]:
+		return UpdateTypeMajor
+	case ns[1] != cs[1]:
+		return UpdateTypeMinor
+	case ns[2] != cs[2]:
+		return UpdateTypePatch
+	default:
+		return UpdateTypeOther
+	}
+}
+
+// UpdateRule restricts versions a package is updated to.
+// It's created from the field `update` of aqua.yaml.
+type UpdateRule struct {
+	currentVersion string
+	types          []string
+	allowedVersion *vm.Program
+	// minAge is the minimum duration since a version is published.
+	minAge time.Duration
+	now    time.Time
+}
+
+// NewUpdateRule creates an UpdateRule.
+// minAge is a duration such as "72h".
+// It returns nil if none of types, allowedVersion, and minAge is set.
+func NewUpdateRule(currentVersion string, types []string, allowedVersion, minAge string) (*UpdateRule, error) {
+	if len(types) == 0 && allowedVersion == "" && minAge == "" {
+		return nil, nil //nolint:nilnil
+	}
+	for _, t := range types {
+		switch t {
+		case UpdateTypeMajor, UpdateTypeMinor, UpdateTypePatch, UpdateTypeOther:
+		default:
+			return nil, fmt.Errorf("%w: %s", errInvalidUpdateType, t)
+		}
+	}
+	rule := &UpdateRule{
+		currentVersion: currentVersion,
+		types:          types,
+		now:            time.Now(),
+	}
+	if minAge != "" {
+		d, err := time.ParseDuration(minAge)
+		if err != nil {
+			return nil, fmt.Errorf("parse min_age as a duration: %w", err)
+		}
+		rule.minAge = d
+	}
+	if allowedVersion != "" {
+		prog, err := expr.CompileAllowedVersion(allowedVersion)
+		if err != nil {
+			return nil, fmt.Errorf("compile allowed_version: %w", err)
+		}
+		rule.allowedVersion = prog
+	}
+	return rule, nil
+}
+
+// Match returns true if the package is allowed to be updated to the version.
+// If the rule is nil, Match always returns true.
+func (r *UpdateRule) Match(tagName string) bool {
+	if r == nil {
+		return true
+	}
+	if len(r.types) != 0 && !slices.Contains(r.types, GetUpdateType(r.currentVersion, tagName)) {
+		return false
+	}
+	if r.allowedVersion == nil {
+		return true
+	}
+	sv := tagName
+	if _, prefix, err := GetVersionAndPrefix(tagName); err == nil {
+		sv = strings.TrimPrefix(tagName, prefix)
+	}
+	f, err := expr.EvaluateAllowedVersion(r.allowedVersion, tagName, sv)
+	return err == nil && f
+}
+
+// HasMinAge returns true if the rule requires the minimum age of versions.
+// Version getters need to get the published time of versions only if HasMinAge returns true.
+func (r *UpdateRule) HasMinAge() bool {
+	return r != nil && r.minAge > 0
+}
+
+// MatchTime returns true if the version published at the time is older than min_age.
+// If the rule is nil or min_age isn't set, MatchTime always returns true.
+// If the published time is unknown, the version isn't allowed.
+func (r *UpdateRule) MatchTime(publishedAt time.Time) bool {
+	if !r.HasMinAge() {
+		return true
+	}
+	if publishedAt.IsZero() {
+		return false
+	}
+	return r.now.Sub(publishedAt) >= r.minAge
+}
+
+// getUpdateRule returns the update rule of filters.
+func getUpdateRule(filters []*Filter) *UpdateRule {
+	for _, filter := range filters {
+		if filter.UpdateRule != nil {
+			return filter.UpdateRule
+		}
+	}
+	return nil
+}
+
+// SetUpdateRule sets the rule to filters.
+func SetUpdateRule(filters []*Filter, rule *UpdateRule) {
+	for _, filter := range filters {
+		filter.UpdateRule = rule
+	}
+}
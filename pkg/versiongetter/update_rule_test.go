@@ -0,0 +1,112 @@
+package versiongetter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquaproj/aqua/v2/pkg/versiongetter"
+)
+
+func TestGetUpdateType(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name           string
+		currentVersion string
+		newVersion     string
+		exp            string
+	}{
+		{name: "major", currentVersion: "v1.2.3", newVersion: "v2.0.0", exp: "major"},
+		{name: "minor", currentVersion: "v1.2.3", newVersion: "v1.3.0", exp: "minor"},
+		{name: "patch", currentVersion: "v1.2.3", newVersion: "v1.2.4", exp: "patch"},
+		{name: "prerelease", currentVersion: "v1.2.3-rc.1", newVersion: "v1.2.3", exp: "other"},
+		{name: "prefix", currentVersion: "jq-1.6", newVersion: "jq-1.7", exp: "minor"},
+		{name: "prefix is changed", currentVersion: "v1.6.0", newVersion: "jq-1.7", exp: "other"},
+		{name: "not semver", currentVersion: "foo", newVersion: "bar", exp: "other"},
+		{name: "same", currentVersion: "v1.2.3", newVersion: "v1.2.3", exp: ""},
+		{name: "downgrade", currentVersion: "v1.2.3", newVersion: "v1.2.0", exp: ""},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			if s := versiongetter.GetUpdateType(d.currentVersion, d.newVersion); s != d.exp {
+				t.Fatalf("wanted %s, got %s", d.exp, s)
+			}
+		})
+	}
+}
+
+func TestUpdateRule_Match(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name           string
+		currentVersion string
+		types          []string
+		allowedVersion string
+		tag            string
+		exp            bool
+		isErr          bool
+	}{
+		{name: "no rule", currentVersion: "v1.0.0", tag: "v2.0.0", exp: true},
+		{name: "type is allowed", currentVersion: "v1.0.0", types: []string{"minor", "patch"}, tag: "v1.1.0", exp: true},
+		{name: "type isn't allowed", currentVersion: "v1.0.0", types: []string{"minor", "patch"}, tag: "v2.0.0", exp: false},
+		{name: "current version isn't an update", currentVersion: "v1.0.0", types: []string{"patch"}, tag: "v1.0.0", exp: false},
+		{name: "allowed_version", currentVersion: "v1.0.0", allowedVersion: `semver("< 2.0.0")`, tag: "v1.5.0", exp: true},
+		{name: "allowed_version with prefix", currentVersion: "cli-v1.0.0", allowedVersion: `semver("< 2.0.0")`, tag: "cli-v2.0.0", exp: false},
+		{name: "Version", currentVersion: "v1.0.0", allowedVersion: `Version != "v1.5.0"`, tag: "v1.5.0", exp: false},
+		{name: "invalid type", currentVersion: "v1.0.0", types: []string{"foo"}, isErr: true},
+		{name: "invalid allowed_version", currentVersion: "v1.0.0", allowedVersion: `Version ==`, isErr: true},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			rule, err := versiongetter.NewUpdateRule(d.currentVersion, d.types, d.allowedVersion, "")
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if f := rule.Match(d.tag); f != d.exp {
+				t.Fatalf("wanted %v, got %v", d.exp, f)
+			}
+		})
+	}
+}
+
+func TestUpdateRule_MatchTime(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		name        string
+		minAge      string
+		publishedAt time.Time
+		exp         bool
+		isErr       bool
+	}{
+		{name: "no min_age", publishedAt: time.Now(), exp: true},
+		{name: "old enough", minAge: "72h", publishedAt: time.Now().Add(-100 * time.Hour), exp: true},
+		{name: "too new", minAge: "72h", publishedAt: time.Now().Add(-time.Hour), exp: false},
+		{name: "unknown published time", minAge: "72h", exp: false},
+		{name: "invalid min_age", minAge: "3 days", isErr: true},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			t.Parallel()
+			rule, err := versiongetter.NewUpdateRule("v1.0.0", nil, "", d.minAge)
+			if err != nil {
+				if d.isErr {
+					return
+				}
+				t.Fatal(err)
+			}
+			if d.isErr {
+				t.Fatal("error must be returned")
+			}
+			if f := rule.MatchTime(d.publishedAt); f != d.exp {
+				t.Fatalf("wanted %v, got %v", d.exp, f)
+			}
+		})
+	}
+}